@@ -17,12 +17,19 @@ package indySDK
 */
 import "C"
 import (
+	"context"
 	"github.com/joyride9999/IndySdkGoBindings/anoncreds"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
 	"unsafe"
 )
 
 // CreateRevocationState Create revocation state for a credential in the particular time moment
 func CreateRevocationState(blobReaderHandle int, revRegDefJson string, revRegDeltaJson string, timestamp uint64, credRevId string) (revStateJson string, err error) {
+	return CreateRevocationStateCtx(context.Background(), blobReaderHandle, revRegDefJson, revRegDeltaJson, timestamp, credRevId)
+}
+
+// CreateRevocationStateCtx is like CreateRevocationState but returns ctx.Err() if ctx is done before libindy answers.
+func CreateRevocationStateCtx(ctx context.Context, blobReaderHandle int, revRegDefJson string, revRegDeltaJson string, timestamp uint64, credRevId string) (revStateJson string, err error) {
 
 	upRevRegDefJson := unsafe.Pointer(C.CString(revRegDefJson))
 	upRevRegDeltaJson := unsafe.Pointer(C.CString(revRegDeltaJson))
@@ -32,7 +39,53 @@ func CreateRevocationState(blobReaderHandle int, revRegDefJson string, revRegDel
 	defer C.free(upCredRevId)
 
 	channel := anoncreds.CreateRevocationState(blobReaderHandle, upRevRegDefJson, upRevRegDeltaJson, timestamp, upCredRevId)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Results[0].(string), result.Error
+}
+
+// UpdateRevocationState updates the revocation state of a credential with the delta since the state timestamp
+func UpdateRevocationState(blobReaderHandle int, revStateJson string, revRegDefJson string, revRegDeltaJson string, timestamp uint64, credRevId string) (updatedRevStateJson string, err error) {
+	return UpdateRevocationStateCtx(context.Background(), blobReaderHandle, revStateJson, revRegDefJson, revRegDeltaJson, timestamp, credRevId)
+}
+
+// UpdateRevocationStateCtx is like UpdateRevocationState but returns ctx.Err() if ctx is done before libindy answers.
+func UpdateRevocationStateCtx(ctx context.Context, blobReaderHandle int, revStateJson string, revRegDefJson string, revRegDeltaJson string, timestamp uint64, credRevId string) (updatedRevStateJson string, err error) {
+
+	upRevStateJson := unsafe.Pointer(C.CString(revStateJson))
+	upRevRegDefJson := unsafe.Pointer(C.CString(revRegDefJson))
+	upRevRegDeltaJson := unsafe.Pointer(C.CString(revRegDeltaJson))
+	upCredRevId := unsafe.Pointer(C.CString(credRevId))
+	defer C.free(upRevStateJson)
+	defer C.free(upRevRegDefJson)
+	defer C.free(upRevRegDeltaJson)
+	defer C.free(upCredRevId)
+
+	channel := anoncreds.UpdateRevocationState(blobReaderHandle, upRevStateJson, upRevRegDefJson, upRevRegDeltaJson, timestamp, upCredRevId)
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Results[0].(string), result.Error
+}
+
+// IssuerMergeRevocationRegistryDeltas merges two revocation registry deltas into one, other must start at the accumulator rev reg delta ends with
+func IssuerMergeRevocationRegistryDeltas(revRegDeltaJson string, otherRevRegDeltaJson string) (mergedRevRegDeltaJson string, err error) {
+	return IssuerMergeRevocationRegistryDeltasCtx(context.Background(), revRegDeltaJson, otherRevRegDeltaJson)
+}
+
+// IssuerMergeRevocationRegistryDeltasCtx is like IssuerMergeRevocationRegistryDeltas but returns ctx.Err() if ctx is done before libindy answers.
+func IssuerMergeRevocationRegistryDeltasCtx(ctx context.Context, revRegDeltaJson string, otherRevRegDeltaJson string) (mergedRevRegDeltaJson string, err error) {
+
+	upRevRegDeltaJson := unsafe.Pointer(C.CString(revRegDeltaJson))
+	upOtherRevRegDeltaJson := unsafe.Pointer(C.CString(otherRevRegDeltaJson))
+	defer C.free(upRevRegDeltaJson)
+	defer C.free(upOtherRevRegDeltaJson)
+
+	channel := anoncreds.IssuerMergeRevocationRegistryDeltas(upRevRegDeltaJson, upOtherRevRegDeltaJson)
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -41,6 +94,11 @@ func CreateRevocationState(blobReaderHandle int, revRegDefJson string, revRegDel
 
 // IssuerRevokeCredential   Revoke a credential identified by a cred_revoc_id (returned by issuer_create_credential).
 func IssuerRevokeCredential(issuerHandle int, blobReaderHandle int, revRegId string, credRevId string) (revRegDeltaJson string, err error) {
+	return IssuerRevokeCredentialCtx(context.Background(), issuerHandle, blobReaderHandle, revRegId, credRevId)
+}
+
+// IssuerRevokeCredentialCtx is like IssuerRevokeCredential but returns ctx.Err() if ctx is done before libindy answers.
+func IssuerRevokeCredentialCtx(ctx context.Context, issuerHandle int, blobReaderHandle int, revRegId string, credRevId string) (revRegDeltaJson string, err error) {
 
 	upRevRegId := unsafe.Pointer(C.CString(revRegId))
 	upCredRevId := unsafe.Pointer(C.CString(credRevId))
@@ -48,7 +106,7 @@ func IssuerRevokeCredential(issuerHandle int, blobReaderHandle int, revRegId str
 	defer C.free(upCredRevId)
 
 	channel := anoncreds.IssuerRevokeCredential(issuerHandle, blobReaderHandle, upRevRegId, upCredRevId)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -57,6 +115,12 @@ func IssuerRevokeCredential(issuerHandle int, blobReaderHandle int, revRegId str
 
 func IssuerCreateAndStoreRevocReg(wh int, issuerDid string, revocDefType string, tag string, credDefId string,
 	configJson string, blobHandle int) (revocRegId string, revocRegDefJson string, revocRegEntryJson string, err error) {
+	return IssuerCreateAndStoreRevocRegCtx(context.Background(), wh, issuerDid, revocDefType, tag, credDefId, configJson, blobHandle)
+}
+
+// IssuerCreateAndStoreRevocRegCtx is like IssuerCreateAndStoreRevocReg but returns ctx.Err() if ctx is done before libindy answers.
+func IssuerCreateAndStoreRevocRegCtx(ctx context.Context, wh int, issuerDid string, revocDefType string, tag string, credDefId string,
+	configJson string, blobHandle int) (revocRegId string, revocRegDefJson string, revocRegEntryJson string, err error) {
 
 	upIssuerDid := unsafe.Pointer(C.CString(issuerDid))
 	defer C.free(upIssuerDid)
@@ -70,7 +134,7 @@ func IssuerCreateAndStoreRevocReg(wh int, issuerDid string, revocDefType string,
 	defer C.free(upConfigJson)
 
 	channel := anoncreds.CreateAndStoreRevocReg(wh, upIssuerDid, upRevocDefType, upTag, upCredDefId, upConfigJson, blobHandle)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", "", "", result.Error
 	}
@@ -78,6 +142,11 @@ func IssuerCreateAndStoreRevocReg(wh int, issuerDid string, revocDefType string,
 }
 
 func IssuerCreateSchema(submitterDid string, name string, version string, attrs string) (schemaId string, schemaJson string, err error) {
+	return IssuerCreateSchemaCtx(context.Background(), submitterDid, name, version, attrs)
+}
+
+// IssuerCreateSchemaCtx is like IssuerCreateSchema but returns ctx.Err() if ctx is done before libindy answers.
+func IssuerCreateSchemaCtx(ctx context.Context, submitterDid string, name string, version string, attrs string) (schemaId string, schemaJson string, err error) {
 
 	upSubmitterDid := unsafe.Pointer(C.CString(submitterDid))
 	defer C.free(upSubmitterDid)
@@ -89,7 +158,7 @@ func IssuerCreateSchema(submitterDid string, name string, version string, attrs
 	defer C.free(upAttrs)
 
 	channel := anoncreds.IssuerCreateSchema(upSubmitterDid, upName, upVersion, upAttrs)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", "", result.Error
 	}
@@ -97,6 +166,11 @@ func IssuerCreateSchema(submitterDid string, name string, version string, attrs
 }
 
 func IssuerCreateAndStoreCredentialDefinition(wh int, did string, schema string, tag string, signatureType string, configJs string) (credDefId string, credDefJson string, err error) {
+	return IssuerCreateAndStoreCredentialDefinitionCtx(context.Background(), wh, did, schema, tag, signatureType, configJs)
+}
+
+// IssuerCreateAndStoreCredentialDefinitionCtx is like IssuerCreateAndStoreCredentialDefinition but returns ctx.Err() if ctx is done before libindy answers.
+func IssuerCreateAndStoreCredentialDefinitionCtx(ctx context.Context, wh int, did string, schema string, tag string, signatureType string, configJs string) (credDefId string, credDefJson string, err error) {
 
 	upDid := unsafe.Pointer(C.CString(did))
 	defer C.free(upDid)
@@ -110,7 +184,7 @@ func IssuerCreateAndStoreCredentialDefinition(wh int, did string, schema string,
 	defer C.free(upConfigJson)
 
 	channel := anoncreds.IssuerCreateAndStoreCredentialDef(wh, upDid, upSchema, upTag, upSignatureType, upConfigJson)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", "", result.Error
 	}
@@ -118,6 +192,11 @@ func IssuerCreateAndStoreCredentialDefinition(wh int, did string, schema string,
 }
 
 func IssuerRotateCredentialDefStart(walletHandle int, credDefID string, configJson string) (string, error) {
+	return IssuerRotateCredentialDefStartCtx(context.Background(), walletHandle, credDefID, configJson)
+}
+
+// IssuerRotateCredentialDefStartCtx is like IssuerRotateCredentialDefStart but returns ctx.Err() if ctx is done before libindy answers.
+func IssuerRotateCredentialDefStartCtx(ctx context.Context, walletHandle int, credDefID string, configJson string) (string, error) {
 
 	upCredDefId := unsafe.Pointer(C.CString(credDefID))
 	defer C.free(upCredDefId)
@@ -125,7 +204,7 @@ func IssuerRotateCredentialDefStart(walletHandle int, credDefID string, configJs
 	defer C.free(upConfigJson)
 
 	channel := anoncreds.IssuerRotateCredentialDefStart(walletHandle, upCredDefId, upConfigJson)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -133,21 +212,31 @@ func IssuerRotateCredentialDefStart(walletHandle int, credDefID string, configJs
 }
 
 func IssuerRotateCredentialDefApply(walletHandle int, credDefID string) error {
+	return IssuerRotateCredentialDefApplyCtx(context.Background(), walletHandle, credDefID)
+}
+
+// IssuerRotateCredentialDefApplyCtx is like IssuerRotateCredentialDefApply but returns ctx.Err() if ctx is done before libindy answers.
+func IssuerRotateCredentialDefApplyCtx(ctx context.Context, walletHandle int, credDefID string) error {
 	upCredDefId := unsafe.Pointer(C.CString(credDefID))
 	defer C.free(upCredDefId)
 
 	channel := anoncreds.IssuerRotateCredentialDefApply(walletHandle, upCredDefId)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // IssuerCreateCredentialOffer Create credential offer
 func IssuerCreateCredentialOffer(wh int, credDefId string) (credOffer string, err error) {
+	return IssuerCreateCredentialOfferCtx(context.Background(), wh, credDefId)
+}
+
+// IssuerCreateCredentialOfferCtx is like IssuerCreateCredentialOffer but returns ctx.Err() if ctx is done before libindy answers.
+func IssuerCreateCredentialOfferCtx(ctx context.Context, wh int, credDefId string) (credOffer string, err error) {
 	upCredDefId := unsafe.Pointer(C.CString(credDefId))
 	defer C.free(upCredDefId)
 
 	channel := anoncreds.IssuerCreateCredentialOffer(wh, upCredDefId)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -156,12 +245,17 @@ func IssuerCreateCredentialOffer(wh int, credDefId string) (credOffer string, er
 
 // ProverCreateMasterSecret creates a master secret with a given name and stores it in the wallet.
 func ProverCreateMasterSecret(wh int, masterSecretName string) (idMasterSecret string, err error) {
+	return ProverCreateMasterSecretCtx(context.Background(), wh, masterSecretName)
+}
+
+// ProverCreateMasterSecretCtx is like ProverCreateMasterSecret but returns ctx.Err() if ctx is done before libindy answers.
+func ProverCreateMasterSecretCtx(ctx context.Context, wh int, masterSecretName string) (idMasterSecret string, err error) {
 
 	upSecretName := unsafe.Pointer(GetOptionalValue(masterSecretName))
 	defer C.free(upSecretName)
 
 	channel := anoncreds.ProverCreateMasterSecret(wh, upSecretName)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -171,6 +265,12 @@ func ProverCreateMasterSecret(wh int, masterSecretName string) (idMasterSecret s
 // ProverCreateCredentialRequest Creates a credential request for the given credential offer.
 func ProverCreateCredentialRequest(wh int, proverDID string, credOfferJSON string, credDefinitionJSON string,
 	masterSecretID string) (credentialRequest string, credentialRequestMetadata string, err error) {
+	return ProverCreateCredentialRequestCtx(context.Background(), wh, proverDID, credOfferJSON, credDefinitionJSON, masterSecretID)
+}
+
+// ProverCreateCredentialRequestCtx is like ProverCreateCredentialRequest but returns ctx.Err() if ctx is done before libindy answers.
+func ProverCreateCredentialRequestCtx(ctx context.Context, wh int, proverDID string, credOfferJSON string, credDefinitionJSON string,
+	masterSecretID string) (credentialRequest string, credentialRequestMetadata string, err error) {
 
 	upProverDid := unsafe.Pointer(C.CString(proverDID))
 	defer C.free(upProverDid)
@@ -182,7 +282,7 @@ func ProverCreateCredentialRequest(wh int, proverDID string, credOfferJSON strin
 	defer C.free(upMasterSecretId)
 
 	channel := anoncreds.ProverCreateCredentialRequest(wh, upProverDid, upCredOfferJson, upCredDefJson, upMasterSecretId)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", "", result.Error
 	}
@@ -192,6 +292,12 @@ func ProverCreateCredentialRequest(wh int, proverDID string, credOfferJSON strin
 // IssuerCreateCredential Creates a credential
 func IssuerCreateCredential(whIssuer int, credOfferJson, credRequestJson, credValueJson, revocRegistryId string,
 	blobHandle int) (credentialJson string, credentialRevocationId string, revocationRegistryDeltaJson string, err error) {
+	return IssuerCreateCredentialCtx(context.Background(), whIssuer, credOfferJson, credRequestJson, credValueJson, revocRegistryId, blobHandle)
+}
+
+// IssuerCreateCredentialCtx is like IssuerCreateCredential but returns ctx.Err() if ctx is done before libindy answers.
+func IssuerCreateCredentialCtx(ctx context.Context, whIssuer int, credOfferJson, credRequestJson, credValueJson, revocRegistryId string,
+	blobHandle int) (credentialJson string, credentialRevocationId string, revocationRegistryDeltaJson string, err error) {
 
 	upCredOfferJson := unsafe.Pointer(C.CString(credOfferJson))
 	defer C.free(upCredOfferJson)
@@ -203,7 +309,7 @@ func IssuerCreateCredential(whIssuer int, credOfferJson, credRequestJson, credVa
 	defer C.free(upRevRegId)
 
 	channel := anoncreds.IssuerCreateCredential(whIssuer, upCredOfferJson, upCredRequestJson, upCredValueJson, upRevRegId, blobHandle)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", "", "", result.Error
 	}
@@ -212,6 +318,11 @@ func IssuerCreateCredential(whIssuer int, credOfferJson, credRequestJson, credVa
 
 // ProverStoreCredential stores the credential in the wallet
 func ProverStoreCredential(whProver int, credentialIdOptional, credRequestMetadataJson, credJson, credDefJson, revocRegDefJsonOptional string) (credentialId string, err error) {
+	return ProverStoreCredentialCtx(context.Background(), whProver, credentialIdOptional, credRequestMetadataJson, credJson, credDefJson, revocRegDefJsonOptional)
+}
+
+// ProverStoreCredentialCtx is like ProverStoreCredential but returns ctx.Err() if ctx is done before libindy answers.
+func ProverStoreCredentialCtx(ctx context.Context, whProver int, credentialIdOptional, credRequestMetadataJson, credJson, credDefJson, revocRegDefJsonOptional string) (credentialId string, err error) {
 
 	upCredId := unsafe.Pointer(GetOptionalValue(credentialIdOptional))
 	defer C.free(upCredId)
@@ -225,7 +336,7 @@ func ProverStoreCredential(whProver int, credentialIdOptional, credRequestMetada
 	defer C.free(upRevRegDef)
 
 	channel := anoncreds.ProverStoreCredential(whProver, upCredId, upCredRequestMetadataJson, upCredentialJson, upCredDefJson, upRevRegDef)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -234,22 +345,32 @@ func ProverStoreCredential(whProver int, credentialIdOptional, credRequestMetada
 
 // ProverDeleteCredential deletes identified credential from wallet
 func ProverDeleteCredential(walletHandle int, credentialID string) error {
+	return ProverDeleteCredentialCtx(context.Background(), walletHandle, credentialID)
+}
+
+// ProverDeleteCredentialCtx is like ProverDeleteCredential but returns ctx.Err() if ctx is done before libindy answers.
+func ProverDeleteCredentialCtx(ctx context.Context, walletHandle int, credentialID string) error {
 
 	upCredentialId := unsafe.Pointer(C.CString(credentialID))
 	defer C.free(upCredentialId)
 
 	channel := anoncreds.ProverDeleteCredential(walletHandle, upCredentialId)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 func ProverGetCredentials(walletHandle int, filterJson string) (string, error) {
+	return ProverGetCredentialsCtx(context.Background(), walletHandle, filterJson)
+}
+
+// ProverGetCredentialsCtx is like ProverGetCredentials but returns ctx.Err() if ctx is done before libindy answers.
+func ProverGetCredentialsCtx(ctx context.Context, walletHandle int, filterJson string) (string, error) {
 
 	upFilter := unsafe.Pointer(C.CString(filterJson))
 	defer C.free(upFilter)
 
 	channel := anoncreds.ProverGetCredentials(walletHandle, upFilter)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -258,8 +379,13 @@ func ProverGetCredentials(walletHandle int, filterJson string) (string, error) {
 
 // GenerateNonce nonce
 func GenerateNonce() (nonce string, err error) {
+	return GenerateNonceCtx(context.Background())
+}
+
+// GenerateNonceCtx is like GenerateNonce but returns ctx.Err() if ctx is done before libindy answers.
+func GenerateNonceCtx(ctx context.Context) (nonce string, err error) {
 	channel := anoncreds.GenerateNonce()
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -268,6 +394,11 @@ func GenerateNonce() (nonce string, err error) {
 
 // ProverSearchForCredentialForProofReq search for credential and returns a search handle
 func ProverSearchForCredentialForProofReq(wh int, proofRequestJson, extraQueryJson string) (searchHandle int, err error) {
+	return ProverSearchForCredentialForProofReqCtx(context.Background(), wh, proofRequestJson, extraQueryJson)
+}
+
+// ProverSearchForCredentialForProofReqCtx is like ProverSearchForCredentialForProofReq but returns ctx.Err() if ctx is done before libindy answers.
+func ProverSearchForCredentialForProofReqCtx(ctx context.Context, wh int, proofRequestJson, extraQueryJson string) (searchHandle int, err error) {
 
 	upProofRequest := unsafe.Pointer(C.CString(proofRequestJson))
 	defer C.free(upProofRequest)
@@ -276,7 +407,7 @@ func ProverSearchForCredentialForProofReq(wh int, proofRequestJson, extraQueryJs
 	defer C.free(upExtraQuery)
 
 	channel := anoncreds.ProverSearchForCredentialsForProofReq(wh, upProofRequest, upExtraQuery)
-	result := <-channel
+	result := indyUtils.WaitForHandle(ctx, channel, func(sh int) { ProverCloseCredentialsSearchForProofReq(sh) })
 	if result.Error != nil {
 		return -1, result.Error
 	}
@@ -285,8 +416,13 @@ func ProverSearchForCredentialForProofReq(wh int, proofRequestJson, extraQueryJs
 
 // ProverCloseCredentialsSearchForProofReq close handle
 func ProverCloseCredentialsSearchForProofReq(searchHandle int) (err error) {
+	return ProverCloseCredentialsSearchForProofReqCtx(context.Background(), searchHandle)
+}
+
+// ProverCloseCredentialsSearchForProofReqCtx is like ProverCloseCredentialsSearchForProofReq but returns ctx.Err() if ctx is done before libindy answers.
+func ProverCloseCredentialsSearchForProofReqCtx(ctx context.Context, searchHandle int) (err error) {
 	channel := anoncreds.ProverCloseCredentialsSearchForProofReq(searchHandle)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -295,12 +431,17 @@ func ProverCloseCredentialsSearchForProofReq(searchHandle int) (err error) {
 
 // ProverFetchCredentialsForProofReq - gets credential out of a search handle
 func ProverFetchCredentialsForProofReq(sh int, itemReferent string, count int) (credentialJson string, err error) {
+	return ProverFetchCredentialsForProofReqCtx(context.Background(), sh, itemReferent, count)
+}
+
+// ProverFetchCredentialsForProofReqCtx is like ProverFetchCredentialsForProofReq but returns ctx.Err() if ctx is done before libindy answers.
+func ProverFetchCredentialsForProofReqCtx(ctx context.Context, sh int, itemReferent string, count int) (credentialJson string, err error) {
 
 	upItemReferent := unsafe.Pointer(C.CString(itemReferent))
 	defer C.free(upItemReferent)
 
 	channel := anoncreds.ProverFetchCredentialsForProofReq(sh, upItemReferent, count)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -308,6 +449,11 @@ func ProverFetchCredentialsForProofReq(sh int, itemReferent string, count int) (
 }
 
 func ProverCreateProof(wh int, proofRequestJson, requestedCredentialsJson, masterSecretId, schemasForAttrsJson, credentialDefsForAttrsJson, revStatesJson string) (proofJson string, err error) {
+	return ProverCreateProofCtx(context.Background(), wh, proofRequestJson, requestedCredentialsJson, masterSecretId, schemasForAttrsJson, credentialDefsForAttrsJson, revStatesJson)
+}
+
+// ProverCreateProofCtx is like ProverCreateProof but returns ctx.Err() if ctx is done before libindy answers.
+func ProverCreateProofCtx(ctx context.Context, wh int, proofRequestJson, requestedCredentialsJson, masterSecretId, schemasForAttrsJson, credentialDefsForAttrsJson, revStatesJson string) (proofJson string, err error) {
 
 	upProofRequest := unsafe.Pointer(C.CString(proofRequestJson))
 	defer C.free(upProofRequest)
@@ -329,7 +475,7 @@ func ProverCreateProof(wh int, proofRequestJson, requestedCredentialsJson, maste
 	defer C.free(upRevStates)
 
 	channel := anoncreds.ProverCreateProof(wh, upProofRequest, upRequestedCredentials, upMasterSecretId, upSchemasForAttrs, upCredentialDefsForAttrs, upRevStates)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -337,6 +483,11 @@ func ProverCreateProof(wh int, proofRequestJson, requestedCredentialsJson, maste
 }
 
 func VerifierVerifyProof(proofRequestJson, proofJson, schemasJson, credDefsJson, revRegDefsJson, revRegsJson string) (valid bool, err error) {
+	return VerifierVerifyProofCtx(context.Background(), proofRequestJson, proofJson, schemasJson, credDefsJson, revRegDefsJson, revRegsJson)
+}
+
+// VerifierVerifyProofCtx is like VerifierVerifyProof but returns ctx.Err() if ctx is done before libindy answers.
+func VerifierVerifyProofCtx(ctx context.Context, proofRequestJson, proofJson, schemasJson, credDefsJson, revRegDefsJson, revRegsJson string) (valid bool, err error) {
 
 	upProofRequest := unsafe.Pointer(C.CString(proofRequestJson))
 	defer C.free(upProofRequest)
@@ -361,7 +512,7 @@ func VerifierVerifyProof(proofRequestJson, proofJson, schemasJson, credDefsJson,
 	defer C.free(upRevRegs)
 
 	channel := anoncreds.VerifierVerifyProof(upProofRequest, upProof, upSchemas, upCredDefs, upRevRegDefs, upRevRegs)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return false, result.Error
 	}
@@ -376,12 +527,17 @@ func VerifierVerifyProof(proofRequestJson, proofJson, schemasJson, credDefsJson,
 }
 
 func ProverGetCredential(wh int, credentialId string) (credentialJson string, err error) {
+	return ProverGetCredentialCtx(context.Background(), wh, credentialId)
+}
+
+// ProverGetCredentialCtx is like ProverGetCredential but returns ctx.Err() if ctx is done before libindy answers.
+func ProverGetCredentialCtx(ctx context.Context, wh int, credentialId string) (credentialJson string, err error) {
 
 	upCredentialId := unsafe.Pointer(C.CString(credentialId))
 	defer C.free(upCredentialId)
 
 	channel := anoncreds.ProverGetCredential(wh, upCredentialId)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -390,12 +546,17 @@ func ProverGetCredential(wh int, credentialId string) (credentialJson string, er
 }
 
 func ProverGetCredentialsForProofRequest(walletHandle int, proofReqJson string) (credentialJson string, err error) {
+	return ProverGetCredentialsForProofRequestCtx(context.Background(), walletHandle, proofReqJson)
+}
+
+// ProverGetCredentialsForProofRequestCtx is like ProverGetCredentialsForProofRequest but returns ctx.Err() if ctx is done before libindy answers.
+func ProverGetCredentialsForProofRequestCtx(ctx context.Context, walletHandle int, proofReqJson string) (credentialJson string, err error) {
 
 	upProofReq := unsafe.Pointer(C.CString(proofReqJson))
 	defer C.free(upProofReq)
 
 	channel := anoncreds.ProverGetCredentialsForProofReq(walletHandle, upProofReq)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -403,12 +564,17 @@ func ProverGetCredentialsForProofRequest(walletHandle int, proofReqJson string)
 }
 
 func ProverSearchCredentials(walletHandle int, queryJson string) (searchHandle int, totalCount int, err error) {
+	return ProverSearchCredentialsCtx(context.Background(), walletHandle, queryJson)
+}
+
+// ProverSearchCredentialsCtx is like ProverSearchCredentials but returns ctx.Err() if ctx is done before libindy answers.
+func ProverSearchCredentialsCtx(ctx context.Context, walletHandle int, queryJson string) (searchHandle int, totalCount int, err error) {
 
 	upQuery := unsafe.Pointer(C.CString(queryJson))
 	defer C.free(upQuery)
 
 	channel := anoncreds.ProverSearchCredentials(walletHandle, upQuery)
-	result := <-channel
+	result := indyUtils.WaitForHandle(ctx, channel, func(sh int) { ProverCloseCredentialsSearch(sh) })
 	if result.Error != nil {
 		return 0, 0, result.Error
 	}
@@ -416,20 +582,42 @@ func ProverSearchCredentials(walletHandle int, queryJson string) (searchHandle i
 }
 
 func ProverFetchCredentials(searchHandle int, totalCount int) (credentialsJson string, err error) {
+	return ProverFetchCredentialsCtx(context.Background(), searchHandle, totalCount)
+}
+
+// ProverFetchCredentialsCtx is like ProverFetchCredentials but returns ctx.Err() if ctx is done before libindy answers.
+func ProverFetchCredentialsCtx(ctx context.Context, searchHandle int, totalCount int) (credentialsJson string, err error) {
 	channel := anoncreds.ProverFetchCredentials(searchHandle, totalCount)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
 	return result.Results[0].(string), result.Error
 }
 
+// ProverCloseCredentialsSearch closes a search opened by ProverSearchCredentials
+func ProverCloseCredentialsSearch(searchHandle int) error {
+	return ProverCloseCredentialsSearchCtx(context.Background(), searchHandle)
+}
+
+// ProverCloseCredentialsSearchCtx is like ProverCloseCredentialsSearch but returns ctx.Err() if ctx is done before libindy answers.
+func ProverCloseCredentialsSearchCtx(ctx context.Context, searchHandle int) error {
+	channel := anoncreds.ProverCloseCredentialsSearch(searchHandle)
+	result := indyUtils.WaitForResult(ctx, channel)
+	return result.Error
+}
+
 func ToUnqualified(entity string) (res string, err error) {
+	return ToUnqualifiedCtx(context.Background(), entity)
+}
+
+// ToUnqualifiedCtx is like ToUnqualified but returns ctx.Err() if ctx is done before libindy answers.
+func ToUnqualifiedCtx(ctx context.Context, entity string) (res string, err error) {
 
 	upEntity := unsafe.Pointer(C.CString(entity))
 	defer C.free(upEntity)
 	channel := anoncreds.ToUnqualified(upEntity)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -66,6 +66,12 @@ extern void createAndStoreRevocRegCB(indy_handle_t, indy_error_t, char*, char*,
 typedef void (*cb_createRevocationState)(indy_handle_t, indy_error_t, char*);
 extern void createRevocationStateCB(indy_handle_t, indy_error_t, char*);
 
+typedef void (*cb_updateRevocationState)(indy_handle_t, indy_error_t, char*);
+extern void updateRevocationStateCB(indy_handle_t, indy_error_t, char*);
+
+typedef void (*cb_issuerMergeRevocationRegistryDeltas)(indy_handle_t, indy_error_t, char*);
+extern void issuerMergeRevocationRegistryDeltasCB(indy_handle_t, indy_error_t, char*);
+
 typedef void (*cb_proverCloseCredentialsSearchForProofReq)(indy_handle_t, indy_error_t);
 extern void proverCloseCredentialsSearchForProofReqCB(indy_handle_t, indy_error_t);
 
@@ -87,13 +93,15 @@ extern void proverSearchCredentialsCB(indy_handle_t, indy_error_t, indy_handle_t
 typedef void (*cb_proverFetchCredentials)(indy_handle_t, indy_error_t, char*);
 extern void proverFetchCredentialsCB(indy_handle_t, indy_error_t, char*);
 
+typedef void (*cb_proverCloseCredentialsSearch)(indy_handle_t, indy_error_t);
+extern void proverCloseCredentialsSearchCB(indy_handle_t, indy_error_t);
+
 typedef void (*cb_toUnqualified)(indy_handle_t, indy_error_t, char*);
 extern void toUnqualifiedCB(indy_handle_t, indy_error_t, char*);
 */
 import "C"
 import (
 	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
-	"errors"
 	"unsafe"
 )
 
@@ -106,8 +114,8 @@ func issuerRevokeCredentialCB(commandHandle C.indy_handle_t, indyError C.indy_er
 					string(C.GoString(revocRegDeltaJson)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -140,8 +148,8 @@ func IssuerRevokeCredential(wh int, bh int, revRegId unsafe.Pointer, credRevId u
 		(C.cb_issuerRevokeCredential)(unsafe.Pointer(C.issuerRevokeCredentialCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -154,8 +162,8 @@ func proverCloseCredentialsSearchForProofReqCB(commandHandle C.indy_handle_t, in
 		indyUtils.RemoveFuture((int)(commandHandle),
 			indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -175,8 +183,8 @@ func ProverCloseCredentialsSearchForProofReq(searchHandle int) chan indyUtils.In
 		(C.cb_proverCloseCredentialsSearchForProofReq)(unsafe.Pointer(C.proverCloseCredentialsSearchForProofReqCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -192,8 +200,8 @@ func createRevocationStateCB(commandHandle C.indy_handle_t, indyError C.indy_err
 					string(C.GoString(revocStateJson)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -225,8 +233,101 @@ func CreateRevocationState(blobReaderHandle int, revRegDefJson unsafe.Pointer, r
 		(C.cb_createRevocationState)(unsafe.Pointer(C.createRevocationStateCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
+		return future
+	}
+
+	return future
+}
+
+//export updateRevocationStateCB
+func updateRevocationStateCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, updatedRevStateJson *C.char) {
+	if indyError == 0 {
+		indyUtils.RemoveFuture((int)(commandHandle),
+			indyUtils.IndyResult{Error: nil,
+				Results: []interface{}{
+					string(C.GoString(updatedRevStateJson)),
+				}})
+	} else {
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
+	}
+}
+
+// UpdateRevocationState        Update revocation state of a credential to a newer time moment, only the delta since the state timestamp is needed.
+func UpdateRevocationState(blobReaderHandle int, revStateJson unsafe.Pointer, revRegDefJson unsafe.Pointer, revRegDeltaJson unsafe.Pointer, timestamp uint64, credRevId unsafe.Pointer) chan indyUtils.IndyResult {
+
+	// Prepare the call parameters
+	handle, future := indyUtils.NewFutureCommand()
+	commandHandle := (C.indy_handle_t)(handle)
+
+	/*
+			:param blob_storage_reader_handle: configuration of blob storage reader handle that will allow to read revocation tails
+			:param rev_state_json: revocation registry state json
+		    :param rev_reg_def_json: revocation registry definition json
+		    :param rev_reg_delta_json: revocation registry definition delta json
+		    :param timestamp: time represented as a total number of seconds from Unix Epoch
+		    :param cred_rev_id: user credential revocation id in revocation registry
+		    :return: revocation state json {
+		         "rev_reg": <revocation registry>,
+		         "witness": <witness>,
+		         "timestamp" : integer
+		    }
+	*/
+	res := C.indy_update_revocation_state(commandHandle,
+		(C.indy_handle_t)(blobReaderHandle),
+		(*C.char)(revStateJson),
+		(*C.char)(revRegDefJson),
+		(*C.char)(revRegDeltaJson),
+		C.ulonglong(timestamp),
+		(*C.char)(credRevId),
+		(C.cb_updateRevocationState)(unsafe.Pointer(C.updateRevocationStateCB)))
+
+	if res != 0 {
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
+		return future
+	}
+
+	return future
+}
+
+//export issuerMergeRevocationRegistryDeltasCB
+func issuerMergeRevocationRegistryDeltasCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, mergedRevRegDelta *C.char) {
+	if indyError == 0 {
+		indyUtils.RemoveFuture((int)(commandHandle),
+			indyUtils.IndyResult{Error: nil,
+				Results: []interface{}{
+					string(C.GoString(mergedRevRegDelta)),
+				}})
+	} else {
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
+	}
+}
+
+// IssuerMergeRevocationRegistryDeltas       Merge two revocation registry deltas (returned by issuer_create_credential or issuer_revoke_credential) to accumulate common delta.
+//    Send common delta to ledger to reduce the load.
+func IssuerMergeRevocationRegistryDeltas(revRegDeltaJson unsafe.Pointer, otherRevRegDeltaJson unsafe.Pointer) chan indyUtils.IndyResult {
+
+	// Prepare the call parameters
+	handle, future := indyUtils.NewFutureCommand()
+	commandHandle := (C.indy_handle_t)(handle)
+
+	/*
+	   :param rev_reg_delta_json: revocation registry delta.
+	   :param other_rev_reg_delta_json: revocation registry delta for which PrevAccum value  is equal to current accum value of rev_reg_delta_json.
+	   :return: Merged revocation registry delta
+	*/
+	res := C.indy_issuer_merge_revocation_registry_deltas(commandHandle,
+		(*C.char)(revRegDeltaJson),
+		(*C.char)(otherRevRegDeltaJson),
+		(C.cb_issuerMergeRevocationRegistryDeltas)(unsafe.Pointer(C.issuerMergeRevocationRegistryDeltasCB)))
+
+	if res != 0 {
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -244,8 +345,8 @@ func createAndStoreRevocRegCB(commandHandle C.indy_handle_t, indyError C.indy_er
 					string(C.GoString(revocRegEntryJson)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -336,8 +437,8 @@ func CreateAndStoreRevocReg(wh int, issuerDid unsafe.Pointer, revocDefType unsaf
 		(C.cb_createAndStoreRevocReg)(unsafe.Pointer(C.createAndStoreRevocRegCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -353,8 +454,8 @@ func proverGetCredentialCB(commandHandle C.indy_handle_t, indyError C.indy_error
 					string(C.GoString(credential)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -385,8 +486,8 @@ func ProverGetCredential(wh int, credentialId unsafe.Pointer) chan indyUtils.Ind
 		(C.cb_proverGetCredential)(unsafe.Pointer(C.proverGetCredentialCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -402,8 +503,8 @@ func verifierVerifyProofCB(commandHandle C.indy_handle_t, indyError C.indy_error
 					(bool)(valid),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -519,8 +620,8 @@ func VerifierVerifyProof(proofRequestJson, proofJson, schemasJson, credDefsJson,
 		(C.cb_verifierVerifyProof)(unsafe.Pointer(C.verifierVerifyProofCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -536,8 +637,8 @@ func proverCreateProofCB(commandHandle C.indy_handle_t, indyError C.indy_error_t
 					string(C.GoString(proofJson)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -714,8 +815,8 @@ func ProverCreateProof(wh int, proofRequestJson, requestedCredentialsJson, maste
 		(C.cb_proverCreateProof)(unsafe.Pointer(C.proverCreateProofCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -731,8 +832,8 @@ func proverFetchCredentialsForProofReqCB(commandHandle C.indy_handle_t, indyErro
 					string(C.GoString(credentialsJson)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -770,8 +871,8 @@ func ProverFetchCredentialsForProofReq(searchHandle int, itemReferent unsafe.Poi
 		(C.cb_proverFetchCredentialsForProofReq)(unsafe.Pointer(C.proverFetchCredentialsForProofReqCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -787,8 +888,8 @@ func proverSearchForCredentialsForProofReqCB(commandHandle C.indy_handle_t, indy
 					(int)(searchHandle),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -888,8 +989,8 @@ func ProverSearchForCredentialsForProofReq(wh int, proofRequestJson, extraQueryJ
 		(C.cb_proverSearchForCredentialsForProofReq)(unsafe.Pointer(C.proverSearchForCredentialsForProofReqCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -905,8 +1006,8 @@ func generateNonceCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, no
 					string(C.GoString(nonce)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -925,8 +1026,8 @@ func GenerateNonce() chan indyUtils.IndyResult {
 		(C.cb_generateNonce)(unsafe.Pointer(C.generateNonceCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -942,8 +1043,8 @@ func proverCreateMasterSecretCB(commandHandle C.indy_handle_t, indyError C.indy_
 					string(C.GoString(idMasterSecret)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -964,8 +1065,8 @@ func ProverCreateMasterSecret(wh int, secretName unsafe.Pointer) chan indyUtils.
 		(C.cb_proverCreateMasterSecret)(unsafe.Pointer(C.proverCreateMasterSecretCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -981,8 +1082,8 @@ func issuerCreateCredentialOfferCB(commandHandle C.indy_handle_t, indyError C.in
 					string(C.GoString(credentialOfferJson)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -1015,8 +1116,8 @@ func IssuerCreateCredentialOffer(wh int, credDefinitionId unsafe.Pointer) chan i
 		(C.cb_issuerCreateCredentialOffer)(unsafe.Pointer(C.issuerCreateCredentialOfferCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -1033,8 +1134,8 @@ func proverCreateCredentialRequestCB(commandHandle C.indy_handle_t, indyError C.
 					string(C.GoString(credentialRequestMetadataJSON)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -1089,8 +1190,8 @@ func ProverCreateCredentialRequest(wh int, proverDID, credentialOfferJSON, crede
 		(C.cb_proverCreateCredentialRequest)(unsafe.Pointer(C.proverCreateCredentialRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -1107,8 +1208,8 @@ func issuerCreateSchemaCB(commandHandle C.indy_handle_t, indyError C.indy_error_
 					string(C.GoString(schemaJson)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -1144,8 +1245,8 @@ func IssuerCreateSchema(submitterDid unsafe.Pointer, name unsafe.Pointer, versio
 		(C.cb_issuerCreateSchema)(unsafe.Pointer(C.issuerCreateSchemaCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -1162,8 +1263,8 @@ func issuerCreateAndStoreCredentialDefCB(commandHandle C.indy_handle_t, indyErro
 					string(C.GoString(credDefJson)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -1233,8 +1334,8 @@ func IssuerCreateAndStoreCredentialDef(wh int, issuerDid unsafe.Pointer, schemaJ
 		(C.cb_issuerCreateAndStoreCredentialDef)(unsafe.Pointer(C.issuerCreateAndStoreCredentialDefCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -1249,8 +1350,8 @@ func issuerRotateCredentialDefStartCB(commandHandle C.indy_handle_t, indyError C
 				Results: []interface{}{
 					string(C.GoString(credDefJson))}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -1283,8 +1384,8 @@ func IssuerRotateCredentialDefStart(walletHandle int, credDefID unsafe.Pointer,
 		(*C.char)(configJson),
 		(C.cb_issuerRotateCredentialDefStart)(unsafe.Pointer(C.issuerRotateCredentialDefStartCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -1297,8 +1398,8 @@ func issuerRotateCredentialDefApplyCB(commandHandle C.indy_handle_t, indyError C
 		indyUtils.RemoveFuture((int)(commandHandle),
 			indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -1322,8 +1423,8 @@ func IssuerRotateCredentialDefApply(walletHandle int, credDefID unsafe.Pointer)
 		(*C.char)(credDefID),
 		(C.cb_issuerRotateCredentialDefApply)(unsafe.Pointer(C.issuerRotateCredentialDefApplyCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -1351,8 +1452,8 @@ func issuerCreateCredentialCB(commandHandle C.indy_handle_t, indyError C.indy_er
 					deltaJson,
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -1423,8 +1524,8 @@ func IssuerCreateCredential(wh int, credOfferJson, credRequestJson, credentialTr
 		(C.cb_issuerCreateCredential)(unsafe.Pointer(C.issuerCreateCredentialCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -1440,8 +1541,8 @@ func proverStoreCredentialCB(commandHandle C.indy_handle_t, indyError C.indy_err
 					string(C.GoString(credentialId)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -1487,8 +1588,8 @@ func ProverStoreCredential(wh int, credentialId, credRequestMetadataJson, credJs
 		(C.cb_proverStoreCredential)(unsafe.Pointer(C.proverStoreCredentialCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -1500,8 +1601,8 @@ func proverDeleteCredentialCB(commandHandle C.indy_handle_t, indyError C.indy_er
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -1524,8 +1625,8 @@ func ProverDeleteCredential(walletHandle int, credentialID unsafe.Pointer) chan
 		(*C.char)(credentialID),
 		(C.cb_proverDeleteCredential)(unsafe.Pointer(C.proverDeleteCredentialCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -1540,8 +1641,8 @@ func proverGetCredentialsCB(commandHandle C.indy_handle_t, indyError C.indy_erro
 					string(C.GoString(credentialsJson)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -1584,8 +1685,8 @@ func ProverGetCredentials(walletHandle int, filterJson unsafe.Pointer) chan indy
 		(*C.char)(filterJson),
 		(C.cb_proverGetCredentials)(unsafe.Pointer(C.proverGetCredentialsCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -1600,8 +1701,8 @@ func proverGetCredentialsForProofReqCB(commandHandle C.indy_handle_t, indyError
 					string(C.GoString(credentialsJson)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -1708,8 +1809,8 @@ func ProverGetCredentialsForProofReq(walletHandle int, proofReqJson unsafe.Point
 		(*C.char)(proofReqJson),
 		(C.cb_proverGetCredentialsForProofReq)(unsafe.Pointer(C.proverGetCredentialsForProofReqCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -1725,8 +1826,8 @@ func proverSearchCredentialsCB(commandHandle C.indy_handle_t, indyError C.indy_e
 					int(totalCount),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -1758,8 +1859,8 @@ func ProverSearchCredentials(walletHandle int, queryJson unsafe.Pointer) chan in
 		(*C.char)(queryJson),
 		(C.cb_proverSearchCredentials)(unsafe.Pointer(C.proverSearchCredentialsCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -1774,8 +1875,8 @@ func proverFetchCredentialsCB(commandHandle C.indy_handle_t, indyError C.indy_er
 					string(C.GoString(credentialsJson)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -1807,8 +1908,44 @@ func ProverFetchCredentials(searchHandle int, totalCount int) chan indyUtils.Ind
 		C.indy_u32_t(totalCount),
 		(C.cb_proverFetchCredentials)(unsafe.Pointer(C.proverFetchCredentialsCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
+		return future
+	}
+	return future
+}
+
+//export proverCloseCredentialsSearchCB
+func proverCloseCredentialsSearchCB(commandHandle C.indy_handle_t, indyError C.indy_error_t) {
+	if indyError == 0 {
+		indyUtils.RemoveFuture((int)(commandHandle),
+			indyUtils.IndyResult{Error: nil})
+	} else {
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
+	}
+}
+
+// ProverCloseCredentialsSearch       close credentials search (make search handle invalid)
+func ProverCloseCredentialsSearch(searchHandle int) chan indyUtils.IndyResult {
+
+	// Prepare the call parameters.
+	handle, future := indyUtils.NewFutureCommand()
+	commandHandle := (C.indy_handle_t)(handle)
+
+	/*
+	   Close credentials search (make search handle invalid)
+	   :param search_handle: Search handle (created by prover_search_credentials)
+	   :return: None
+	*/
+
+	// Call C.indy_prover_close_credentials_search
+	res := C.indy_prover_close_credentials_search(commandHandle,
+		C.indy_handle_t(searchHandle),
+		(C.cb_proverCloseCredentialsSearch)(unsafe.Pointer(C.proverCloseCredentialsSearchCB)))
+	if res != 0 {
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -1823,8 +1960,8 @@ func toUnqualifiedCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, re
 					string(C.GoString(res)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -1861,8 +1998,8 @@ func ToUnqualified(entity unsafe.Pointer) chan indyUtils.IndyResult {
 		(*C.char)(entity),
 		(C.cb_proverGetCredentials)(unsafe.Pointer(C.proverGetCredentialsCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -44,3 +44,13 @@ type CredentialInfo struct {
 	CreationDate           time.Time
 	RevocationDate         *time.Time
 }
+
+// WalletCredential - credential info returned by the prover credential searches
+type WalletCredential struct {
+	Referent  string            `json:"referent"`
+	Attrs     map[string]string `json:"attrs"`
+	SchemaId  string            `json:"schema_id"`
+	CredDefId string            `json:"cred_def_id"`
+	RevRegId  string            `json:"rev_reg_id,omitempty"`
+	CredRevId string            `json:"cred_rev_id,omitempty"`
+}
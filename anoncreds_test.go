@@ -12,9 +12,11 @@
 package indySDK
 
 import (
+	"errors"
 	"fmt"
 	"github.com/Jeffail/gabs/v2"
 	"github.com/google/uuid"
+	"github.com/joyride9999/IndySdkGoBindings/anoncreds"
 	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
 	"testing"
 )
@@ -821,6 +823,7 @@ func TestProverSearchCredentials(t *testing.T) {
 			if tt.wantErr {
 				fmt.Println("Expected error: ", errSearch)
 			} else {
+				defer ProverCloseCredentialsSearch(searchHandle)
 				// Check if variables are valid
 				_, errFetch := ProverFetchCredentials(searchHandle, totalCount)
 				if errFetch != nil {
@@ -834,6 +837,75 @@ func TestProverSearchCredentials(t *testing.T) {
 	return
 }
 
+func TestCredentialIterator(t *testing.T) {
+	whIssuer, errCreate := createWallet(issuerConfig(), issuerCredentials())
+	if errCreate != nil && !errors.Is(errCreate, indyUtils.ErrWalletAlreadyExists) {
+		t.Errorf("CreateWallet() error = '%v'", errCreate)
+		return
+	}
+	defer walletCleanup(whIssuer, issuerConfig(), issuerCredentials())
+	didIssuer, _, _ := CreateAndStoreDID(whIssuer, "")
+
+	whHolder, errCreate := createWallet(holderConfig(), holderCredentials())
+	if errCreate != nil && !errors.Is(errCreate, indyUtils.ErrWalletAlreadyExists) {
+		t.Errorf("CreateWallet() error = '%v'", errCreate)
+		return
+	}
+	defer walletCleanup(whHolder, holderConfig(), holderCredentials())
+	didHolder, _, _ := CreateAndStoreDID(whHolder, "")
+
+	_, _, _, credDefId, credDefJson, masterSecret, errCredential := createAndStoreCredential(whIssuer, didIssuer, whHolder, didHolder)
+	if errCredential != nil {
+		t.Errorf("createAndStoreCredential() error = '%v'", errCredential)
+		return
+	}
+	// two more credentials so the search needs several pages
+	for i := 0; i < 2; i++ {
+		credOffer, _ := IssuerCreateCredentialOffer(whIssuer, credDefId)
+		credentialRequest, credentialRequestMetadata, _ := ProverCreateCredentialRequest(whHolder, didHolder, credOffer, credDefJson, masterSecret)
+		credentialJson, _, _, _ := IssuerCreateCredential(whIssuer, credOffer, credentialRequest, credValuesJson, "", 0)
+		_, errStore := ProverStoreCredential(whHolder, "", credentialRequestMetadata, credentialJson, credDefJson, "")
+		if errStore != nil {
+			t.Errorf("ProverStoreCredential() error = '%v'", errStore)
+			return
+		}
+	}
+
+	pageSize := CredentialSearchPageSize
+	CredentialSearchPageSize = 2
+	defer func() { CredentialSearchPageSize = pageSize }()
+
+	it, errSearch := NewCredentialIterator(whHolder, fmt.Sprintf(`{"cred_def_id": %q}`, credDefId))
+	if errSearch != nil {
+		t.Errorf("NewCredentialIterator() error = '%v'", errSearch)
+		return
+	}
+	defer it.Close()
+	referents := make(map[string]bool)
+	for it.Next() {
+		credential := it.Credential()
+		if credential.CredDefId != credDefId || credential.Attrs["name"] == "" {
+			t.Errorf("Credential() credential = '%v'", credential)
+			return
+		}
+		referents[credential.Referent] = true
+	}
+	if it.Err() != nil || it.Total() != 3 || len(referents) != 3 {
+		t.Errorf("CredentialIterator total = '%v', credentials = '%v', error = '%v'", it.Total(), len(referents), it.Err())
+		return
+	}
+
+	visited := 0
+	errForEach := ProverForEachCredential(whHolder, "{}", func(credential anoncreds.WalletCredential) bool {
+		visited++
+		return false
+	})
+	if errForEach != nil || visited != 1 {
+		t.Errorf("ProverForEachCredential() visited = '%v', error = '%v'", visited, errForEach)
+		return
+	}
+}
+
 func TestProverSearchForCredentialForProofReq(t *testing.T) {
 	// Create and open issuer wallet
 	whIssuer, errCreate := createWallet(issuerConfig(), issuerCredentials())
@@ -1144,3 +1216,89 @@ func TestVerifierVerifyProof(t *testing.T) {
 
 	return
 }
+
+func TestUpdateRevocationState(t *testing.T) {
+	whIssuer, errCreate := createWallet(issuerConfig(), issuerCredentials())
+	if errCreate != nil && !errors.Is(errCreate, indyUtils.ErrWalletAlreadyExists) {
+		t.Errorf("CreateWallet() error = '%v'", errCreate)
+		return
+	}
+	defer walletCleanup(whIssuer, issuerConfig(), issuerCredentials())
+	didIssuer, _, _ := CreateAndStoreDID(whIssuer, "")
+
+	whHolder, errCreate := createWallet(holderConfig(), holderCredentials())
+	if errCreate != nil && !errors.Is(errCreate, indyUtils.ErrWalletAlreadyExists) {
+		t.Errorf("CreateWallet() error = '%v'", errCreate)
+		return
+	}
+	defer walletCleanup(whHolder, holderConfig(), holderCredentials())
+	didHolder, _, _ := CreateAndStoreDID(whHolder, "")
+	masterSecret, _ := ProverCreateMasterSecret(whHolder, "")
+
+	tailsDir := t.TempDir()
+	info, revRegDefJson, revRegEntryJson, errCredDef := createRevocableCredDef(whIssuer, didIssuer, tailsDir, 5)
+	if errCredDef != nil {
+		t.Errorf("createRevocableCredDef() error = '%v'", errCredDef)
+		return
+	}
+	blobReaderHandle, errReader := IndyOpenBlobStorageReader("default", fmt.Sprintf(`{"base_dir": "%s", "uri_pattern": ""}`, tailsDir))
+	if errReader != nil {
+		t.Errorf("IndyOpenBlobStorageReader() error = '%v'", errReader)
+		return
+	}
+
+	// the holder builds its state once both credentials are issued
+	_, credRevIdA, issueDeltaA, errIssue := issueRevocableCredential(whIssuer, info, revRegDefJson, blobReaderHandle, whHolder, didHolder, masterSecret)
+	if errIssue != nil {
+		t.Errorf("issueRevocableCredential() error = '%v'", errIssue)
+		return
+	}
+	_, credRevIdB, issueDeltaB, errIssue := issueRevocableCredential(whIssuer, info, revRegDefJson, blobReaderHandle, whHolder, didHolder, masterSecret)
+	if errIssue != nil {
+		t.Errorf("issueRevocableCredential() error = '%v'", errIssue)
+		return
+	}
+
+	issuedDelta, errMerge := IssuerMergeRevocationRegistryDeltas(revRegEntryJson, issueDeltaA)
+	if errMerge == nil {
+		issuedDelta, errMerge = IssuerMergeRevocationRegistryDeltas(issuedDelta, issueDeltaB)
+	}
+	if errMerge != nil {
+		t.Errorf("IssuerMergeRevocationRegistryDeltas() error = '%v'", errMerge)
+		return
+	}
+	issued, _ := gabs.ParseJSON([]byte(issuedDelta))
+	if len(issued.Path("value.issued").Children()) != 2 {
+		t.Errorf("IssuerMergeRevocationRegistryDeltas() merged = '%v'", issuedDelta)
+		return
+	}
+
+	revState, errState := CreateRevocationState(blobReaderHandle, revRegDefJson, issuedDelta, 100, credRevIdA)
+	if errState != nil {
+		t.Errorf("CreateRevocationState() error = '%v'", errState)
+		return
+	}
+
+	// revoking B changes the accumulator, the state of A only needs the revocation delta
+	revokeDelta, errRevoke := IssuerRevokeCredential(whIssuer, blobReaderHandle, info.RevocationRegistryId, credRevIdB)
+	if errRevoke != nil {
+		t.Errorf("IssuerRevokeCredential() error = '%v'", errRevoke)
+		return
+	}
+	updatedState, errUpdate := UpdateRevocationState(blobReaderHandle, revState, revRegDefJson, revokeDelta, 200, credRevIdA)
+	if errUpdate != nil {
+		t.Errorf("UpdateRevocationState() error = '%v'", errUpdate)
+		return
+	}
+
+	before, _ := gabs.ParseJSON([]byte(revState))
+	after, errParse := gabs.ParseJSON([]byte(updatedState))
+	if errParse != nil || after.Path("timestamp").Data().(float64) != 200 {
+		t.Errorf("UpdateRevocationState() state = '%v'", updatedState)
+		return
+	}
+	if after.Path("rev_reg").String() == before.Path("rev_reg").String() {
+		t.Errorf("UpdateRevocationState() kept the accumulator of the old state")
+		return
+	}
+}
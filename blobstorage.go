@@ -17,12 +17,19 @@ package indySDK
 */
 import "C"
 import (
+	"context"
 	"github.com/joyride9999/IndySdkGoBindings/blobstorage"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
 	"unsafe"
 )
 
 // IndyOpenBlobStorageReader opens blob reader
 func IndyOpenBlobStorageReader(blobStorageType string, config string) (blobHandle int, err error) {
+	return IndyOpenBlobStorageReaderCtx(context.Background(), blobStorageType, config)
+}
+
+// IndyOpenBlobStorageReaderCtx is like IndyOpenBlobStorageReader but returns ctx.Err() if ctx is done before libindy answers.
+func IndyOpenBlobStorageReaderCtx(ctx context.Context, blobStorageType string, config string) (blobHandle int, err error) {
 
 	upBlobStorageType := unsafe.Pointer(C.CString(blobStorageType))
 	defer C.free(upBlobStorageType)
@@ -30,7 +37,7 @@ func IndyOpenBlobStorageReader(blobStorageType string, config string) (blobHandl
 	defer C.free(upConfig)
 
 	channel := blobstorage.OpenBlobStorageReader(upBlobStorageType, upConfig)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return -1, result.Error
 	}
@@ -40,6 +47,11 @@ func IndyOpenBlobStorageReader(blobStorageType string, config string) (blobHandl
 
 // IndyOpenBlobStorageWriter opens blob writer
 func IndyOpenBlobStorageWriter(blobStorageType string, config string) (blobHandle int, err error) {
+	return IndyOpenBlobStorageWriterCtx(context.Background(), blobStorageType, config)
+}
+
+// IndyOpenBlobStorageWriterCtx is like IndyOpenBlobStorageWriter but returns ctx.Err() if ctx is done before libindy answers.
+func IndyOpenBlobStorageWriterCtx(ctx context.Context, blobStorageType string, config string) (blobHandle int, err error) {
 
 	upBlobStorageType := unsafe.Pointer(C.CString(blobStorageType))
 	defer C.free(upBlobStorageType)
@@ -47,7 +59,7 @@ func IndyOpenBlobStorageWriter(blobStorageType string, config string) (blobHandl
 	defer C.free(upConfig)
 
 	channel := blobstorage.OpenBlobStorageWriter(upBlobStorageType, upConfig)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return -1, result.Error
 	}
@@ -23,7 +23,6 @@ package blobstorage
 import "C"
 import (
 	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
-	"errors"
 	"unsafe"
 )
 
@@ -36,8 +35,8 @@ func openBlobStorageReaderCB(commandHandle C.indy_handle_t, indyError C.indy_err
 					int(blobHandle),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -50,8 +49,8 @@ func openBlobStorageWriterCB(commandHandle C.indy_handle_t, indyError C.indy_err
 					int(blobHandle),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -67,9 +66,9 @@ func OpenBlobStorageReader(blobStorageType, configJson unsafe.Pointer) chan indy
 		(*C.char)(configJson),
 		(C.cb_open_blob_storage)(unsafe.Pointer(C.openBlobStorageReaderCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
+		indyErr := indyUtils.NewIndyError(int(res))
 		go func() {
-			indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+			indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr})
 		}()
 		return future
 	}
@@ -89,9 +88,9 @@ func OpenBlobStorageWriter(blobStorageType, configJson unsafe.Pointer) chan indy
 		(*C.char)(configJson),
 		(C.cb_open_blob_storage)(unsafe.Pointer(C.openBlobStorageWriterCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
+		indyErr := indyUtils.NewIndyError(int(res))
 		go func() {
-			indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+			indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr})
 		}()
 		return future
 	}
@@ -17,12 +17,19 @@ package indySDK
 */
 import "C"
 import (
+	"context"
 	"github.com/joyride9999/IndySdkGoBindings/cache"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
 	"unsafe"
 )
 
 // GetCacheCredDef gets credential definition json data for specified credential definition id
 func GetCacheCredDef(ph int, wh int, sdid string, credDefId string, options string) (string, error) {
+	return GetCacheCredDefCtx(context.Background(), ph, wh, sdid, credDefId, options)
+}
+
+// GetCacheCredDefCtx is like GetCacheCredDef but returns ctx.Err() if ctx is done before libindy answers.
+func GetCacheCredDefCtx(ctx context.Context, ph int, wh int, sdid string, credDefId string, options string) (string, error) {
 
 	upSDid := unsafe.Pointer(C.CString(sdid))
 	defer C.free(upSDid)
@@ -32,7 +39,7 @@ func GetCacheCredDef(ph int, wh int, sdid string, credDefId string, options stri
 	defer C.free(upOptions)
 
 	channel := cache.GetCredDef(ph, wh, upSDid, upCredDefId, upOptions)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -41,6 +48,11 @@ func GetCacheCredDef(ph int, wh int, sdid string, credDefId string, options stri
 
 // GetCacheSchema gets schema json data for specified schema id
 func GetCacheSchema(ph int, wh int, sdid string, schemaId string, options string) (string, error) {
+	return GetCacheSchemaCtx(context.Background(), ph, wh, sdid, schemaId, options)
+}
+
+// GetCacheSchemaCtx is like GetCacheSchema but returns ctx.Err() if ctx is done before libindy answers.
+func GetCacheSchemaCtx(ctx context.Context, ph int, wh int, sdid string, schemaId string, options string) (string, error) {
 
 	upSDid := unsafe.Pointer(C.CString(sdid))
 	defer C.free(upSDid)
@@ -50,7 +62,7 @@ func GetCacheSchema(ph int, wh int, sdid string, schemaId string, options string
 	defer C.free(upOptions)
 
 	channel := cache.GetSchema(ph, wh, upSDid, upSchemaId, upOptions)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -59,19 +71,29 @@ func GetCacheSchema(ph int, wh int, sdid string, schemaId string, options string
 
 // PurgeCredDefCache purge credential definition cache
 func PurgeCredDefCache(wh int, options string) error {
+	return PurgeCredDefCacheCtx(context.Background(), wh, options)
+}
+
+// PurgeCredDefCacheCtx is like PurgeCredDefCache but returns ctx.Err() if ctx is done before libindy answers.
+func PurgeCredDefCacheCtx(ctx context.Context, wh int, options string) error {
 
 	upOptions := unsafe.Pointer(C.CString(options))
 	defer C.free(upOptions)
 	channel := cache.PurgeCredDefCache(wh, upOptions)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // PurgeSchemaCache Purge schema cache
 func PurgeSchemaCache(wh int, options string) error {
+	return PurgeSchemaCacheCtx(context.Background(), wh, options)
+}
+
+// PurgeSchemaCacheCtx is like PurgeSchemaCache but returns ctx.Err() if ctx is done before libindy answers.
+func PurgeSchemaCacheCtx(ctx context.Context, wh int, options string) error {
 	upOptions := unsafe.Pointer(C.CString(options))
 	defer C.free(upOptions)
 	channel := cache.PurgeSchemaCache(wh, upOptions)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
@@ -31,7 +31,6 @@ import "C"
 
 import (
 	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
-	"errors"
 	"unsafe"
 )
 
@@ -44,8 +43,8 @@ func getSchemaCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, schema
 					string(C.GoString(schemaJson)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -93,8 +92,8 @@ func GetSchema(poolHandle int, walletHandle int, submitterDid, schemaID, options
 		(*C.char)(optionsJson),
 		(C.cb_getSchema)(unsafe.Pointer(C.getSchemaCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -110,8 +109,8 @@ func getCredDefCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, credD
 					string(C.GoString(credDefJson)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -164,8 +163,8 @@ func GetCredDef(poolHandle int, walletHandle int, submitterDid, credDefId, optio
 		(*C.char)(optionsJson),
 		(C.cb_getCredDef)(unsafe.Pointer(C.getCredDefCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -177,8 +176,8 @@ func purgeCredDefCacheCB(commandHandle C.indy_handle_t, indyError C.indy_error_t
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -207,8 +206,8 @@ func PurgeCredDefCache(wh int, options unsafe.Pointer) chan indyUtils.IndyResult
 		(*C.char)(options),
 		(C.cb_purgeCredDefCache)(unsafe.Pointer(C.purgeCredDefCacheCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -220,8 +219,8 @@ func purgeSchemaCacheCB(commandHandle C.indy_handle_t, indyError C.indy_error_t)
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -249,8 +248,8 @@ func PurgeSchemaCache(wh int, options unsafe.Pointer) chan indyUtils.IndyResult
 		(*C.char)(options),
 		(C.cb_purgeSchemaCache)(unsafe.Pointer(C.purgeSchemaCacheCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -0,0 +1,133 @@
+/*
+// ******************************************************************
+// Purpose: typed iteration over the credentials of a prover wallet
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package indySDK
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/joyride9999/IndySdkGoBindings/anoncreds"
+)
+
+// CredentialSearchPageSize number of credentials read from libindy per ProverFetchCredentials call
+var CredentialSearchPageSize = 100
+
+// CredentialIterator walks the result of a prover credential search a page at a time. The search handle is closed
+// when the iterator is exhausted, fails or Close is called, so always defer Close to cover early exits:
+//
+//	it, err := NewCredentialIterator(wh, "{}")
+//	if err != nil { ... }
+//	defer it.Close()
+//	for it.Next() {
+//		credential := it.Credential()
+//	}
+//	if it.Err() != nil { ... }
+type CredentialIterator struct {
+	ctx          context.Context
+	searchHandle int
+	total        int
+	fetched      int
+	page         []anoncreds.WalletCredential
+	current      anoncreds.WalletCredential
+	err          error
+	closed       bool
+}
+
+// NewCredentialIterator searches the wallet credentials matching the wql query
+func NewCredentialIterator(wh int, queryJson string) (*CredentialIterator, error) {
+	return NewCredentialIteratorCtx(context.Background(), wh, queryJson)
+}
+
+// NewCredentialIteratorCtx is like NewCredentialIterator but the libindy calls of the iterator return ctx.Err() if ctx is done before libindy answers.
+func NewCredentialIteratorCtx(ctx context.Context, wh int, queryJson string) (*CredentialIterator, error) {
+	searchHandle, total, err := ProverSearchCredentialsCtx(ctx, wh, queryJson)
+	if err != nil {
+		return nil, err
+	}
+	return &CredentialIterator{ctx: ctx, searchHandle: searchHandle, total: total}, nil
+}
+
+// Total number of credentials matching the query
+func (it *CredentialIterator) Total() int {
+	return it.total
+}
+
+// Next advances to the next credential, returns false when the search is exhausted or failed
+func (it *CredentialIterator) Next() bool {
+	if it.closed {
+		return false
+	}
+	if len(it.page) == 0 {
+		if it.fetched >= it.total {
+			it.err = it.Close()
+			return false
+		}
+		credentialsJson, err := ProverFetchCredentialsCtx(it.ctx, it.searchHandle, CredentialSearchPageSize)
+		if err == nil {
+			err = json.Unmarshal([]byte(credentialsJson), &it.page)
+		}
+		if err != nil {
+			it.err = err
+			it.Close()
+			return false
+		}
+		if len(it.page) == 0 {
+			it.err = it.Close()
+			return false
+		}
+		it.fetched += len(it.page)
+	}
+
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Credential returns the credential Next advanced to
+func (it *CredentialIterator) Credential() anoncreds.WalletCredential {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, nil when the search was exhausted
+func (it *CredentialIterator) Err() error {
+	return it.err
+}
+
+// Close releases the libindy search handle, calling it again does nothing
+func (it *CredentialIterator) Close() error {
+	if it.closed {
+		return nil
+	}
+	it.closed, it.page = true, nil
+	// the handle must be released even if the iteration context is done
+	return ProverCloseCredentialsSearch(it.searchHandle)
+}
+
+// ProverForEachCredential calls fn for each wallet credential matching the wql query until fn returns false,
+// the search handle is closed in every case
+func ProverForEachCredential(wh int, queryJson string, fn func(credential anoncreds.WalletCredential) bool) error {
+	return ProverForEachCredentialCtx(context.Background(), wh, queryJson, fn)
+}
+
+// ProverForEachCredentialCtx is like ProverForEachCredential but returns ctx.Err() if ctx is done before libindy answers.
+func ProverForEachCredentialCtx(ctx context.Context, wh int, queryJson string, fn func(credential anoncreds.WalletCredential) bool) error {
+	it, err := NewCredentialIteratorCtx(ctx, wh, queryJson)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for it.Next() {
+		if !fn(it.Credential()) {
+			return it.Close()
+		}
+	}
+	return it.Err()
+}
@@ -17,14 +17,21 @@ package indySDK
 */
 import "C"
 import (
-	"github.com/joyride9999/IndySdkGoBindings/crypto"
+	"context"
 	"encoding/json"
 	"errors"
+	"github.com/joyride9999/IndySdkGoBindings/crypto"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
 	"unsafe"
 )
 
 // CreateKey creates keys pair and stores in the wallet
 func CreateKey(wh int, key crypto.Key) (string, error) {
+	return CreateKeyCtx(context.Background(), wh, key)
+}
+
+// CreateKeyCtx is like CreateKey but returns ctx.Err() if ctx is done before libindy answers.
+func CreateKeyCtx(ctx context.Context, wh int, key crypto.Key) (string, error) {
 
 	jsonKey, err := json.Marshal(key)
 	if err != nil {
@@ -35,7 +42,7 @@ func CreateKey(wh int, key crypto.Key) (string, error) {
 	defer C.free(upKey)
 
 	channel := crypto.CreateKey(wh, upKey)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -44,6 +51,11 @@ func CreateKey(wh int, key crypto.Key) (string, error) {
 
 // SetKeyMetadata saves/replaces the meta information for the giving key in the wallet
 func SetKeyMetadata(wh int, verkey string, metadata string) error {
+	return SetKeyMetadataCtx(context.Background(), wh, verkey, metadata)
+}
+
+// SetKeyMetadataCtx is like SetKeyMetadata but returns ctx.Err() if ctx is done before libindy answers.
+func SetKeyMetadataCtx(ctx context.Context, wh int, verkey string, metadata string) error {
 
 	upVerKey := unsafe.Pointer(C.CString(verkey))
 	defer C.free(upVerKey)
@@ -51,17 +63,22 @@ func SetKeyMetadata(wh int, verkey string, metadata string) error {
 	defer C.free(upMetadata)
 
 	channel := crypto.SetKeyMetadata(wh, upVerKey, upMetadata)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // GetKeyMetadata retrieves the meta information for the giving key in the wallet
 func GetKeyMetadata(wh int, verkey string) (string, error) {
+	return GetKeyMetadataCtx(context.Background(), wh, verkey)
+}
+
+// GetKeyMetadataCtx is like GetKeyMetadata but returns ctx.Err() if ctx is done before libindy answers.
+func GetKeyMetadataCtx(ctx context.Context, wh int, verkey string) (string, error) {
 
 	upVerKey := unsafe.Pointer(C.CString(verkey))
 	defer C.free(upVerKey)
 	channel := crypto.GetKeyMetadata(wh, upVerKey)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -70,6 +87,11 @@ func GetKeyMetadata(wh int, verkey string) (string, error) {
 
 // Sign signs a message with a key
 func Sign(wh int, signerVK string, messageRaw []uint8, messageLen uint32) ([]uint8, error) {
+	return SignCtx(context.Background(), wh, signerVK, messageRaw, messageLen)
+}
+
+// SignCtx is like Sign but returns ctx.Err() if ctx is done before libindy answers.
+func SignCtx(ctx context.Context, wh int, signerVK string, messageRaw []uint8, messageLen uint32) ([]uint8, error) {
 
 	upSignerVK := unsafe.Pointer(C.CString(signerVK))
 	defer C.free(upSignerVK)
@@ -77,7 +99,7 @@ func Sign(wh int, signerVK string, messageRaw []uint8, messageLen uint32) ([]uin
 	defer C.free(upMessageRaw)
 
 	channel := crypto.Sign(wh, upSignerVK, upMessageRaw, messageLen)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return []uint8(""), result.Error
 	}
@@ -86,6 +108,11 @@ func Sign(wh int, signerVK string, messageRaw []uint8, messageLen uint32) ([]uin
 
 // Verify signs a message with a key
 func Verify(signerVK string, messageRaw []uint8, messageLen uint32, signatureRaw []uint8, signatureLen uint32) (bool, error) {
+	return VerifyCtx(context.Background(), signerVK, messageRaw, messageLen, signatureRaw, signatureLen)
+}
+
+// VerifyCtx is like Verify but returns ctx.Err() if ctx is done before libindy answers.
+func VerifyCtx(ctx context.Context, signerVK string, messageRaw []uint8, messageLen uint32, signatureRaw []uint8, signatureLen uint32) (bool, error) {
 
 	upSignerVK := unsafe.Pointer(C.CString(signerVK))
 	defer C.free(upSignerVK)
@@ -95,7 +122,7 @@ func Verify(signerVK string, messageRaw []uint8, messageLen uint32, signatureRaw
 	defer C.free(upSignatureRaw)
 
 	channel := crypto.Verify(upSignerVK, upMessageRaw, messageLen, upSignatureRaw, signatureLen)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return false, result.Error
 	}
@@ -104,6 +131,11 @@ func Verify(signerVK string, messageRaw []uint8, messageLen uint32, signatureRaw
 
 // AnonCrypt encrypts a message by anonymous-encryption scheme
 func AnonCrypt(recipientVK string, messageRaw []uint8, messageLen uint32) ([]uint8, error) {
+	return AnonCryptCtx(context.Background(), recipientVK, messageRaw, messageLen)
+}
+
+// AnonCryptCtx is like AnonCrypt but returns ctx.Err() if ctx is done before libindy answers.
+func AnonCryptCtx(ctx context.Context, recipientVK string, messageRaw []uint8, messageLen uint32) ([]uint8, error) {
 
 	upRecipientVK := unsafe.Pointer(C.CString(recipientVK))
 	defer C.free(upRecipientVK)
@@ -111,7 +143,7 @@ func AnonCrypt(recipientVK string, messageRaw []uint8, messageLen uint32) ([]uin
 	defer C.free(upMessageRaw)
 
 	channel := crypto.AnonCrypt(upRecipientVK, upMessageRaw, messageLen)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return []uint8(""), result.Error
 	}
@@ -120,6 +152,11 @@ func AnonCrypt(recipientVK string, messageRaw []uint8, messageLen uint32) ([]uin
 
 // AnonDecrypt decrypts a message by anonymous-encryption scheme
 func AnonDecrypt(wh int, recipientVK string, messageRaw []uint8, messageLen uint32) ([]uint8, error) {
+	return AnonDecryptCtx(context.Background(), wh, recipientVK, messageRaw, messageLen)
+}
+
+// AnonDecryptCtx is like AnonDecrypt but returns ctx.Err() if ctx is done before libindy answers.
+func AnonDecryptCtx(ctx context.Context, wh int, recipientVK string, messageRaw []uint8, messageLen uint32) ([]uint8, error) {
 
 	upRecipientVK := unsafe.Pointer(C.CString(recipientVK))
 	defer C.free(upRecipientVK)
@@ -127,25 +164,75 @@ func AnonDecrypt(wh int, recipientVK string, messageRaw []uint8, messageLen uint
 	defer C.free(upMessageRaw)
 
 	channel := crypto.AnonDecrypt(wh, upRecipientVK, upMessageRaw, messageLen)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return []uint8(""), result.Error
 	}
 	return result.Results[0].([]uint8), result.Error
 }
 
-// PackMsg packs a message by encrypting the message and serializes it in a JWE-like format
+// AuthCrypt encrypts a message for recipientVK by authenticated-encryption scheme, senderVK must be a key of the wallet.
+// Kept for legacy agents, use PackMsg for new ones.
+func AuthCrypt(wh int, senderVK string, recipientVK string, message []uint8) ([]uint8, error) {
+	return AuthCryptCtx(context.Background(), wh, senderVK, recipientVK, message)
+}
+
+// AuthCryptCtx is like AuthCrypt but returns ctx.Err() if ctx is done before libindy answers.
+func AuthCryptCtx(ctx context.Context, wh int, senderVK string, recipientVK string, message []uint8) ([]uint8, error) {
+
+	upSenderVK := unsafe.Pointer(C.CString(senderVK))
+	defer C.free(upSenderVK)
+	upRecipientVK := unsafe.Pointer(C.CString(recipientVK))
+	defer C.free(upRecipientVK)
+	upMessageRaw := unsafe.Pointer(C.CBytes(message))
+	defer C.free(upMessageRaw)
+
+	channel := crypto.AuthCrypt(wh, upSenderVK, upRecipientVK, upMessageRaw, uint32(len(message)))
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return []uint8(""), result.Error
+	}
+	return result.Results[0].([]uint8), result.Error
+}
+
+// AuthDecrypt decrypts a message encrypted by AuthCrypt for recipientVK, returns the verkey of the sender and the message
+func AuthDecrypt(wh int, recipientVK string, message []uint8) (senderVK string, decrypted []uint8, err error) {
+	return AuthDecryptCtx(context.Background(), wh, recipientVK, message)
+}
+
+// AuthDecryptCtx is like AuthDecrypt but returns ctx.Err() if ctx is done before libindy answers.
+func AuthDecryptCtx(ctx context.Context, wh int, recipientVK string, message []uint8) (senderVK string, decrypted []uint8, err error) {
+
+	upRecipientVK := unsafe.Pointer(C.CString(recipientVK))
+	defer C.free(upRecipientVK)
+	upMessageRaw := unsafe.Pointer(C.CBytes(message))
+	defer C.free(upMessageRaw)
+
+	channel := crypto.AuthDecrypt(wh, upRecipientVK, upMessageRaw, uint32(len(message)))
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return "", []uint8(""), result.Error
+	}
+	return result.Results[0].(string), result.Results[1].([]uint8), result.Error
+}
+
+// PackMsg packs a message by encrypting the message and serializes it in a JWE-like format, an empty sender anoncrypts the message
 func PackMsg(wh int, messageRaw []uint8, messageLen uint32, receiverKeys string, sender string) ([]uint8, error) {
+	return PackMsgCtx(context.Background(), wh, messageRaw, messageLen, receiverKeys, sender)
+}
+
+// PackMsgCtx is like PackMsg but returns ctx.Err() if ctx is done before libindy answers.
+func PackMsgCtx(ctx context.Context, wh int, messageRaw []uint8, messageLen uint32, receiverKeys string, sender string) ([]uint8, error) {
 
 	upMessageRaw := unsafe.Pointer(C.CBytes(messageRaw))
 	defer C.free(upMessageRaw)
 	upReceiverKeys := unsafe.Pointer(C.CString(receiverKeys))
 	defer C.free(upReceiverKeys)
-	upSender := unsafe.Pointer(C.CString(sender))
+	upSender := unsafe.Pointer(GetOptionalValue(sender))
 	defer C.free(upSender)
 
 	channel := crypto.PackMsg(wh, upMessageRaw, messageLen, upReceiverKeys, upSender)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return []uint8(""), result.Error
 	}
@@ -154,36 +241,19 @@ func PackMsg(wh int, messageRaw []uint8, messageLen uint32, receiverKeys string,
 
 // UnpackMsg packs a message by encrypting the message and serializes it in a JWE-like format
 func UnpackMsg(wh int, messageRaw []uint8, messageLen uint32) ([]uint8, error) {
+	return UnpackMsgCtx(context.Background(), wh, messageRaw, messageLen)
+}
+
+// UnpackMsgCtx is like UnpackMsg but returns ctx.Err() if ctx is done before libindy answers.
+func UnpackMsgCtx(ctx context.Context, wh int, messageRaw []uint8, messageLen uint32) ([]uint8, error) {
 
 	upMessageRaw := unsafe.Pointer(C.CBytes(messageRaw))
 	defer C.free(upMessageRaw)
 
 	channel := crypto.UnpackMsg(wh, upMessageRaw, messageLen)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return []uint8(""), result.Error
 	}
 	return result.Results[0].([]uint8), result.Error
 }
-
-////indy_crypto_auth_crypt	/// **** THIS FUNCTION WILL BE DEPRECATED USE indy_pack_message() INSTEAD ****
-//// AuthCrypt dncrypt a message by authenticated-encryption scheme
-//func AuthCrypt(wh int, senderVK string, recipientVK string, messageRaw *uint8, messageLen uint32) (string, error) {
-//	channel := crypto.AuthCrypt(wh, senderVK, recipientVK, messageRaw, messageLen)
-//	result := <-channel
-//	if result.Error != nil {
-//		return "", result.Error
-//	}
-//	return result.Results[0].(string), result.Error
-//}
-//
-////indy_crypto_auth_decrypt	/// **** THIS FUNCTION WILL BE DEPRECATED USE indy_unpack_message() INSTEAD ****
-//// AuthDecrypt dncrypt a message by authenticated-encryption scheme
-//func AuthDecrypt(wh int, recipientVK string, messageRaw *uint8, messageLen uint32) (string, string, error) {
-//	channel := crypto.AuthDecrypt(wh, recipientVK, messageRaw, messageLen)
-//	result := <-channel
-//	if result.Error != nil {
-//		return "", "", result.Error
-//	}
-//	return result.Results[0].(string), result.Results[1].(string), result.Error
-//}
@@ -24,8 +24,8 @@ typedef void (*cb_anonCrypt)(indy_handle_t, indy_error_t, indy_u8_t*, indy_u32_t
 typedef void (*cb_anonDecrypt)(indy_handle_t, indy_error_t, indy_u8_t*, indy_u32_t);
 typedef void (*cb_packMsg)(indy_handle_t, indy_error_t, indy_u8_t*, indy_u32_t);
 typedef void (*cb_unpackMsg)(indy_handle_t, indy_error_t, indy_u8_t*, indy_u32_t);
-//typedef void (*cb_authCrypt)(indy_handle_t, indy_error_t, indy_u8_t, indy_u32_t);
-//typedef void (*cb_authDecrypt)(indy_handle_t, indy_error_t, char*, indy_u8_t, indy_u32_t);
+typedef void (*cb_authCrypt)(indy_handle_t, indy_error_t, indy_u8_t*, indy_u32_t);
+typedef void (*cb_authDecrypt)(indy_handle_t, indy_error_t, char*, indy_u8_t*, indy_u32_t);
 
 extern void createKeyCB(indy_handle_t, indy_error_t, char*);
 extern void setKeyMetadataCB(indy_handle_t, indy_error_t);
@@ -36,14 +36,13 @@ extern void anonCryptCB(indy_handle_t, indy_error_t, indy_u8_t*, indy_u32_t);
 extern void anonDecryptCB(indy_handle_t, indy_error_t, indy_u8_t*, indy_u32_t);
 extern void packMsgCB(indy_handle_t, indy_error_t, indy_u8_t*, indy_u32_t);
 extern void unpackMsgCB(indy_handle_t, indy_error_t, indy_u8_t*, indy_u32_t);
-//extern void authCryptCB(indy_handle_t, indy_error_t, indy_u8_t, indy_u32_t);
-//extern void authDecryptCB(indy_handle_t, indy_error_t, char*, indy_u8_t, indy_u32_t);
+extern void authCryptCB(indy_handle_t, indy_error_t, indy_u8_t*, indy_u32_t);
+extern void authDecryptCB(indy_handle_t, indy_error_t, char*, indy_u8_t*, indy_u32_t);
 */
 import "C"
 
 import (
 	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
-	"errors"
 	"unsafe"
 )
 
@@ -52,8 +51,8 @@ func createKeyCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, vkey *
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{string(C.GoString(vkey))}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -83,8 +82,8 @@ func CreateKey(wh int, key unsafe.Pointer) chan indyUtils.IndyResult {
 		(*C.char)(key),
 		(C.cb_createKey)(unsafe.Pointer(C.createKeyCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -96,8 +95,8 @@ func setKeyMetadataCB(commandHandle C.indy_handle_t, indyError C.indy_error_t) {
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -123,8 +122,8 @@ func SetKeyMetadata(wh int, verkey, metadata unsafe.Pointer) chan indyUtils.Indy
 		(*C.char)(metadata),
 		(C.cb_setKeyMetadata)(unsafe.Pointer(C.setKeyMetadataCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -137,8 +136,8 @@ func getKeyMetadataCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, m
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{string(C.GoString(meta))}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -162,8 +161,8 @@ func GetKeyMetadata(wh int, verkey unsafe.Pointer) chan indyUtils.IndyResult {
 		(*C.char)(verkey),
 		(C.cb_getKeyMetadata)(unsafe.Pointer(C.getKeyMetadataCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -175,8 +174,8 @@ func signCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, sigRaw *C.i
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{C.GoBytes(unsafe.Pointer(sigRaw), C.int(sigLen))}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -206,8 +205,8 @@ func Sign(wh int, signerVK unsafe.Pointer, messageRaw unsafe.Pointer, messageLen
 		C.indy_u32_t(messageLen),
 		(C.cb_sign)(unsafe.Pointer(C.signCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -219,8 +218,8 @@ func verifyCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, valid C.i
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{bool(valid)}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -252,8 +251,8 @@ func Verify(signerVK unsafe.Pointer, messageRaw unsafe.Pointer, messageLen uint3
 		C.indy_u32_t(signatureLen),
 		(C.cb_verify)(unsafe.Pointer(C.verifyCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -266,8 +265,8 @@ func anonCryptCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, msg *C
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil,
 			Results: []interface{}{C.GoBytes(unsafe.Pointer(msg), C.int(uint32(msgLen))), uint32(msgLen)}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -301,8 +300,8 @@ func AnonCrypt(recipientVK unsafe.Pointer, messageRaw unsafe.Pointer, messageLen
 		C.indy_u32_t(messageLen),
 		(C.cb_anonCrypt)(unsafe.Pointer(C.anonCryptCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -315,8 +314,8 @@ func anonDecryptCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, msg
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil,
 			Results: []interface{}{C.GoBytes(unsafe.Pointer(msg), C.int(msgLen))}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -351,8 +350,8 @@ func AnonDecrypt(wh int, recipientVK, messageRaw unsafe.Pointer, messageLen uint
 		C.indy_u32_t(messageLen),
 		(C.cb_anonDecrypt)(unsafe.Pointer(C.anonDecryptCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -365,8 +364,8 @@ func packMsgCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, jwe *C.i
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil,
 			Results: []interface{}{C.GoBytes(unsafe.Pointer(jwe), C.int(uint32(jweLen))), uint32(jweLen)}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -402,8 +401,8 @@ func PackMsg(wh int, messageRaw unsafe.Pointer, messageLen uint32, receiverKeys,
 		(*C.char)(sender),
 		(C.cb_packMsg)(unsafe.Pointer(C.packMsgCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -416,8 +415,8 @@ func unpackMsgCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, js *C.
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil,
 			Results: []interface{}{C.GoBytes(unsafe.Pointer(js), C.int(uint32(jsLen))), uint32(jsLen)}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -443,120 +442,118 @@ func UnpackMsg(wh int, messageRaw unsafe.Pointer, messageLen uint32) chan indyUt
 		C.indy_u32_t(messageLen),
 		(C.cb_unpackMsg)(unsafe.Pointer(C.unpackMsgCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
 	return future
 }
 
-////export authCryptCB
-//func authCryptCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, msg C.indy_u8_t, msgLen C.indy_u32_t) {
-//	if indyError == 0 {
-//		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{uint8(msg), uint32(msgLen)}})
-//	} else {
-//		errMsg := indyUtils.GetIndyError(int(indyError))
-//		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
-//	}
-//}
-//
-//// AuthCrypt encrypt a message by authenticated-encryption scheme
-//func AuthCrypt(wh int, senderVK string, recipientVK string, messageRaw *uint8, messageLen uint32) chan indyUtils.IndyResult {
-//
-//	handle, future := indyUtils.NewFutureCommand()
-//
-//	commandHandle := (C.indy_handle_t)(handle)
-//
-//	/*
-//		**** THIS FUNCTION WILL BE DEPRECATED USE indy_pack_message() INSTEAD ****
-//		Encrypt a message by authenticated-encryption scheme.
-//
-//		Sender can encrypt a confidential message specifically for Recipient, using Sender's public key.
-//		Using Recipient's public key, Sender can compute a shared secret key.
-//		Using Sender's public key and his secret key, Recipient can compute the exact same shared secret key.
-//		That shared secret key can be used to verify that the encrypted message was not tampered with,
-//		before eventually decrypting it.
-//
-//		Note to use DID keys with this function you can call indy_key_for_did to get key id (verkey)
-//		for specific DID.
-//
-//		:param wallet_handle: wallet handle (created by open_wallet).
-//		:param sender_vk: id (verkey) of message sender. The key must be created by calling indy_create_key or indy_create_and_store_my_did
-//		:param recipient_vk: id (verkey) of message recipient
-//		:param message_raw: a pointer to first byte of message that to be encrypted
-//		:param message_len: a message length
-//
-//		:return:
-//	*/
-//
-//	// Call indy_crypto_auth_crypt
-//	res := C.indy_crypto_auth_crypt(commandHandle,
-//		(C.indy_handle_t)(wh),
-//		C.CString(senderVK),
-//		C.CString(recipientVK),
-//		(*C.indy_u8_t)(unsafe.Pointer(messageRaw)),
-//		C.indy_u32_t(messageLen),
-//		(C.cb_authCrypt)(unsafe.Pointer(C.authCryptCB)))
-//	if res != 0 {
-//		errMsg := indyUtils.GetIndyError(int(res))
-//		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
-//		return future
-//	}
-//
-//	return future
-//}
-//
-////export authDecryptCB
-//func authDecryptCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, senderVK *C.char, msg C.indy_u8_t, msgLen C.indy_u32_t) {
-//	if indyError == 0 {
-//		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{string(C.GoString(senderVK)), uint8(msg), uint32(msgLen)}})
-//	} else {
-//		errMsg := indyUtils.GetIndyError(int(indyError))
-//		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
-//	}
-//}
-//
-//// AuthDecrypt dencrypt a message by authenticated-encryption scheme
-//func AuthDecrypt(wh int, recipientVK string, messageRaw *uint8, messageLen uint32) chan indyUtils.IndyResult {
-//
-//	handle, future := indyUtils.NewFutureCommand()
-//
-//	commandHandle := (C.indy_handle_t)(handle)
-//
-//	/*
-//		**** THIS FUNCTION WILL BE DEPRECATED USE indy_unpack_message() INSTEAD ****
-//		Decrypt a message by authenticated-encryption scheme.
-//
-//		Sender can encrypt a confidential message specifically for Recipient, using Sender's public key.
-//		Using Recipient's public key, Sender can compute a shared secret key.
-//		Using Sender's public key and his secret key, Recipient can compute the exact same shared secret key.
-//		That shared secret key can be used to verify that the encrypted message was not tampered with,
-//		before eventually decrypting it.
-//
-//		Note to use DID keys with this function you can call indy_key_for_did to get key id (verkey)
-//		for specific DID.
-//
-//		:param wallet_handle: wallet handle (created by open_wallet).
-//		:param recipient_vk: id (verkey) of message recipient
-//		:param message_raw: a pointer to first byte of message that to be encrypted
-//		:param message_len: a message length
-//
-//		:return:
-//	*/
-//
-//	// Call indy_crypto_auth_decrypt
-//	res := C.indy_crypto_auth_decrypt(commandHandle,
-//		(C.indy_handle_t)(wh),
-//		C.CString(recipientVK),
-//		(*C.indy_u8_t)(unsafe.Pointer(messageRaw)),
-//		C.indy_u32_t(messageLen),
-//		(C.cb_authDecrypt)(unsafe.Pointer(C.authDecryptCB)))
-//	if res != 0 {
-//		errMsg := indyUtils.GetIndyError(int(res))
-//		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
-//		return future
-//	}
-//
-//	return future
-//}
+//export authCryptCB
+func authCryptCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, msg *C.indy_u8_t, msgLen C.indy_u32_t) {
+	if indyError == 0 {
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil,
+			Results: []interface{}{C.GoBytes(unsafe.Pointer(msg), C.int(uint32(msgLen))), uint32(msgLen)}})
+	} else {
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
+	}
+}
+
+// AuthCrypt encrypts a message by authenticated-encryption scheme
+func AuthCrypt(wh int, senderVK unsafe.Pointer, recipientVK unsafe.Pointer, messageRaw unsafe.Pointer, messageLen uint32) chan indyUtils.IndyResult {
+	handle, future := indyUtils.NewFutureCommand()
+	commandHandle := (C.indy_handle_t)(handle)
+
+	/*
+		**** THIS FUNCTION WILL BE DEPRECATED USE indy_pack_message() INSTEAD ****
+		Encrypt a message by authenticated-encryption scheme.
+
+		Sender can encrypt a confidential message specifically for Recipient, using Sender's public key.
+		Using Recipient's public key, Sender can compute a shared secret key.
+		Using Sender's public key and his secret key, Recipient can compute the exact same shared secret key.
+		That shared secret key can be used to verify that the encrypted message was not tampered with,
+		before eventually decrypting it.
+
+		Note to use DID keys with this function you can call indy_key_for_did to get key id (verkey)
+		for specific DID.
+
+		:param wallet_handle: wallet handle (created by open_wallet).
+		:param sender_vk: id (verkey) of message sender. The key must be created by calling indy_create_key or indy_create_and_store_my_did
+		:param recipient_vk: id (verkey) of message recipient
+		:param message_raw: a pointer to first byte of message that to be encrypted
+		:param message_len: a message length
+
+		:return:
+	*/
+
+	// Call indy_crypto_auth_crypt
+	res := C.indy_crypto_auth_crypt(commandHandle,
+		(C.indy_handle_t)(wh),
+		(*C.char)(senderVK),
+		(*C.char)(recipientVK),
+		(*C.indy_u8_t)(messageRaw),
+		C.indy_u32_t(messageLen),
+		(C.cb_authCrypt)(unsafe.Pointer(C.authCryptCB)))
+	if res != 0 {
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
+		return future
+	}
+
+	return future
+}
+
+//export authDecryptCB
+func authDecryptCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, senderVK *C.char, msg *C.indy_u8_t, msgLen C.indy_u32_t) {
+	if indyError == 0 {
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil,
+			Results: []interface{}{string(C.GoString(senderVK)), C.GoBytes(unsafe.Pointer(msg), C.int(uint32(msgLen))), uint32(msgLen)}})
+	} else {
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
+	}
+}
+
+// AuthDecrypt decrypts a message by authenticated-encryption scheme
+func AuthDecrypt(wh int, recipientVK unsafe.Pointer, messageRaw unsafe.Pointer, messageLen uint32) chan indyUtils.IndyResult {
+	handle, future := indyUtils.NewFutureCommand()
+	commandHandle := (C.indy_handle_t)(handle)
+
+	/*
+		**** THIS FUNCTION WILL BE DEPRECATED USE indy_unpack_message() INSTEAD ****
+		Decrypt a message by authenticated-encryption scheme.
+
+		Sender can encrypt a confidential message specifically for Recipient, using Sender's public key.
+		Using Recipient's public key, Sender can compute a shared secret key.
+		Using Sender's public key and his secret key, Recipient can compute the exact same shared secret key.
+		That shared secret key can be used to verify that the encrypted message was not tampered with,
+		before eventually decrypting it.
+
+		Note to use DID keys with this function you can call indy_key_for_did to get key id (verkey)
+		for specific DID.
+
+		:param wallet_handle: wallet handle (created by open_wallet).
+		:param recipient_vk: id (verkey) of my key. The key must be created by calling indy_create_key or indy_create_and_store_my_did
+		:param encrypted_msg_raw: a pointer to first byte of message that to be decrypted
+		:param encrypted_msg_len: a message length
+
+		:return: sender verkey and decrypted message
+	*/
+
+	// Call indy_crypto_auth_decrypt
+	res := C.indy_crypto_auth_decrypt(commandHandle,
+		(C.indy_handle_t)(wh),
+		(*C.char)(recipientVK),
+		(*C.indy_u8_t)(messageRaw),
+		C.indy_u32_t(messageLen),
+		(C.cb_authDecrypt)(unsafe.Pointer(C.authDecryptCB)))
+	if res != 0 {
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
+		return future
+	}
+
+	return future
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/joyride9999/IndySdkGoBindings/crypto"
 	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 )
@@ -130,6 +131,74 @@ func TestAnonDecrypt(t *testing.T) {
 	return
 }
 
+func TestAuthCryptDecrypt(t *testing.T) {
+	// Prepare wallet for tests
+	walletHandle, errCreate := createWallet(testConfig(), testCredentials())
+	if errCreate != nil && !errors.Is(errCreate, indyUtils.ErrWalletAlreadyExists) {
+		t.Errorf("CreateWallet() error = '%v'", errCreate)
+		return
+	}
+	defer walletCleanup(walletHandle, testConfig(), testCredentials())
+
+	// Sender and recipient keys live in the same wallet
+	_, senderVerKey, errDid := CreateAndStoreDID(walletHandle, "")
+	if errDid != nil {
+		t.Errorf("CreateAndStoreDid() error = '%v'", errDid)
+		return
+	}
+	_, recipientVerKey, errDid := CreateAndStoreDID(walletHandle, "")
+	if errDid != nil {
+		t.Errorf("CreateAndStoreDid() error = '%v'", errDid)
+		return
+	}
+
+	// Message to be encrypted
+	message := []uint8("{\"reqId\":1496822211362017764}")
+
+	_, errCrypt := AuthCrypt(walletHandle, "invalid-ver-key", recipientVerKey, message)
+	if errCrypt == nil {
+		t.Errorf("AuthCrypt() with unknown sender key succeeded")
+		return
+	}
+	encrypted, errCrypt := AuthCrypt(walletHandle, senderVerKey, recipientVerKey, message)
+	if errCrypt != nil {
+		t.Errorf("AuthCrypt() error = '%v'", errCrypt)
+		return
+	}
+
+	type args struct {
+		Message []byte
+		VerKey  string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{"auth-decrypt-works", args{Message: encrypted, VerKey: recipientVerKey}, false},
+		{"auth-decrypt-invalid-message", args{Message: []byte("invalid-message"), VerKey: recipientVerKey}, true},
+		{"auth-decrypt-other-ver-key", args{Message: encrypted, VerKey: senderVerKey}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender, decrypted, errDecrypt := AuthDecrypt(walletHandle, tt.args.VerKey, tt.args.Message)
+			hasError := errDecrypt != nil
+			if hasError != tt.wantErr {
+				t.Errorf("AuthDecrypt() error = '%v'", errDecrypt)
+				return
+			}
+			if tt.wantErr {
+				t.Log("Expected error: ", errDecrypt)
+				return
+			}
+			if sender != senderVerKey || string(decrypted) != string(message) {
+				t.Errorf("AuthDecrypt() sender = '%v', message = '%v'", sender, string(decrypted))
+			}
+		})
+	}
+}
+
 func TestCreateKey(t *testing.T) {
 	// Prepare wallet for tests
 	walletHandle, errCreate := createWallet(testConfig(), testCredentials())
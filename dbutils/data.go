@@ -14,8 +14,8 @@ package dbutils
 import (
 	"errors"
 	"fmt"
-	"github.com/jackc/pgconn"
 	"gorm.io/gorm"
+	"time"
 )
 
 // Tables definitions
@@ -41,6 +41,9 @@ func (i *ItemsDB) TableName() string {
 	return "items"
 }
 
+// items columns loaded when the caller did not ask for the record value
+var itemColumnsWithoutValue = []string{"wallet_id", "id", "type", "name"}
+
 type TagsEncryptedDB struct {
 	WalletId string `gorm:"column:wallet_id;primaryKey"`
 	Name     string `gorm:"column:name;primaryKey"`
@@ -63,6 +66,55 @@ func (tp *TagsPlaintextDB) TableName() string {
 	return "tags_plaintext"
 }
 
+type SchemaVersionDB struct {
+	Version   int       `gorm:"column:version;primaryKey;auto_increment:false"`
+	Name      string    `gorm:"column:name;not null"`
+	AppliedAt time.Time `gorm:"column:applied_at;not null"`
+}
+
+func (sv *SchemaVersionDB) TableName() string {
+	return "schema_version"
+}
+
+// Create the wallet tables
+func pgCreateTablesMultiTableMultiSchema(db *gorm.DB, schema string) error {
+	mtDb := MetadataDB{}
+	errM := db.Scopes(AddSchemaTable(schema, mtDb.TableName())).AutoMigrate(&mtDb)
+	if errM != nil {
+		return errM
+	}
+
+	itDb := ItemsDB{}
+	errM = db.Scopes(AddSchemaTable(schema, itDb.TableName())).AutoMigrate(&itDb)
+	if errM != nil {
+		return errM
+	}
+
+	teDb := TagsEncryptedDB{}
+	errM = db.Scopes(AddSchemaTable(schema, teDb.TableName())).AutoMigrate(&teDb)
+	if errM != nil {
+		return errM
+	}
+
+	tpDb := TagsPlaintextDB{}
+	return db.Scopes(AddSchemaTable(schema, tpDb.TableName())).AutoMigrate(&tpDb)
+}
+
+// Adds a constraint unless the schema already has one with the same name (postgres has no ADD CONSTRAINT IF NOT EXISTS)
+func pgAddConstraint(db *gorm.DB, schema string, name string, sql string) error {
+	var nCount int64
+	errC := db.Raw("SELECT count(*) FROM pg_constraint c JOIN pg_namespace n ON n.oid = c.connamespace WHERE n.nspname = ? AND c.conname = ?",
+		schema, name).Scan(&nCount).Error
+	if errC != nil {
+		return errC
+	}
+	if nCount > 0 {
+		return nil
+	}
+
+	return db.Exec(sql).Error
+}
+
 // Set constrains on tables
 func pgAddConstraintsMultiTableMultiSchema(db *gorm.DB, schema string) error {
 
@@ -94,10 +146,8 @@ func pgAddConstraintsMultiTableMultiSchema(db *gorm.DB, schema string) error {
 		return errA
 	}
 
-	// TODO : check if constraint already exist and dont add it anymore
-	sql = fmt.Sprintf("ALTER TABLE \"%s\".items ADD CONSTRAINT \"%s_fk_items_metadata\" FOREIGN KEY (wallet_id) REFERENCES \"%s\".\"metadata\" (wallet_id) ON DELETE CASCADE ON UPDATE CASCADE", schema, schema, schema)
-	errA = db.Exec(sql).Error
-	if errA != nil && errA.(*pgconn.PgError).Code != "42710" {
+	errA = pgAddConstraint(db, schema, schema+"_fk_items_metadata", fmt.Sprintf("ALTER TABLE \"%s\".items ADD CONSTRAINT \"%s_fk_items_metadata\" FOREIGN KEY (wallet_id) REFERENCES \"%s\".\"metadata\" (wallet_id) ON DELETE CASCADE ON UPDATE CASCADE", schema, schema, schema))
+	if errA != nil {
 		return errA
 	}
 
@@ -119,10 +169,8 @@ func pgAddConstraintsMultiTableMultiSchema(db *gorm.DB, schema string) error {
 		return errA
 	}
 
-	// TODO : check if constraint already exist and dont add it anymore
-	sql = fmt.Sprintf("ALTER TABLE \"%s\".\"tags_encrypted\" ADD CONSTRAINT \"%s_fk_tagse_items\" FOREIGN KEY (wallet_id, item_id) REFERENCES \"%s\".\"items\" (wallet_id,id) ON DELETE CASCADE ON UPDATE CASCADE", schema, schema, schema)
-	errA = db.Exec(sql).Error
-	if errA != nil && errA.(*pgconn.PgError).Code != "42710" {
+	errA = pgAddConstraint(db, schema, schema+"_fk_tagse_items", fmt.Sprintf("ALTER TABLE \"%s\".\"tags_encrypted\" ADD CONSTRAINT \"%s_fk_tagse_items\" FOREIGN KEY (wallet_id, item_id) REFERENCES \"%s\".\"items\" (wallet_id,id) ON DELETE CASCADE ON UPDATE CASCADE", schema, schema, schema))
+	if errA != nil {
 		return errA
 	}
 
@@ -144,10 +192,8 @@ func pgAddConstraintsMultiTableMultiSchema(db *gorm.DB, schema string) error {
 		return errA
 	}
 
-	// TODO : check if constraint already exist and dont add it anymore
-	sql = fmt.Sprintf("ALTER TABLE \"%s\".\"tags_plaintext\" ADD CONSTRAINT \"%s_fk_tagsp_items\" FOREIGN KEY (wallet_id, item_id) REFERENCES \"%s\".\"items\" (wallet_id,id) ON DELETE CASCADE ON UPDATE CASCADE", schema, schema, schema)
-	errA = db.Exec(sql).Error
-	if errA != nil && errA.(*pgconn.PgError).Code != "42710" {
+	errA = pgAddConstraint(db, schema, schema+"_fk_tagsp_items", fmt.Sprintf("ALTER TABLE \"%s\".\"tags_plaintext\" ADD CONSTRAINT \"%s_fk_tagsp_items\" FOREIGN KEY (wallet_id, item_id) REFERENCES \"%s\".\"items\" (wallet_id,id) ON DELETE CASCADE ON UPDATE CASCADE", schema, schema, schema))
+	if errA != nil {
 		return errA
 	}
 
@@ -0,0 +1,137 @@
+/*
+// ******************************************************************
+// Purpose: Shares one database connection pool per DSN between the pg wallets
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package dbutils
+
+import (
+	"errors"
+	"fmt"
+	"github.com/joyride9999/IndySdkGoBindings/wallet"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"sync"
+	"time"
+)
+
+// ErrPoolLimitsConflict is returned when a wallet sets pool limits that differ from the ones of the open pool of its DSN
+var ErrPoolLimitsConflict = errors.New("pool limits conflict with the open pool of the dsn")
+
+type pgPool struct {
+	db     *gorm.DB
+	refs   int
+	limits wallet.StorageConfig // limits the pool was opened with, see configurePool
+}
+
+// pgConnectionManager keeps one pool per DSN, wallets get scoped sessions on it
+// and address their own schema through AddSchemaTable
+type pgConnectionManager struct {
+	mutex sync.Mutex
+	pools map[string]*pgPool
+}
+
+func newPgConnectionManager() *pgConnectionManager {
+	return &pgConnectionManager{pools: make(map[string]*pgPool)}
+}
+
+// Acquire returns a session on the pool for the config DSN, opening the pool on first use.
+// The pool limits are applied when the pool is opened, later wallets of the DSN must set the same limits or none.
+// Every Acquire must be paired with a Release of the same DSN.
+func (m *pgConnectionManager) Acquire(storageCfg wallet.StorageConfig) (*gorm.DB, error) {
+	if len(storageCfg.Dsn) == 0 {
+		return nil, errors.New("missing dsn in storage config")
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	pool, ok := m.pools[storageCfg.Dsn]
+	if !ok {
+		db, errOpen := gorm.Open(postgres.Open(storageCfg.Dsn), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		if errOpen != nil {
+			return nil, errOpen
+		}
+		errCfg := configurePool(db, storageCfg)
+		if errCfg != nil {
+			closePool(db)
+			return nil, errCfg
+		}
+		pool = &pgPool{db: db, limits: storageCfg}
+		m.pools[storageCfg.Dsn] = pool
+	} else {
+		errLimits := checkPoolLimits(pool.limits, storageCfg)
+		if errLimits != nil {
+			return nil, errLimits
+		}
+	}
+	pool.refs++
+
+	return pool.db.Session(&gorm.Session{
+		Logger: logger.Default.LogMode(logger.LogLevel(storageCfg.LogSql)),
+	}), nil
+}
+
+// Release gives back a session, the pool is closed when the last one is released
+func (m *pgConnectionManager) Release(dsn string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	pool, ok := m.pools[dsn]
+	if !ok {
+		return
+	}
+	pool.refs--
+	if pool.refs <= 0 {
+		closePool(pool.db)
+		delete(m.pools, dsn)
+	}
+}
+
+// configurePool applies the pool limits set in the storage config, zero values keep the current setting
+func configurePool(db *gorm.DB, storageCfg wallet.StorageConfig) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if storageCfg.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(storageCfg.MaxOpenConns)
+	}
+	if storageCfg.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(storageCfg.MaxIdleConns)
+	}
+	if storageCfg.ConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(time.Duration(storageCfg.ConnMaxLifetime) * time.Second)
+	}
+	return nil
+}
+
+// checkPoolLimits rejects limits set by a wallet that differ from the ones the pool was opened with
+func checkPoolLimits(limits wallet.StorageConfig, storageCfg wallet.StorageConfig) error {
+	if storageCfg.MaxOpenConns > 0 && storageCfg.MaxOpenConns != limits.MaxOpenConns {
+		return fmt.Errorf("%w: max_open_conns %d, the pool has %d", ErrPoolLimitsConflict, storageCfg.MaxOpenConns, limits.MaxOpenConns)
+	}
+	if storageCfg.MaxIdleConns > 0 && storageCfg.MaxIdleConns != limits.MaxIdleConns {
+		return fmt.Errorf("%w: max_idle_conns %d, the pool has %d", ErrPoolLimitsConflict, storageCfg.MaxIdleConns, limits.MaxIdleConns)
+	}
+	if storageCfg.ConnMaxLifetime > 0 && storageCfg.ConnMaxLifetime != limits.ConnMaxLifetime {
+		return fmt.Errorf("%w: conn_max_lifetime %d, the pool has %d", ErrPoolLimitsConflict, storageCfg.ConnMaxLifetime, limits.ConnMaxLifetime)
+	}
+	return nil
+}
+
+func closePool(db *gorm.DB) {
+	sqlDB, err := db.DB()
+	if err == nil {
+		_ = sqlDB.Close()
+	}
+}
@@ -0,0 +1,148 @@
+/*
+// ******************************************************************
+// Purpose: Versioned migrations of the pg wallet schemas
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package dbutils
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"time"
+)
+
+// pgMigration changes a wallet schema from Version-1 to Version
+type pgMigration struct {
+	Version int
+	Name    string
+	Up      func(db *gorm.DB, schema string) error
+}
+
+// Migrations applied to every wallet schema, in order. Never edit or reorder a released migration, append a new one.
+// The first ones are idempotent so schemas created before versioning are upgraded in place.
+var pgMigrations = []pgMigration{
+	{Version: 1, Name: "create tables", Up: pgCreateTablesMultiTableMultiSchema},
+	{Version: 2, Name: "add indexes and constraints", Up: pgAddConstraintsMultiTableMultiSchema},
+}
+
+// LatestSchemaVersion version of a wallet schema after all the migrations are applied
+func LatestSchemaVersion() int {
+	return pgMigrations[len(pgMigrations)-1].Version
+}
+
+// SchemaVersion returns the last migration applied to a wallet schema, 0 when it was never migrated
+func SchemaVersion(db *gorm.DB, schema string) (int, error) {
+	if !checkSchemaName(schema) {
+		return 0, errors.New("characters not allowed in schema name")
+	}
+
+	var exists bool
+	errE := db.Raw("SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_schema = ? AND table_name = ?)",
+		schema, (&SchemaVersionDB{}).TableName()).Scan(&exists).Error
+	if errE != nil {
+		return 0, errE
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	var version int
+	sv := SchemaVersionDB{}
+	errV := db.Scopes(AddSchemaTable(schema, sv.TableName())).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	if errV != nil {
+		return 0, errV
+	}
+	return version, nil
+}
+
+// MigrateSchema applies the pending migrations to a wallet schema.
+// Call it inside a transaction, the schema_version table stays locked until the transaction ends
+// so concurrent migrations of the same schema are serialized.
+func MigrateSchema(tx *gorm.DB, schema string) error {
+	if !checkSchemaName(schema) {
+		return errors.New("characters not allowed in schema name")
+	}
+
+	sv := SchemaVersionDB{}
+	errM := tx.Scopes(AddSchemaTable(schema, sv.TableName())).AutoMigrate(&sv)
+	if errM != nil {
+		return errM
+	}
+
+	sql := fmt.Sprintf("LOCK TABLE \"%s\".\"%s\" IN EXCLUSIVE MODE", schema, sv.TableName())
+	errL := tx.Exec(sql).Error
+	if errL != nil {
+		return errL
+	}
+
+	current, errV := SchemaVersion(tx, schema)
+	if errV != nil {
+		return errV
+	}
+	if current > LatestSchemaVersion() {
+		return fmt.Errorf("schema %s has version %d, newer than the supported version %d", schema, current, LatestSchemaVersion())
+	}
+
+	for _, m := range pgMigrations {
+		if m.Version <= current {
+			continue
+		}
+
+		errU := m.Up(tx, schema)
+		if errU != nil {
+			return fmt.Errorf("migration %d (%s) of schema %s: %w", m.Version, m.Name, schema, errU)
+		}
+
+		applied := SchemaVersionDB{Version: m.Version, Name: m.Name, AppliedAt: time.Now().UTC()}
+		errR := tx.Scopes(AddSchemaTable(schema, applied.TableName())).Create(&applied).Error
+		if errR != nil {
+			return errR
+		}
+	}
+
+	return nil
+}
+
+// MigrateAll upgrades every wallet schema found in the database to the latest version.
+// Each schema is migrated in its own transaction, the first failure stops the upgrade.
+func MigrateAll(dsn string) error {
+	db, errOpen := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if errOpen != nil {
+		return errOpen
+	}
+	defer closePool(db)
+
+	// a wallet schema is any schema with a metadata table
+	var schemas []string
+	errS := db.Raw("SELECT table_schema FROM information_schema.tables WHERE table_name = ? AND table_type = 'BASE TABLE' "+
+		"AND table_schema NOT IN ('pg_catalog', 'information_schema') ORDER BY table_schema", (&MetadataDB{}).TableName()).Scan(&schemas).Error
+	if errS != nil {
+		return errS
+	}
+
+	for _, schema := range schemas {
+		if !checkSchemaName(schema) {
+			continue
+		}
+
+		errT := db.Transaction(func(tx *gorm.DB) error {
+			return MigrateSchema(tx, schema)
+		})
+		if errT != nil {
+			return errT
+		}
+	}
+
+	return nil
+}
@@ -12,7 +12,8 @@
 package dbutils
 
 import (
-	"github.com/Jeffail/gabs/v2"
+	"errors"
+	"github.com/joyride9999/IndySdkGoBindings/wallet"
 	"testing"
 )
 
@@ -39,21 +40,108 @@ func TestWqlToSql(t *testing.T) {
 		"~t4": "v4"
 	}`
 
-	js, errGabs := gabs.ParseJSON([]byte(queryTest))
-	if errGabs != nil {
-		t.Errorf("Cant parse = '%v'", errGabs)
-	}
-
 	//wql to sql
 	db, _ := testStorage.OpenDb(dsn, "", 4)
-	children := js.ChildrenMap()
-	var qparams []interface{}
-	q := testStorage.OperatorToSql(db, children, &qparams, "test")
+	where, errQuery := WqlToSql(queryTest, "test")
+	if errQuery != nil {
+		t.Errorf("WqlToSql() error = '%v'", errQuery)
+		return
+	}
 
 	//do the select
 	var items []ItemsDB
 	var item ItemsDB
 	tx := db.Scopes(AddSchemaTable("test", item.TableName())).Where("type = ?", "ittypes")
-	tx.Where(q, qparams...).Find(&items)
+	tx.Where(where.Clause, where.Args...).Find(&items)
+
+}
+
+func TestPgConnectionManager(t *testing.T) {
+	dsn := "host=localhost user=wallet password=siemens dbname=wallets port=5432 sslmode=disable"
+	manager := newPgConnectionManager()
+	storageCfg := wallet.StorageConfig{Dsn: dsn, MaxOpenConns: 5, MaxIdleConns: 2, ConnMaxLifetime: 60}
+
+	db1, errA := manager.Acquire(storageCfg)
+	if errA != nil {
+		t.Errorf("Acquire() error = '%v'", errA)
+		return
+	}
+	db2, errA := manager.Acquire(storageCfg)
+	if errA != nil {
+		t.Errorf("Acquire() error = '%v'", errA)
+		return
+	}
+
+	sqlDB1, _ := db1.DB()
+	sqlDB2, _ := db2.DB()
+	if sqlDB1 != sqlDB2 {
+		t.Errorf("Acquire() sessions with the same dsn should share the pool")
+	}
+	if sqlDB1.Stats().MaxOpenConnections != 5 {
+		t.Errorf("Acquire() max open connections = '%d', want = '5'", sqlDB1.Stats().MaxOpenConnections)
+	}
+
+	// a wallet of the same dsn can't change the limits of the shared pool
+	_, errA = manager.Acquire(wallet.StorageConfig{Dsn: dsn, MaxOpenConns: 50})
+	if !errors.Is(errA, ErrPoolLimitsConflict) {
+		t.Errorf("Acquire() conflicting limits error = '%v', want = '%v'", errA, ErrPoolLimitsConflict)
+	}
+	if sqlDB1.Stats().MaxOpenConnections != 5 {
+		t.Errorf("Acquire() max open connections = '%d' after a conflicting acquire, want = '5'", sqlDB1.Stats().MaxOpenConnections)
+	}
+	db3, errA := manager.Acquire(wallet.StorageConfig{Dsn: dsn})
+	if errA != nil {
+		t.Errorf("Acquire() without limits error = '%v'", errA)
+		return
+	}
+	sqlDB3, _ := db3.DB()
+	if sqlDB3 != sqlDB1 {
+		t.Errorf("Acquire() sessions with the same dsn should share the pool")
+	}
+	manager.Release(dsn)
 
+	manager.Release(dsn)
+	if _, ok := manager.pools[dsn]; !ok {
+		t.Errorf("Release() pool closed while still in use")
+	}
+	manager.Release(dsn)
+	if _, ok := manager.pools[dsn]; ok {
+		t.Errorf("Release() pool not closed after the last release")
+	}
+
+	_, errA = manager.Acquire(wallet.StorageConfig{})
+	if errA == nil {
+		t.Errorf("Acquire() without dsn should fail")
+	}
+}
+
+func TestMigrateAll(t *testing.T) {
+	dsn := "host=localhost user=wallet password=siemens dbname=wallets port=5432 sslmode=disable"
+	testStorage := NewPgMultiSchemaStorage()
+	storageConfig := `{"dsn": "` + dsn + `"}`
+
+	_, errCreate := testStorage.Create("migrationtest", storageConfig, "", "metadata")
+	if errCreate != nil {
+		t.Errorf("Create() error = '%v'", errCreate)
+		return
+	}
+	defer testStorage.Delete("migrationtest", storageConfig, "")
+
+	// running the migrations again must be a no-op
+	errMigrate := MigrateAll(dsn)
+	if errMigrate != nil {
+		t.Errorf("MigrateAll() error = '%v'", errMigrate)
+		return
+	}
+
+	db, _ := testStorage.OpenDb(dsn, "", 4)
+	defer testStorage.CloseDb(db)
+	version, errVersion := SchemaVersion(db, "migrationtest")
+	if errVersion != nil {
+		t.Errorf("SchemaVersion() error = '%v'", errVersion)
+		return
+	}
+	if version != LatestSchemaVersion() {
+		t.Errorf("SchemaVersion() = '%d', want = '%d'", version, LatestSchemaVersion())
+	}
 }
@@ -18,13 +18,14 @@ package dbutils
 */
 import "C"
 import (
-	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
-	"github.com/joyride9999/IndySdkGoBindings/wallet"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/Jeffail/gabs/v2"
 	"github.com/jackc/pgconn"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"github.com/joyride9999/IndySdkGoBindings/wallet"
+	"github.com/joyride9999/IndySdkGoBindings/wql"
 	cmap "github.com/orcaman/concurrent-map"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -44,12 +45,14 @@ func checkSchemaName(schema string) bool {
 	return true
 }
 
-// AddSchemaTable Add schema prefix to the queries
+// AddSchemaTable Add schema prefix to the queries. The wallets share the connection pool,
+// so every query must be scoped to the wallet schema with it
 func AddSchemaTable(schema string, table string) func(tx *gorm.DB) *gorm.DB {
 	return func(tx *gorm.DB) *gorm.DB {
 		// bad schema name
-		if !checkSchemaName(schema) {
-			return nil
+		if len(schema) > 0 && !checkSchemaName(schema) {
+			_ = tx.AddError(errors.New("characters not allowed in schema name"))
+			return tx
 		}
 		if len(schema) > 0 {
 			tn := fmt.Sprintf("%s.%s", schema, table)
@@ -65,13 +68,14 @@ func NewPgMultiSchemaStorage() *pgMultiSchemaStorage {
 	storage.MetadataHandles = cmap.New()
 	storage.StorageHandles = cmap.New()
 	storage.HandlesToDb = cmap.New()
+	storage.HandlesToDsn = cmap.New()
+	storage.Connections = newPgConnectionManager()
 	storage.WalletIdsToDb = cmap.New()
 	storage.SearchHandles = cmap.New()
 	storage.SearchHandlesName = cmap.New()
 	storage.SearchHandlesValue = cmap.New()
 	storage.SearchHandlesType = cmap.New()
 	storage.SearchHandlesTags = cmap.New()
-	storage.SearchHandlesIterator = cmap.New()
 	return storage
 }
 
@@ -82,12 +86,13 @@ type pgMultiSchemaStorage struct {
 	StorageHandles        cmap.ConcurrentMap // handle to walletid(pk) (int to string)
 	WalletIdsToDb         cmap.ConcurrentMap //wallet id to db connection (string to *gorm.db)
 	HandlesToDb           cmap.ConcurrentMap //storage handles to db connection(int to *gorm.db)
+	HandlesToDsn          cmap.ConcurrentMap //storage handles to the dsn of their pool (int to string)
+	Connections           *pgConnectionManager
 	SearchHandles         cmap.ConcurrentMap
 	SearchHandlesName     cmap.ConcurrentMap
 	SearchHandlesValue    cmap.ConcurrentMap
 	SearchHandlesType     cmap.ConcurrentMap
 	SearchHandlesTags     cmap.ConcurrentMap
-	SearchHandlesIterator cmap.ConcurrentMap
 	SearchHandleCounter   indyUtils.Counter
 }
 
@@ -193,52 +198,25 @@ func (e *pgMultiSchemaStorage) Create(walletId string, storageConfig string, cre
 		return 210, errU // WalletStorageError: Storage error occurred during wallet operation
 	}
 
-	db, errOpenDb := e.OpenDb(storageCfg.Dsn, walletId, storageCfg.LogSql)
+	db, errOpenDb := e.Connections.Acquire(storageCfg)
 	if errOpenDb != nil {
 		return 210, errOpenDb // WalletStorageError: Storage error occurred during wallet operation
 	}
-	defer e.CloseDb(db)
+	defer e.Connections.Release(storageCfg.Dsn)
 	tx := db.Begin()
 	errSchema := e.CreateSchema(tx, walletId)
 	if errSchema != nil {
 		tx.Rollback()
 		return 203, errSchema // WalletAlreadyExistsError
 	}
-	// auto-migrate GORM struct
-	mtDb := MetadataDB{}
-	errM := tx.Scopes(AddSchemaTable(walletId, mtDb.TableName())).AutoMigrate(mtDb)
-	if errM != nil {
-		tx.Rollback()
-		return 210, errM // WalletStorageError: Storage error occurred during wallet operation
-	}
-	itDb := ItemsDB{}
-	errM = tx.Scopes(AddSchemaTable(walletId, itDb.TableName())).AutoMigrate(itDb)
-	if errM != nil {
-		tx.Rollback()
-		return 210, errM // WalletStorageError: Storage error occurred during wallet operation
-	}
-
-	teDb := TagsEncryptedDB{}
-	errM = tx.Scopes(AddSchemaTable(walletId, teDb.TableName())).AutoMigrate(teDb)
-	if errM != nil {
-		tx.Rollback()
-		return 210, errM // WalletStorageError: Storage error occurred during wallet operation
-	}
-
-	tpDb := TagsPlaintextDB{}
-	errM = tx.Scopes(AddSchemaTable(walletId, tpDb.TableName())).AutoMigrate(tpDb)
+	// create the tables at the latest schema version
+	errM := MigrateSchema(tx, walletId)
 	if errM != nil {
 		tx.Rollback()
 		return 210, errM // WalletStorageError: Storage error occurred during wallet operation
 	}
 
-	errCon := pgAddConstraintsMultiTableMultiSchema(tx, walletId)
-	if errCon != nil {
-		tx.Rollback()
-		return 210, errM // WalletStorageError: Storage error occurred during wallet operation
-	}
-
-	mtDb = MetadataDB{WalletId: walletId, Value: metadata}
+	mtDb := MetadataDB{WalletId: walletId, Value: metadata}
 	txa := tx.Scopes(AddSchemaTable(walletId, mtDb.TableName())).Create(&mtDb)
 	if txa.Error != nil {
 		tx.Rollback()
@@ -267,16 +245,18 @@ func (e *pgMultiSchemaStorage) Open(walletId string, storageConfig string, crede
 		return 0, 210, errU // WalletStorageError: Storage error occurred during wallet operation
 	}
 
-	db, errOpenDb := e.OpenDb(storageCfg.Dsn, walletId, storageCfg.LogSql)
+	db, errOpenDb := e.Connections.Acquire(storageCfg)
 	if errOpenDb != nil {
 		return 0, 210, errOpenDb // WalletStorageError: Storage error occurred during wallet operation
 	}
 
 	tx := db.Scopes(AddSchemaTable(walletId, metadata.TableName())).Find(&metadata, "wallet_id = ?", walletId).Count(&nCount)
 	if tx.Error != nil {
+		e.Connections.Release(storageCfg.Dsn)
 		return 0, 210, tx.Error //WalletStorageError: Storage error occurred during wallet operation
 	}
 	if nCount != 1 {
+		e.Connections.Release(storageCfg.Dsn)
 		return 0, 210, errors.New("Storage error occurred during wallet operation") //WalletStorageError: Storage error occurred during wallet operation
 	}
 
@@ -284,30 +264,39 @@ func (e *pgMultiSchemaStorage) Open(walletId string, storageConfig string, crede
 	e.StorageHandles.Set(handleKey, walletId)
 	e.WalletIdsToDb.Set(walletId, db)
 	e.HandlesToDb.Set(handleKey, db)
+	e.HandlesToDsn.Set(handleKey, storageCfg.Dsn)
 
 	e.MetadataHandles.Set(handleKey, C.CString(metadata.Value))
 
 	return int(nextStorageHandle), 0, nil
 }
 
-//Close - removes the internal handle from the cache map for a wallet id
+//Close - removes the internal handle from the cache map for a wallet id and releases its pool session
 func (e *pgMultiSchemaStorage) Close(storageHandle int) error {
 	keyStorageHandle := strconv.Itoa(storageHandle)
-	db, err := e.GetDbFromHandle(storageHandle)
+	_, err := e.GetDbFromHandle(storageHandle)
 	if err != nil {
 		return err
 	}
-	e.CloseDb(db)
 
+	tmp, ok := e.StorageHandles.Get(keyStorageHandle)
+	if ok {
+		e.WalletIdsToDb.Remove(tmp.(string))
+	}
 	e.StorageHandles.Remove(keyStorageHandle)
 	e.HandlesToDb.Remove(keyStorageHandle)
 
-	tmp, ok := e.StorageHandles.Get(keyStorageHandle)
-	if !ok {
-		return nil
+	metadata, okM := e.MetadataHandles.Get(keyStorageHandle)
+	if okM {
+		C.free(unsafe.Pointer(metadata.(*C.char)))
+	}
+	e.MetadataHandles.Remove(keyStorageHandle)
+
+	dsn, okDsn := e.HandlesToDsn.Get(keyStorageHandle)
+	if okDsn {
+		e.Connections.Release(dsn.(string))
 	}
-	walletId := tmp.(string)
-	e.WalletIdsToDb.Remove(walletId)
+	e.HandlesToDsn.Remove(keyStorageHandle)
 
 	return nil
 }
@@ -321,12 +310,12 @@ func (e *pgMultiSchemaStorage) Delete(walletID string, storageConfig string, cre
 	if errU != nil {
 		return 210, errU // WalletStorageError: Storage error occurred during wallet operation
 	}
-	db, errOpenDb := e.OpenDb(storageCfg.Dsn, walletID, storageCfg.LogSql)
+	db, errOpenDb := e.Connections.Acquire(storageCfg)
 	if errOpenDb != nil {
 		return 210, errOpenDb // WalletStorageError: Storage error occurred during wallet operation
 	}
 
-	defer e.CloseDb(db)
+	defer e.Connections.Release(storageCfg.Dsn)
 	tx := db.Begin()
 
 	var metaData MetadataDB
@@ -409,7 +398,7 @@ func (e *pgMultiSchemaStorage) AddRecord(storageHandle int, recordType string, r
 	tags, errParse := gabs.ParseJSON([]byte(tagsJson))
 	if errParse != nil {
 		tx.Rollback()
-		return 104, errors.New(indyUtils.GetIndyError(104)) //104: "CommonInvalidParam5: Caller passed invalid value as param 5 (null, invalid json and etc..)",
+		return 104, indyUtils.NewIndyError(104) //104: "CommonInvalidParam5: Caller passed invalid value as param 5 (null, invalid json and etc..)",
 	}
 
 	children := tags.ChildrenMap()
@@ -418,7 +407,7 @@ func (e *pgMultiSchemaStorage) AddRecord(storageHandle int, recordType string, r
 		tagValue, okC := child.Data().(string)
 		if !okC {
 			tx.Rollback()
-			return 104, errors.New(indyUtils.GetIndyError(104)) //104: "CommonInvalidParam5: Caller passed invalid value as param 5 (null, invalid json and etc..)",
+			return 104, indyUtils.NewIndyError(104) //104: "CommonInvalidParam5: Caller passed invalid value as param 5 (null, invalid json and etc..)",
 		}
 		if k[0:1] == "~" { // tags unencrypted
 			tp := TagsPlaintextDB{
@@ -457,12 +446,12 @@ func (e *pgMultiSchemaStorage) UpdateRecordValue(storageHandle int, recordType s
 
 	walletID, errW := e.GetWalletIdFromHandle(storageHandle)
 	if errW != nil {
-		return 200, errors.New(indyUtils.GetIndyError(200)) //"WalletInvalidHandle: Caller passed invalid wallet handle"
+		return 200, indyUtils.NewIndyError(200) //"WalletInvalidHandle: Caller passed invalid wallet handle"
 	}
 
 	db, err := e.GetDbFromHandle(storageHandle)
 	if err != nil {
-		return 200, errors.New(indyUtils.GetIndyError(200)) //"WalletInvalidHandle: Caller passed invalid wallet handle"
+		return 200, indyUtils.NewIndyError(200) //"WalletInvalidHandle: Caller passed invalid wallet handle"
 	}
 
 	//start transaction
@@ -477,13 +466,13 @@ func (e *pgMultiSchemaStorage) UpdateRecordValue(storageHandle int, recordType s
 	switch tu.RowsAffected {
 	case 0:
 		tx.Rollback()
-		return 212, errors.New(indyUtils.GetIndyError(212)) // "WalletItemNotFound: Requested wallet item not found"
+		return 212, indyUtils.NewIndyError(212) // "WalletItemNotFound: Requested wallet item not found"
 	case 1:
 		tx.Commit()
 		return 0, nil
 	default:
 		tx.Rollback()
-		return 112, errors.New(indyUtils.GetIndyError(112)) // 	112: "CommonInvalidState: Invalid library state was detected in runtime. It signals library bug",
+		return 112, indyUtils.NewIndyError(112) // 	112: "CommonInvalidState: Invalid library state was detected in runtime. It signals library bug",
 	}
 
 }
@@ -493,12 +482,12 @@ func (e *pgMultiSchemaStorage) UpdateRecordValue(storageHandle int, recordType s
 func (e *pgMultiSchemaStorage) UpdateRecordTags(storageHandle int, recordType string, recordId string, tagsJson string) (int, error) {
 	walletId, errW := e.GetWalletIdFromHandle(storageHandle)
 	if errW != nil {
-		return 200, errors.New(indyUtils.GetIndyError(200)) //"WalletInvalidHandle: Caller passed invalid wallet handle"
+		return 200, indyUtils.NewIndyError(200) //"WalletInvalidHandle: Caller passed invalid wallet handle"
 	}
 
 	db, err := e.GetDbFromHandle(storageHandle)
 	if err != nil {
-		return 200, errors.New(indyUtils.GetIndyError(200)) //"WalletInvalidHandle: Caller passed invalid wallet handle"
+		return 200, indyUtils.NewIndyError(200) //"WalletInvalidHandle: Caller passed invalid wallet handle"
 	}
 
 	//start transaction
@@ -513,7 +502,7 @@ func (e *pgMultiSchemaStorage) UpdateRecordTags(storageHandle int, recordType st
 
 	if tS.RowsAffected != 1 {
 		tx.Rollback()
-		return 112, errors.New(indyUtils.GetIndyError(112)) // 	112: "CommonInvalidState: Invalid library state was detected in runtime. It signals library bug",
+		return 112, indyUtils.NewIndyError(112) // 	112: "CommonInvalidState: Invalid library state was detected in runtime. It signals library bug",
 	}
 
 	tagsE := TagsEncryptedDB{}
@@ -537,7 +526,7 @@ func (e *pgMultiSchemaStorage) UpdateRecordTags(storageHandle int, recordType st
 		tags, errParse := gabs.ParseJSON([]byte(tagsJson))
 		if errParse != nil {
 			tx.Rollback()
-			return 103, errors.New(indyUtils.GetIndyError(103)) //103: "CommonInvalidParam4: Caller passed invalid value as param 4 (null, invalid json and etc..)",
+			return 103, indyUtils.NewIndyError(103) //103: "CommonInvalidParam4: Caller passed invalid value as param 4 (null, invalid json and etc..)",
 		}
 
 		children := tags.ChildrenMap()
@@ -546,7 +535,7 @@ func (e *pgMultiSchemaStorage) UpdateRecordTags(storageHandle int, recordType st
 			tagValue, okC := child.Data().(string)
 			if !okC {
 				tx.Rollback()
-				return 103, errors.New(indyUtils.GetIndyError(103)) //103: "CommonInvalidParam4: Caller passed invalid value as param 4 (null, invalid json and etc..)",
+				return 103, indyUtils.NewIndyError(103) //103: "CommonInvalidParam4: Caller passed invalid value as param 4 (null, invalid json and etc..)",
 			}
 			if key[0:1] == "~" { // tags unencrypted
 				tagsP = TagsPlaintextDB{
@@ -586,12 +575,12 @@ func (e *pgMultiSchemaStorage) UpdateRecordTags(storageHandle int, recordType st
 func (e *pgMultiSchemaStorage) AddRecordTags(storageHandle int, recordType string, recordId string, tagsJson string) (int, error) {
 	walletId, errW := e.GetWalletIdFromHandle(storageHandle)
 	if errW != nil {
-		return 200, errors.New(indyUtils.GetIndyError(200)) //"WalletInvalidHandle: Caller passed invalid wallet handle"
+		return 200, indyUtils.NewIndyError(200) //"WalletInvalidHandle: Caller passed invalid wallet handle"
 	}
 
 	db, err := e.GetDbFromHandle(storageHandle)
 	if err != nil {
-		return 200, errors.New(indyUtils.GetIndyError(200)) //"WalletInvalidHandle: Caller passed invalid wallet handle"
+		return 200, indyUtils.NewIndyError(200) //"WalletInvalidHandle: Caller passed invalid wallet handle"
 	}
 
 	//start transaction
@@ -606,7 +595,7 @@ func (e *pgMultiSchemaStorage) AddRecordTags(storageHandle int, recordType strin
 
 	if tS.RowsAffected != 1 {
 		tx.Rollback()
-		return 112, errors.New(indyUtils.GetIndyError(112)) // 	112: "CommonInvalidState: Invalid library state was detected in runtime. It signals library bug",
+		return 112, indyUtils.NewIndyError(112) // 	112: "CommonInvalidState: Invalid library state was detected in runtime. It signals library bug",
 	}
 
 	tagsE := TagsEncryptedDB{}
@@ -617,7 +606,7 @@ func (e *pgMultiSchemaStorage) AddRecordTags(storageHandle int, recordType strin
 		tags, errParse := gabs.ParseJSON([]byte(tagsJson))
 		if errParse != nil {
 			tx.Rollback()
-			return 103, errors.New(indyUtils.GetIndyError(103)) //103: "CommonInvalidParam4: Caller passed invalid value as param 4 (null, invalid json and etc..)",
+			return 103, indyUtils.NewIndyError(103) //103: "CommonInvalidParam4: Caller passed invalid value as param 4 (null, invalid json and etc..)",
 		}
 
 		children := tags.ChildrenMap()
@@ -626,7 +615,7 @@ func (e *pgMultiSchemaStorage) AddRecordTags(storageHandle int, recordType strin
 			tagValue, okC := child.Data().(string)
 			if !okC {
 				tx.Rollback()
-				return 103, errors.New(indyUtils.GetIndyError(103)) //103: "CommonInvalidParam4: Caller passed invalid value as param 4 (null, invalid json and etc..)",
+				return 103, indyUtils.NewIndyError(103) //103: "CommonInvalidParam4: Caller passed invalid value as param 4 (null, invalid json and etc..)",
 			}
 			if key[0:1] == "~" { // tags unencrypted
 				tagsP = TagsPlaintextDB{
@@ -666,12 +655,12 @@ func (e *pgMultiSchemaStorage) AddRecordTags(storageHandle int, recordType strin
 func (e *pgMultiSchemaStorage) DeleteRecordTags(storageHandle int, recordType string, recordId string, tagsJson string) (int, error) {
 	walletId, errW := e.GetWalletIdFromHandle(storageHandle)
 	if errW != nil {
-		return 200, errors.New(indyUtils.GetIndyError(200)) //"WalletInvalidHandle: Caller passed invalid wallet handle"
+		return 200, indyUtils.NewIndyError(200) //"WalletInvalidHandle: Caller passed invalid wallet handle"
 	}
 
 	db, err := e.GetDbFromHandle(storageHandle)
 	if err != nil {
-		return 200, errors.New(indyUtils.GetIndyError(200)) //"WalletInvalidHandle: Caller passed invalid wallet handle"
+		return 200, indyUtils.NewIndyError(200) //"WalletInvalidHandle: Caller passed invalid wallet handle"
 	}
 
 	//start transaction
@@ -687,7 +676,7 @@ func (e *pgMultiSchemaStorage) DeleteRecordTags(storageHandle int, recordType st
 
 	if tS.RowsAffected != 1 {
 		tx.Rollback()
-		return 112, errors.New(indyUtils.GetIndyError(112)) // 	112: "CommonInvalidState: Invalid library state was detected in runtime. It signals library bug",
+		return 112, indyUtils.NewIndyError(112) // 	112: "CommonInvalidState: Invalid library state was detected in runtime. It signals library bug",
 	}
 
 	tagsE := TagsEncryptedDB{}
@@ -698,7 +687,7 @@ func (e *pgMultiSchemaStorage) DeleteRecordTags(storageHandle int, recordType st
 		tags, errParse := gabs.ParseJSON([]byte(tagsJson))
 		if errParse != nil {
 			tx.Rollback()
-			return 103, errors.New(indyUtils.GetIndyError(103)) //103: "CommonInvalidParam4: Caller passed invalid value as param 4 (null, invalid json and etc..)",
+			return 103, indyUtils.NewIndyError(103) //103: "CommonInvalidParam4: Caller passed invalid value as param 4 (null, invalid json and etc..)",
 		}
 
 		children := tags.ChildrenMap()
@@ -707,7 +696,7 @@ func (e *pgMultiSchemaStorage) DeleteRecordTags(storageHandle int, recordType st
 			tagValue, okC := child.Data().(string)
 			if !okC {
 				tx.Rollback()
-				return 103, errors.New(indyUtils.GetIndyError(103)) //103: "CommonInvalidParam4: Caller passed invalid value as param 4 (null, invalid json and etc..)",
+				return 103, indyUtils.NewIndyError(103) //103: "CommonInvalidParam4: Caller passed invalid value as param 4 (null, invalid json and etc..)",
 			}
 			if key[0:1] == "~" { // tags unencrypted
 				tagsP = TagsPlaintextDB{
@@ -747,12 +736,12 @@ func (e *pgMultiSchemaStorage) DeleteRecordTags(storageHandle int, recordType st
 func (e *pgMultiSchemaStorage) DeleteRecord(storageHandle int, recordType string, recordId string) (int, error) {
 	walletId, errW := e.GetWalletIdFromHandle(storageHandle)
 	if errW != nil {
-		return 200, errors.New(indyUtils.GetIndyError(200)) //"WalletInvalidHandle: Caller passed invalid wallet handle"
+		return 200, indyUtils.NewIndyError(200) //"WalletInvalidHandle: Caller passed invalid wallet handle"
 	}
 
 	db, err := e.GetDbFromHandle(storageHandle)
 	if err != nil {
-		return 200, errors.New(indyUtils.GetIndyError(200)) //"WalletInvalidHandle: Caller passed invalid wallet handle"
+		return 200, indyUtils.NewIndyError(200) //"WalletInvalidHandle: Caller passed invalid wallet handle"
 	}
 
 	//start transaction
@@ -788,28 +777,55 @@ func (e *pgMultiSchemaStorage) GetRecordHandle(storageHandle int, recordType str
 		return 0, 200, errors.New("WalletInvalidHandle: Caller passed invalid wallet handle")
 	}
 
-	var nCount int64
-	tx := db.Scopes(AddSchemaTable(walletId, item.TableName())).Find(&item, "wallet_id=? and type=? and name=?", walletId, recordType, recordName).Count(&nCount)
+	options, errO := wallet.ParseRecordOptions(optionsJson)
+	if errO != nil {
+		return 0, 113, indyUtils.NewIndyError(113) // CommonInvalidStructure
+	}
+
+	tx := db.Scopes(AddSchemaTable(walletId, item.TableName()))
+	if !options.RetrieveValue {
+		tx = tx.Select(itemColumnsWithoutValue)
+	}
+	tx = tx.Limit(1).Find(&item, "wallet_id=? and type=? and name=?", walletId, recordType, recordName)
 
 	if tx.Error != nil {
 		return 0, 210, tx.Error // WalletStorageError: Storage error occurred during wallet operation
 	}
-	if nCount == 0 {
+	if tx.RowsAffected == 0 {
 		return 0, 212, errors.New("WalletItemNotFound: Requested wallet item not found")
 	}
 
+	var tagsJson string
+	if options.RetrieveTags {
+		tags, errT := loadTagsJson(db, walletId, []int64{item.Id})
+		if errT != nil {
+			return 0, 210, errT // WalletStorageError: Storage error occurred during wallet operation
+		}
+		tagsJson = tags[item.Id]
+	}
+
 	sh, shKey := e.SearchHandleCounter.Get()
-	e.SearchHandles.Set(shKey, item)
-	e.SearchHandlesName.Set(shKey, C.CString(item.Name))
-	e.SearchHandlesValue.Set(shKey, wallet.RecordValue{
-		Len:   len(item.Value),
-		Value: C.CBytes(item.Value),
-	})
-	e.SearchHandlesType.Set(shKey, C.CString(item.Type))
+	e.setRecordHandle(shKey, item, options.RetrieveValue, options.RetrieveTags, tagsJson)
 
 	return int(sh), 0, nil
 }
 
+// setRecordHandle - keeps the fetched record for the GetRecord* callbacks until FreeRecord
+func (e *pgMultiSchemaStorage) setRecordHandle(handleKey string, item ItemsDB, withValue bool, withTags bool, tagsJson string) {
+	e.SearchHandles.Set(handleKey, item)
+	e.SearchHandlesName.Set(handleKey, C.CString(item.Name))
+	if withValue {
+		e.SearchHandlesValue.Set(handleKey, wallet.RecordValue{
+			Len:   len(item.Value),
+			Value: C.CBytes(item.Value),
+		})
+	}
+	e.SearchHandlesType.Set(handleKey, C.CString(item.Type))
+	if withTags {
+		e.SearchHandlesTags.Set(handleKey, C.CString(tagsJson))
+	}
+}
+
 //GetRecordId - get record id (item.name) ... not to be confused with row id( item.id)
 func (e *pgMultiSchemaStorage) GetRecordId(storageHandle int, recordHandle int) (unsafe.Pointer, int, error) {
 
@@ -821,7 +837,7 @@ func (e *pgMultiSchemaStorage) GetRecordId(storageHandle int, recordHandle int)
 
 	item, okCast := tmp.(*C.char)
 	if !okCast {
-		return nil, 208, errors.New(indyUtils.GetIndyError(208)) //WalletInputError: Input provided to wallet operations is considered not valid
+		return nil, 208, indyUtils.NewIndyError(208) //WalletInputError: Input provided to wallet operations is considered not valid
 	}
 
 	return unsafe.Pointer(item), 0, nil
@@ -838,7 +854,7 @@ func (e *pgMultiSchemaStorage) GetRecordType(storageHandle int, recordHandle int
 
 	item, okCast := tmp.(*C.char)
 	if !okCast {
-		return nil, 208, errors.New(indyUtils.GetIndyError(208)) //WalletInputError: Input provided to wallet operations is considered not valid
+		return nil, 208, indyUtils.NewIndyError(208) //WalletInputError: Input provided to wallet operations is considered not valid
 	}
 
 	return unsafe.Pointer(item), 0, nil
@@ -854,15 +870,24 @@ func (e *pgMultiSchemaStorage) GetRecordValue(storageHandle int, recordHandle in
 
 	rv, okCast := tmp.(wallet.RecordValue)
 	if !okCast {
-		return wallet.RecordValue{}, 208, errors.New(indyUtils.GetIndyError(208)) //WalletInputError: Input provided to wallet operations is considered not valid
+		return wallet.RecordValue{}, 208, indyUtils.NewIndyError(208) //WalletInputError: Input provided to wallet operations is considered not valid
 	}
 
 	return rv, 0, nil
 }
 
-//GetRecordTags - get record tags.
+//GetRecordTags - get record tags, loaded with the record when requested by the options, else loaded now
 func (e *pgMultiSchemaStorage) GetRecordTags(storageHandle int, recordHandle int) (unsafe.Pointer, int, error) {
 	searchHandleKey := strconv.Itoa(recordHandle)
+	cached, okCached := e.SearchHandlesTags.Get(searchHandleKey)
+	if okCached {
+		upTags, okCast := cached.(*C.char)
+		if !okCast {
+			return nil, 208, indyUtils.NewIndyError(208) //WalletInputError: Input provided to wallet operations is considered not valid
+		}
+		return unsafe.Pointer(upTags), 0, nil
+	}
+
 	tmp, ok := e.SearchHandles.Get(searchHandleKey)
 	if !ok {
 		return nil, 200, errors.New("WalletInvalidHandle: Caller passed invalid wallet handle")
@@ -870,44 +895,63 @@ func (e *pgMultiSchemaStorage) GetRecordTags(storageHandle int, recordHandle int
 
 	db, err := e.GetDbFromHandle(storageHandle)
 	if err != nil {
-		return nil, 200, errors.New(indyUtils.GetIndyError(200)) //"WalletInvalidHandle: Caller passed invalid wallet handle"
+		return nil, 200, indyUtils.NewIndyError(200) //"WalletInvalidHandle: Caller passed invalid wallet handle"
 	}
 
 	item, okCast := tmp.(ItemsDB)
 	if !okCast {
-		return nil, 208, errors.New(indyUtils.GetIndyError(208)) //WalletInputError: Input provided to wallet operations is considered not valid
+		return nil, 208, indyUtils.NewIndyError(208) //WalletInputError: Input provided to wallet operations is considered not valid
 	}
 
+	tags, errT := loadTagsJson(db, item.WalletId, []int64{item.Id})
+	if errT != nil {
+		return nil, 210, errT // WalletStorageError: Storage error occurred during wallet operation
+	}
+
+	upTags := C.CString(tags[item.Id])
+	e.SearchHandlesTags.Set(searchHandleKey, upTags)
+
+	return unsafe.Pointer(upTags), 0, nil
+}
+
+// loadTagsJson - loads the plaintext and encrypted tags of the given items as tags json (item id to json)
+func loadTagsJson(db *gorm.DB, walletId string, itemIds []int64) (map[int64]string, error) {
 	var tp TagsPlaintextDB
 	var te TagsEncryptedDB
 	var tps []TagsPlaintextDB
 	var tes []TagsEncryptedDB
 
-	errTp := db.Scopes(AddSchemaTable(item.WalletId, tp.TableName())).Where("item_id=?", item.Id).Find(&tps).Error
+	errTp := db.Scopes(AddSchemaTable(walletId, tp.TableName())).Where("item_id IN ?", itemIds).Find(&tps).Error
 	if errTp != nil {
-		return nil, 210, errTp // WalletStorageError: Storage error occurred during wallet operation
+		return nil, errTp
 	}
 
-	errTe := db.Scopes(AddSchemaTable(item.WalletId, te.TableName())).Where("item_id=?", item.Id).Find(&tes).Error
+	errTe := db.Scopes(AddSchemaTable(walletId, te.TableName())).Where("item_id IN ?", itemIds).Find(&tes).Error
 	if errTe != nil {
-		return nil, 210, errTe // WalletStorageError: Storage error occurred during wallet operation
+		return nil, errTe
 	}
 
-	jsonObj := gabs.New()
+	tags := make(map[int64]map[string]string, len(itemIds))
+	for _, id := range itemIds {
+		tags[id] = make(map[string]string)
+	}
 	for _, tagP := range tps {
-		jsonObj.Set(tagP.Value, tagP.Name)
+		tags[tagP.ItemId][tagP.Name] = tagP.Value
 	}
-
 	for _, tagE := range tes {
-		jsonObj.Set(tagE.Value, tagE.Name)
+		tags[tagE.ItemId][tagE.Name] = tagE.Value
 	}
 
-	tags := jsonObj.String()
-
-	upTags := C.CString(tags)
-	e.SearchHandlesTags.Set(searchHandleKey, upTags)
+	tagsJson := make(map[int64]string, len(tags))
+	for id, itemTags := range tags {
+		bTags, errM := json.Marshal(itemTags)
+		if errM != nil {
+			return nil, errM
+		}
+		tagsJson[id] = string(bTags)
+	}
 
-	return unsafe.Pointer(upTags), 0, nil
+	return tagsJson, nil
 }
 
 //FreeRecord - free search handle record
@@ -938,14 +982,12 @@ func (e *pgMultiSchemaStorage) FreeRecord(storageHandle int, recordHandle int) e
 
 	pTags, okTags := e.SearchHandlesTags.Get(searchHandleKey)
 	if okTags {
-
-		upTags, okCast := pTags.(unsafe.Pointer)
+		upTags, okCast := pTags.(*C.char)
 		if okCast {
-			C.free(upTags)
+			C.free(unsafe.Pointer(upTags))
 		}
-
 	}
-	e.SearchHandlesName.Remove(searchHandleKey)
+	e.SearchHandlesTags.Remove(searchHandleKey)
 
 	return nil
 }
@@ -956,12 +998,12 @@ func (e *pgMultiSchemaStorage) GetStorageMetadata(storageHandle int) (unsafe.Poi
 	handleKey := strconv.Itoa(storageHandle)
 	tmp, ok := e.MetadataHandles.Get(handleKey)
 	if !ok {
-		return nil, 0, 212, errors.New(indyUtils.GetIndyError(212)) //"WalletItemNotFound: Requested wallet item not found"
+		return nil, 0, 212, indyUtils.NewIndyError(212) //"WalletItemNotFound: Requested wallet item not found"
 	}
 
 	metadata, okM := tmp.(*C.char)
 	if !okM {
-		return nil, 0, 210, errors.New(indyUtils.GetIndyError(210)) //"WalletStorageError: Storage error occurred during wallet operation"
+		return nil, 0, 210, indyUtils.NewIndyError(210) //"WalletStorageError: Storage error occurred during wallet operation"
 	}
 
 	return unsafe.Pointer(metadata), storageHandle, 0, nil
@@ -972,11 +1014,11 @@ func (e *pgMultiSchemaStorage) GetStorageMetadata(storageHandle int) (unsafe.Poi
 func (e *pgMultiSchemaStorage) SetStorageMetadata(storageHandle int, metadata string) (int, error) {
 	walletId, errW := e.GetWalletIdFromHandle(storageHandle)
 	if errW != nil {
-		return 200, errors.New(indyUtils.GetIndyError(200)) //"WalletInvalidHandle: Caller passed invalid wallet handle"
+		return 200, indyUtils.NewIndyError(200) //"WalletInvalidHandle: Caller passed invalid wallet handle"
 	}
 	db, err := e.GetDbFromHandle(storageHandle)
 	if err != nil {
-		return 200, errors.New(indyUtils.GetIndyError(200)) //"WalletInvalidHandle: Caller passed invalid wallet handle"
+		return 200, indyUtils.NewIndyError(200) //"WalletInvalidHandle: Caller passed invalid wallet handle"
 	}
 
 	mt := MetadataDB{}
@@ -1001,125 +1043,113 @@ func (e *pgMultiSchemaStorage) FreeStorageMetadata(storageHandle int, metadataHa
 	return nil
 }
 
-//OpenSearch - search handle
+//OpenSearch - search handle, results are loaded lazily by FetchSearchNext
 func (e *pgMultiSchemaStorage) OpenSearch(storageHandle int, recordType string, queryJson string, optionsJson string) (int, int, error) {
 
 	walletId, errW := e.GetWalletIdFromHandle(storageHandle)
 	if errW != nil {
-		return 0, 200, errors.New(indyUtils.GetIndyError(200)) //"WalletInvalidHandle: Caller passed invalid wallet handle"
+		return 0, 200, indyUtils.NewIndyError(200) //"WalletInvalidHandle: Caller passed invalid wallet handle"
 	}
 
 	db, err := e.GetDbFromHandle(storageHandle)
 	if err != nil {
-		return 0, 200, errors.New(indyUtils.GetIndyError(200)) //"WalletInvalidHandle: Caller passed invalid wallet handle"
+		return 0, 200, indyUtils.NewIndyError(200) //"WalletInvalidHandle: Caller passed invalid wallet handle"
 	}
 
-	wqlQuery, errGabs := gabs.ParseJSON([]byte(queryJson))
-	if errGabs != nil {
-		return 0, 113, errors.New(indyUtils.GetIndyError(113))
+	where, errQuery := WqlToSql(queryJson, walletId)
+	if errQuery != nil {
+		if errors.Is(errQuery, wql.ErrInvalidJson) {
+			return 0, 113, indyUtils.NewIndyError(113)
+		}
+		return 0, 214, indyUtils.NewIndyError(214) // WalletQueryError: Returned if provided wallet query is invalid
 	}
 
-	children := wqlQuery.ChildrenMap()
-	var qparams []interface{}
-	sqlClause := e.OperatorToSql(db, children, &qparams, walletId)
-
-	//do the select
-	var items []ItemsDB
-	var item ItemsDB
-	tx := db.Scopes(AddSchemaTable(walletId, item.TableName())).Where("type = ?", recordType)
-	errF := tx.Where(sqlClause, qparams...).Find(&items).Error
-	if errF != nil {
-		return 0, 208, errF //WalletInputError: Input provided to wallet operations is considered not valid
+	options, errO := wallet.ParseSearchOptions(optionsJson)
+	if errO != nil {
+		return 0, 113, indyUtils.NewIndyError(113) // CommonInvalidStructure
 	}
 
+	cursor := newPgSearchCursor(db, walletId, options, func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("type = ?", recordType).Where(where.Clause, where.Args...)
+	})
+
 	sh, shKey := e.SearchHandleCounter.Get()
-	e.SearchHandles.Set(shKey, items)
-	e.SearchHandlesIterator.Set(shKey, 0)
+	e.SearchHandles.Set(shKey, cursor)
 
 	return int(sh), 0, nil
 }
 
-//OpenSearchAll - search handle
+//OpenSearchAll - search handle, results are loaded lazily by FetchSearchNext
 //TODO: testme
 func (e *pgMultiSchemaStorage) OpenSearchAll(storageHandle int) (int, int, error) {
 	walletId, errW := e.GetWalletIdFromHandle(storageHandle)
 	if errW != nil {
-		return 0, 200, errors.New(indyUtils.GetIndyError(200)) //"WalletInvalidHandle: Caller passed invalid wallet handle"
+		return 0, 200, indyUtils.NewIndyError(200) //"WalletInvalidHandle: Caller passed invalid wallet handle"
 	}
 
 	db, err := e.GetDbFromHandle(storageHandle)
 	if err != nil {
-		return 0, 200, errors.New(indyUtils.GetIndyError(200)) //"WalletInvalidHandle: Caller passed invalid wallet handle"
+		return 0, 200, indyUtils.NewIndyError(200) //"WalletInvalidHandle: Caller passed invalid wallet handle"
 	}
 
-	//do the select
-	var items []ItemsDB
-	var item ItemsDB
-	errF := db.Scopes(AddSchemaTable(walletId, item.TableName())).Where("wallet_id = ?", walletId).Find(&items).Error
-
-	if errF != nil {
-		return 0, 208, errF //WalletInputError: Input provided to wallet operations is considered not valid
-	}
+	// libindy reads everything when exporting a wallet
+	options := wallet.SearchOptions{RetrieveRecords: true, RetrieveType: true, RetrieveValue: true, RetrieveTags: true}
+	cursor := newPgSearchCursor(db, walletId, options, func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("wallet_id = ?", walletId)
+	})
 
 	sh, shKey := e.SearchHandleCounter.Get()
-	e.SearchHandles.Set(shKey, items)
-	e.SearchHandlesIterator.Set(shKey, 0)
+	e.SearchHandles.Set(shKey, cursor)
 
 	return int(sh), 0, nil
 }
 
-//GetSearchTotalCount - gets results count
+//GetSearchTotalCount - gets results count with a separate COUNT query
 func (e *pgMultiSchemaStorage) GetSearchTotalCount(storageHandle int, searchHandle int) (int, int, error) {
 	searchHandleKey := strconv.Itoa(searchHandle)
 	tmp, ok := e.SearchHandles.Get(searchHandleKey)
 
 	if !ok {
-		return 0, 208, errors.New(indyUtils.GetIndyError(208)) //WalletInputError: Input provided to wallet operations is considered not valid
+		return 0, 208, indyUtils.NewIndyError(208) //WalletInputError: Input provided to wallet operations is considered not valid
 	}
 
-	items, okCast := tmp.([]ItemsDB)
+	cursor, okCast := tmp.(*pgSearchCursor)
 	if !okCast {
-		return 0, 208, errors.New(indyUtils.GetIndyError(208)) //WalletInputError: Input provided to wallet operations is considered not valid
+		return 0, 208, indyUtils.NewIndyError(208) //WalletInputError: Input provided to wallet operations is considered not valid
 	}
 
-	return len(items), 0, nil
+	nCount, errC := cursor.Count()
+	if errC != nil {
+		return 0, 210, errC // WalletStorageError: Storage error occurred during wallet operation
+	}
+
+	return int(nCount), 0, nil
 }
 
-//FetchSearchNext - advance search counter
+//FetchSearchNext - advance search cursor, the next page is loaded when the current one is consumed
 func (e *pgMultiSchemaStorage) FetchSearchNext(storageHandle int, searchHandle int) (int, int, error) {
 	searchHandleKey := strconv.Itoa(searchHandle)
 	tmp, ok := e.SearchHandles.Get(searchHandleKey)
 
 	if !ok {
-		return 0, 208, errors.New(indyUtils.GetIndyError(208)) //WalletInputError: Input provided to wallet operations is considered not valid
+		return 0, 208, indyUtils.NewIndyError(208) //WalletInputError: Input provided to wallet operations is considered not valid
 	}
 
-	items, okCast := tmp.([]ItemsDB)
+	cursor, okCast := tmp.(*pgSearchCursor)
 	if !okCast {
-		return 0, 208, errors.New(indyUtils.GetIndyError(208)) //WalletInputError: Input provided to wallet operations is considered not valid
+		return 0, 208, indyUtils.NewIndyError(208) //WalletInputError: Input provided to wallet operations is considered not valid
 	}
 
-	tmp1, okI := e.SearchHandlesIterator.Get(searchHandleKey)
-	if !okI {
-		return 0, 208, errors.New(indyUtils.GetIndyError(208)) //WalletInputError: Input provided to wallet operations is considered not valid
+	item, tagsJson, found, errN := cursor.Next()
+	if errN != nil {
+		return 0, 210, errN // WalletStorageError: Storage error occurred during wallet operation
 	}
-
-	counter := tmp1.(int)
-
-	//bounds check
-	if counter >= len(items) {
-		return 0, 212, errors.New(indyUtils.GetIndyError(212)) //"WalletItemNotFound: Requested wallet item not found"
+	if !found {
+		return 0, 212, indyUtils.NewIndyError(212) //"WalletItemNotFound: Requested wallet item not found"
 	}
-	e.SearchHandlesIterator.Set(searchHandleKey, counter+1)
-	item := items[counter]
+
 	handleId, handleKey := e.SearchHandleCounter.Get()
-	e.SearchHandles.Set(handleKey, item)
-	e.SearchHandlesName.Set(handleKey, C.CString(item.Name))
-	e.SearchHandlesValue.Set(handleKey, wallet.RecordValue{
-		Len:   len(item.Value),
-		Value: C.CBytes(item.Value),
-	})
-	e.SearchHandlesType.Set(handleKey, C.CString(item.Type))
+	e.setRecordHandle(handleKey, item, cursor.options.RetrieveValue, cursor.options.RetrieveTags, tagsJson)
 
 	return int(handleId), 0, nil
 }
@@ -1128,175 +1158,33 @@ func (e *pgMultiSchemaStorage) FetchSearchNext(storageHandle int, searchHandle i
 func (e *pgMultiSchemaStorage) FreeSearch(storageHandle int, searchHandle int) error {
 	searchHandleKey := strconv.Itoa(searchHandle)
 	e.SearchHandles.Remove(searchHandleKey)
-	e.SearchHandlesIterator.Remove(searchHandleKey)
-
-	pName, okName := e.SearchHandlesName.Get(searchHandleKey)
-	if okName {
-		C.free(unsafe.Pointer(pName.(*C.char)))
-	}
-	e.SearchHandlesName.Remove(searchHandleKey)
-
-	pValue, okValue := e.SearchHandlesValue.Get(searchHandleKey)
-	if okValue {
-		rv, okCast := pValue.(wallet.RecordValue)
-		if okCast {
-			C.free(rv.Value)
-		}
-	}
-	e.SearchHandlesValue.Remove(searchHandleKey)
-
-	pType, okType := e.SearchHandlesType.Get(searchHandleKey)
-	if okType {
-		C.free(unsafe.Pointer(pType.(*C.char)))
-	}
-	e.SearchHandlesType.Remove(searchHandleKey)
 
 	return nil
 }
 
-// AndToSql - and WQL to sql
-func (e *pgMultiSchemaStorage) AndToSql(db *gorm.DB, jsObject []*gabs.Container, qparams *[]interface{}, schema string, bOp bool) string {
-
-	q := " ( "
-	if bOp {
-		q = " AND ( "
-	}
-	for key, child := range jsObject {
-
-		q = q + e.OperatorToSql(db, child.ChildrenMap(), qparams, schema)
-		if key < len(jsObject)-1 {
-			q = q + " AND "
-		}
-
-	}
-	q = q + " ) "
-
-	return q
-}
-
-// OrToSql - or WQL to sql
-func (e *pgMultiSchemaStorage) OrToSql(db *gorm.DB, jsObject []*gabs.Container, qparams *[]interface{}, schema string, bOp bool) string {
-
-	q := " ( "
-	if bOp {
-		q = " AND ( "
-	}
-	for key, child := range jsObject {
-
-		q = q + e.OperatorToSql(db, child.ChildrenMap(), qparams, schema)
-		if key < len(jsObject)-1 {
-			q = q + " OR "
-		}
-
-	}
-	q = q + " ) "
-
-	return q
-}
-
-// NotToSql - not WQL to sql
-// TODO : testme!!!
-func (e *pgMultiSchemaStorage) NotToSql(db *gorm.DB, jsObject []*gabs.Container, qparams *[]interface{}, schema string, bOp bool) string {
-
-	q := " ( "
-	if bOp {
-		q = " AND ( "
+// WqlToSql - compiles a WQL query to a where clause on the items table of a wallet schema
+func WqlToSql(queryJson string, schema string) (wql.SqlFragment, error) {
+	query, errParse := wql.Parse(queryJson)
+	if errParse != nil {
+		return wql.SqlFragment{}, errParse
 	}
-	for key, child := range jsObject {
-
-		q = q + e.OperatorToSql(db, child.ChildrenMap(), qparams, schema)
-		if key < len(jsObject)-1 {
-			q = q + " Not "
-		}
 
+	if len(schema) > 0 && !checkSchemaName(schema) {
+		return wql.SqlFragment{}, errors.New("characters not allowed in schema name")
 	}
-	q = q + " ) "
-
-	return q
-}
 
-// SubOperatorToSql - build sql  from wql
-func (e *pgMultiSchemaStorage) SubOperatorToSql(db *gorm.DB, js *gabs.Container, tagName string, schema string, bOp bool) (string, *gorm.DB) {
-
-	s := "(id in (?))"
-	if bOp {
-		s = "AND (id in (?))"
-	}
-
-	// get tags table
-	var d *gorm.DB
-	if tagName[0:1] == "~" { // tags unencrypted
-		tp := TagsPlaintextDB{}
-		d = db.Scopes(AddSchemaTable(schema, tp.TableName())).Select("item_id")
-	} else { // tags encrypted
-		te := TagsEncryptedDB{}
-		//d = db.Table(te.TableName()).Select("item_id")
-		d = db.Scopes(AddSchemaTable(schema, te.TableName())).Select("item_id")
-
-	}
-
-	// get subquery condition
-	children := js.ChildrenMap()
-	//equal clause
-	if len(children) == 0 {
-		value := js.Data().(string)
-		return s, d.Where("name = ? AND value = ? ", tagName, value)
-	}
-
-	for key, child := range children {
-		switch key {
-		case "$neq":
-			value := child.Data().(string)
-			return s, d.Where("name = ? AND value != ? ", key, value)
-		case "$gt":
-			value := child.Data().(string)
-			return s, d.Where("name = ? AND value > ?", key, value)
-		case "$gte":
-			value := child.Data().(string)
-			return s, d.Where("name = ? AND value >= ?", key, value)
-		case "$lt":
-			value := child.Data().(string)
-			return s, d.Where("name = ? AND value < ?", key, value)
-		case "$lte":
-			value := child.Data().(string)
-			return s, d.Where("name = ? AND value <= ?", key, value)
-		case "$like":
-			value := child.Data().(string)
-			return s, d.Where("name = ? AND value LIKE ?", key, value)
-		case "$in":
-			var inValue []string
-			for _, vals := range child.Children() {
-				inValue = append(inValue, vals.Data().(string))
+	compiler := wql.SqlCompiler{
+		TagsTable: func(plaintext bool) string {
+			table := (&TagsEncryptedDB{}).TableName()
+			if plaintext {
+				table = (&TagsPlaintextDB{}).TableName()
 			}
-			return s, d.Where("name = ? AND value IN (?)", key, inValue)
-		}
+			if len(schema) == 0 {
+				return fmt.Sprintf("\"%s\"", table)
+			}
+			return fmt.Sprintf("\"%s\".\"%s\"", schema, table)
+		},
 	}
 
-	// TODO: should not be here
-	return s, d
-}
-
-// OperatorToSql - build sql  from wql
-func (e *pgMultiSchemaStorage) OperatorToSql(db *gorm.DB, jsObject map[string]*gabs.Container, qparams *[]interface{}, schema string) string {
-	s := ""
-	b := false
-	for key, child := range jsObject {
-
-		switch key {
-		case "$and":
-			s = s + e.AndToSql(db, child.Children(), qparams, schema, b)
-		case "$or":
-			s = s + e.OrToSql(db, child.Children(), qparams, schema, b)
-		case "$not":
-			s = s + e.NotToSql(db, child.Children(), qparams, schema, b)
-		default:
-			sq, d := e.SubOperatorToSql(db, child, key, schema, b)
-			s = s + sq
-			*qparams = append(*qparams, d)
-		}
-
-		b = true
-
-	}
-	return s
+	return compiler.CompileSql(query)
 }
@@ -0,0 +1,123 @@
+/*
+// ******************************************************************
+// Purpose: Paginated cursor over the items of a pg wallet search
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package dbutils
+
+import (
+	"github.com/joyride9999/IndySdkGoBindings/wallet"
+	"gorm.io/gorm"
+)
+
+// SearchPageSize number of items loaded from the database per round trip by a search
+var SearchPageSize = 100
+
+// pgSearchCursor walks the items matched by a search in pages ordered by id (keyset pagination),
+// so only one page of the result set is kept in memory at any time
+type pgSearchCursor struct {
+	db       *gorm.DB
+	walletId string
+	filter   func(tx *gorm.DB) *gorm.DB // restricts the items table to the search results
+	pageSize int
+	options  wallet.SearchOptions
+
+	page   []ItemsDB
+	tags   map[int64]string // tags json of the current page items, when options.RetrieveTags
+	pos    int
+	lastId int64
+	done   bool
+}
+
+func newPgSearchCursor(db *gorm.DB, walletId string, options wallet.SearchOptions, filter func(tx *gorm.DB) *gorm.DB) *pgSearchCursor {
+	pageSize := SearchPageSize
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	return &pgSearchCursor{
+		db:       db,
+		walletId: walletId,
+		filter:   filter,
+		pageSize: pageSize,
+		options:  options,
+		done:     !options.RetrieveRecords,
+	}
+}
+
+func (c *pgSearchCursor) items() *gorm.DB {
+	var item ItemsDB
+	return c.db.Scopes(AddSchemaTable(c.walletId, item.TableName()), c.filter)
+}
+
+// Next returns the next item of the search and its tags json (empty unless options.RetrieveTags),
+// ok is false when the search is exhausted
+func (c *pgSearchCursor) Next() (item ItemsDB, tagsJson string, ok bool, err error) {
+	if c.pos >= len(c.page) {
+		if c.done {
+			return ItemsDB{}, "", false, nil
+		}
+		errP := c.fetchPage()
+		if errP != nil {
+			return ItemsDB{}, "", false, errP
+		}
+		if len(c.page) == 0 {
+			return ItemsDB{}, "", false, nil
+		}
+	}
+
+	item = c.page[c.pos]
+	c.pos++
+	return item, c.tags[item.Id], true, nil
+}
+
+func (c *pgSearchCursor) fetchPage() error {
+	var page []ItemsDB
+	tx := c.items()
+	if !c.options.RetrieveValue {
+		// ids only searches don't pull the encrypted values
+		tx = tx.Select(itemColumnsWithoutValue)
+	}
+	errF := tx.Where("id > ?", c.lastId).Order("id").Limit(c.pageSize).Find(&page).Error
+	if errF != nil {
+		return errF
+	}
+
+	c.tags = nil
+	if c.options.RetrieveTags && len(page) > 0 {
+		ids := make([]int64, 0, len(page))
+		for _, item := range page {
+			ids = append(ids, item.Id)
+		}
+		tags, errT := loadTagsJson(c.db, c.walletId, ids)
+		if errT != nil {
+			return errT
+		}
+		c.tags = tags
+	}
+
+	c.page = page
+	c.pos = 0
+	if len(page) < c.pageSize {
+		c.done = true
+	}
+	if len(page) > 0 {
+		c.lastId = page[len(page)-1].Id
+	}
+	return nil
+}
+
+// Count runs a separate COUNT query for the whole search
+func (c *pgSearchCursor) Count() (int64, error) {
+	var nCount int64
+	errC := c.items().Count(&nCount).Error
+	if errC != nil {
+		return 0, errC
+	}
+	return nCount, nil
+}
@@ -17,15 +17,22 @@ package indySDK
 */
 import "C"
 import (
-	"github.com/joyride9999/IndySdkGoBindings/did"
+	"context"
 	"encoding/json"
 	"errors"
+	"github.com/joyride9999/IndySdkGoBindings/did"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
 	"unsafe"
 )
 
 // CreateAndStoreDID creates and DID with keys ... nothing is written to blockchain
 // returns did, verkey, error
 func CreateAndStoreDID(walletHandle int, seed string) (string, string, error) {
+	return CreateAndStoreDIDCtx(context.Background(), walletHandle, seed)
+}
+
+// CreateAndStoreDIDCtx is like CreateAndStoreDID but returns ctx.Err() if ctx is done before libindy answers.
+func CreateAndStoreDIDCtx(ctx context.Context, walletHandle int, seed string) (string, string, error) {
 
 	type didJson struct {
 		Did        string `json:"did,omitempty"`
@@ -47,7 +54,7 @@ func CreateAndStoreDID(walletHandle int, seed string) (string, string, error) {
 	defer C.free(upDid)
 
 	channel := did.CreateAndStoreMyDid(walletHandle, upDid)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", "", result.Error
 	}
@@ -56,6 +63,11 @@ func CreateAndStoreDID(walletHandle int, seed string) (string, string, error) {
 
 // ReplaceKeyStart generates temporary key for an existing DID.
 func ReplaceKeyStart(walletHandle int, Did string, identityJson string) (string, error) {
+	return ReplaceKeyStartCtx(context.Background(), walletHandle, Did, identityJson)
+}
+
+// ReplaceKeyStartCtx is like ReplaceKeyStart but returns ctx.Err() if ctx is done before libindy answers.
+func ReplaceKeyStartCtx(ctx context.Context, walletHandle int, Did string, identityJson string) (string, error) {
 
 	upDid := unsafe.Pointer(C.CString(Did))
 	defer C.free(upDid)
@@ -63,7 +75,7 @@ func ReplaceKeyStart(walletHandle int, Did string, identityJson string) (string,
 	defer C.free(upIdentityJson)
 
 	channel := did.ReplaceKeyStart(walletHandle, upDid, upIdentityJson)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -72,28 +84,43 @@ func ReplaceKeyStart(walletHandle int, Did string, identityJson string) (string,
 
 // ReplaceKeyApply applies temporary keys as main for existing DID
 func ReplaceKeyApply(walletHandle int, Did string) error {
+	return ReplaceKeyApplyCtx(context.Background(), walletHandle, Did)
+}
+
+// ReplaceKeyApplyCtx is like ReplaceKeyApply but returns ctx.Err() if ctx is done before libindy answers.
+func ReplaceKeyApplyCtx(ctx context.Context, walletHandle int, Did string) error {
 	upDid := unsafe.Pointer(C.CString(Did))
 	defer C.free(upDid)
 	channel := did.ReplaceKeyApply(walletHandle, upDid)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // StoreTheirDid saves DID for a pairwise connection in a secured wallet to verify transaction.
 func StoreTheirDid(walletHandle int, identityJson string) error {
+	return StoreTheirDidCtx(context.Background(), walletHandle, identityJson)
+}
+
+// StoreTheirDidCtx is like StoreTheirDid but returns ctx.Err() if ctx is done before libindy answers.
+func StoreTheirDidCtx(ctx context.Context, walletHandle int, identityJson string) error {
 	upIdentityJson := unsafe.Pointer(C.CString(identityJson))
 	defer C.free(upIdentityJson)
 	channel := did.StoreTheirDid(walletHandle, upIdentityJson)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // KeyForDid returns ver key for DID.
 func KeyForDid(poolHandle int, walletHandle int, Did string) (string, error) {
+	return KeyForDidCtx(context.Background(), poolHandle, walletHandle, Did)
+}
+
+// KeyForDidCtx is like KeyForDid but returns ctx.Err() if ctx is done before libindy answers.
+func KeyForDidCtx(ctx context.Context, poolHandle int, walletHandle int, Did string) (string, error) {
 	upDid := unsafe.Pointer(C.CString(Did))
 	defer C.free(upDid)
 	channel := did.KeyForDid(poolHandle, walletHandle, upDid)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -103,10 +130,15 @@ func KeyForDid(poolHandle int, walletHandle int, Did string) (string, error) {
 // KeyForLocalDID gets the key for the local DID.
 // returns key, error
 func KeyForLocalDID(walletHandle int, Did string) (string, error) {
+	return KeyForLocalDIDCtx(context.Background(), walletHandle, Did)
+}
+
+// KeyForLocalDIDCtx is like KeyForLocalDID but returns ctx.Err() if ctx is done before libindy answers.
+func KeyForLocalDIDCtx(ctx context.Context, walletHandle int, Did string) (string, error) {
 	upDid := unsafe.Pointer(C.CString(Did))
 	defer C.free(upDid)
 	channel := did.KeyForLocalDid(walletHandle, upDid)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -115,6 +147,11 @@ func KeyForLocalDID(walletHandle int, Did string) (string, error) {
 
 // SetEndPointForDid set/replaces endpoint information for the given DID
 func SetEndPointForDid(walletHandle int, Did string, address string, transportKey string) error {
+	return SetEndPointForDidCtx(context.Background(), walletHandle, Did, address, transportKey)
+}
+
+// SetEndPointForDidCtx is like SetEndPointForDid but returns ctx.Err() if ctx is done before libindy answers.
+func SetEndPointForDidCtx(ctx context.Context, walletHandle int, Did string, address string, transportKey string) error {
 	upDid := unsafe.Pointer(C.CString(Did))
 	defer C.free(upDid)
 	upAddress := unsafe.Pointer(C.CString(address))
@@ -122,16 +159,21 @@ func SetEndPointForDid(walletHandle int, Did string, address string, transportKe
 	upTransportKey := unsafe.Pointer(C.CString(transportKey))
 	defer C.free(upTransportKey)
 	channel := did.SetEndPointForDid(walletHandle, upDid, upAddress, upTransportKey)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // GetEndPointForDid returns endpoint information for the given DID
 func GetEndPointForDid(walletHandle int, poolHandle int, Did string) (string, string, error) {
+	return GetEndPointForDidCtx(context.Background(), walletHandle, poolHandle, Did)
+}
+
+// GetEndPointForDidCtx is like GetEndPointForDid but returns ctx.Err() if ctx is done before libindy answers.
+func GetEndPointForDidCtx(ctx context.Context, walletHandle int, poolHandle int, Did string) (string, string, error) {
 	upDid := unsafe.Pointer(C.CString(Did))
 	defer C.free(upDid)
 	channel := did.GetEndPointForDid(walletHandle, poolHandle, upDid)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", "", result.Error
 	}
@@ -140,21 +182,31 @@ func GetEndPointForDid(walletHandle int, poolHandle int, Did string) (string, st
 
 // SetDidMetadata saves/replaces meta information for the given DID.
 func SetDidMetadata(walletHandle int, Did string, metadata string) error {
+	return SetDidMetadataCtx(context.Background(), walletHandle, Did, metadata)
+}
+
+// SetDidMetadataCtx is like SetDidMetadata but returns ctx.Err() if ctx is done before libindy answers.
+func SetDidMetadataCtx(ctx context.Context, walletHandle int, Did string, metadata string) error {
 	upDid := unsafe.Pointer(C.CString(Did))
 	defer C.free(upDid)
 	upMetadata := unsafe.Pointer(C.CString(metadata))
 	defer C.free(upMetadata)
 	channel := did.SetDidMetadata(walletHandle, upDid, upMetadata)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // GetDidMetadata retrieves meta information for the given DID.
 func GetDidMetadata(walletHandle int, Did string) (string, error) {
+	return GetDidMetadataCtx(context.Background(), walletHandle, Did)
+}
+
+// GetDidMetadataCtx is like GetDidMetadata but returns ctx.Err() if ctx is done before libindy answers.
+func GetDidMetadataCtx(ctx context.Context, walletHandle int, Did string) (string, error) {
 	upDid := unsafe.Pointer(C.CString(Did))
 	defer C.free(upDid)
 	channel := did.GetDidMetadata(walletHandle, upDid)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -163,10 +215,15 @@ func GetDidMetadata(walletHandle int, Did string) (string, error) {
 
 // GetDidWithMetadata retrieves DID, metadata and verkey stored in the wallet.
 func GetDidWithMetadata(walletHandle int, Did string) (string, error) {
+	return GetDidWithMetadataCtx(context.Background(), walletHandle, Did)
+}
+
+// GetDidWithMetadataCtx is like GetDidWithMetadata but returns ctx.Err() if ctx is done before libindy answers.
+func GetDidWithMetadataCtx(ctx context.Context, walletHandle int, Did string) (string, error) {
 	upDid := unsafe.Pointer(C.CString(Did))
 	defer C.free(upDid)
 	channel := did.GetDidWithMetadata(walletHandle, upDid)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -175,8 +232,13 @@ func GetDidWithMetadata(walletHandle int, Did string) (string, error) {
 
 // ListDidsWithMeta lists DIDs and metadata stored in the wallet.
 func ListDidsWithMeta(walletHandle int) (string, error) {
+	return ListDidsWithMetaCtx(context.Background(), walletHandle)
+}
+
+// ListDidsWithMetaCtx is like ListDidsWithMeta but returns ctx.Err() if ctx is done before libindy answers.
+func ListDidsWithMetaCtx(ctx context.Context, walletHandle int) (string, error) {
 	channel := did.ListDidsWithMeta(walletHandle)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -185,13 +247,18 @@ func ListDidsWithMeta(walletHandle int) (string, error) {
 
 // AbbreviateVerKey retrieves abbreviated key if exists, otherwise returns full verkey.
 func AbbreviateVerKey(Did string, verKey string) (string, error) {
+	return AbbreviateVerKeyCtx(context.Background(), Did, verKey)
+}
+
+// AbbreviateVerKeyCtx is like AbbreviateVerKey but returns ctx.Err() if ctx is done before libindy answers.
+func AbbreviateVerKeyCtx(ctx context.Context, Did string, verKey string) (string, error) {
 	upDid := unsafe.Pointer(C.CString(Did))
 	defer C.free(upDid)
 	upVerKey := unsafe.Pointer(C.CString(verKey))
 	defer C.free(upVerKey)
 
 	channel := did.AbbreviateVerKey(upDid, upVerKey)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -200,6 +267,11 @@ func AbbreviateVerKey(Did string, verKey string) (string, error) {
 
 // QualifyDid updates DID related entities stored in the wallet.
 func QualifyDid(walletHandle int, Did string, method string) (string, error) {
+	return QualifyDidCtx(context.Background(), walletHandle, Did, method)
+}
+
+// QualifyDidCtx is like QualifyDid but returns ctx.Err() if ctx is done before libindy answers.
+func QualifyDidCtx(ctx context.Context, walletHandle int, Did string, method string) (string, error) {
 
 	upDid := unsafe.Pointer(C.CString(Did))
 	defer C.free(upDid)
@@ -207,7 +279,7 @@ func QualifyDid(walletHandle int, Did string, method string) (string, error) {
 	defer C.free(upMethod)
 
 	channel := did.QualifyDid(walletHandle, upDid, upMethod)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -61,7 +61,6 @@ extern void qualifyDidCB(indy_handle_t, indy_error_t, char*);
 import "C"
 import (
 	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
-	"errors"
 	"unsafe"
 )
 
@@ -75,8 +74,8 @@ func createAndStoreMyDidCB(commandHandle C.indy_handle_t, indyError C.indy_error
 					string(C.GoString(verKey)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -117,8 +116,8 @@ func CreateAndStoreMyDid(walletHandle int, did unsafe.Pointer) chan indyUtils.In
 		(C.cb_createAndStoreMyDid)(unsafe.Pointer(C.createAndStoreMyDidCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -130,8 +129,8 @@ func replaceKeyStartCB(commandHandle C.indy_handle_t, indyError C.indy_error_t,
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{string(C.GoString(verKey))}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -164,8 +163,8 @@ func ReplaceKeyStart(walletHandle int, did, identityJson unsafe.Pointer) chan in
 		(*C.char)(identityJson),
 		(C.cb_replaceKeyStart)(unsafe.Pointer(C.replaceKeyStartCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -177,8 +176,8 @@ func replaceKeyApplyCB(commandHandle C.indy_handle_t, indyError C.indy_error_t)
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -203,8 +202,8 @@ func ReplaceKeyApply(walletHandle int, did unsafe.Pointer) chan indyUtils.IndyRe
 		(*C.char)(did),
 		(C.cb_replaceKeyApply)(unsafe.Pointer(C.replaceKeyApplyCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -216,8 +215,8 @@ func storeTheirDidCB(commandHandle C.indy_handle_t, indyError C.indy_error_t) {
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -247,8 +246,8 @@ func StoreTheirDid(walletHandle int, identityJson unsafe.Pointer) chan indyUtils
 		(*C.char)(identityJson),
 		(C.cb_storeTheirDid)(unsafe.Pointer(C.storeTheirDidCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -260,8 +259,8 @@ func keyForDidCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, verKey
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{string(C.GoString(verKey))}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -297,8 +296,8 @@ func KeyForDid(poolHandle int, walletHandle int, did unsafe.Pointer) chan indyUt
 		(*C.char)(did),
 		(C.cb_keyForDid)(unsafe.Pointer(C.keyForDidCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -309,8 +308,8 @@ func keyForLocalDidCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, v
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{string(C.GoString(verKey))}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -325,8 +324,8 @@ func KeyForLocalDid(walletHandle int, did unsafe.Pointer) chan indyUtils.IndyRes
 		(C.cb_keyForLocalDid)(unsafe.Pointer(C.keyForLocalDidCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -338,8 +337,8 @@ func setEndPointForDidCB(commandHandle C.indy_handle_t, indyError C.indy_error_t
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -368,8 +367,8 @@ func SetEndPointForDid(walletHandle int, did, address, transportKey unsafe.Point
 		(*C.char)(transportKey),
 		(C.cb_setEndPointForDid)(unsafe.Pointer(C.setEndPointForDidCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -382,8 +381,8 @@ func getEndPointForDidCB(commandHandle C.indy_handle_t, indyError C.indy_error_t
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{string(C.GoString(address)),
 			string(C.GoString(transportKey))}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -409,8 +408,8 @@ func GetEndPointForDid(walletHandle int, poolHandle int, did unsafe.Pointer) cha
 		(*C.char)(did),
 		(C.cb_getEndPointForDid)(unsafe.Pointer(C.getEndPointForDidCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -422,8 +421,8 @@ func setDidMetadataCB(commandHandle C.indy_handle_t, indyError C.indy_error_t) {
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -448,8 +447,8 @@ func SetDidMetadata(walletHandle int, did, metadata unsafe.Pointer) chan indyUti
 		(*C.char)(metadata),
 		(C.cb_setDidMetadata)(unsafe.Pointer(C.setDidMetadataCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -461,8 +460,8 @@ func getDidMetadataCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, m
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{string(C.GoString(metadata))}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -485,8 +484,8 @@ func GetDidMetadata(walletHandle int, did unsafe.Pointer) chan indyUtils.IndyRes
 		(*C.char)(did),
 		(C.cb_getDidMetadata)(unsafe.Pointer(C.getDidMetadataCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -499,8 +498,8 @@ func getDidWithMetadataCB(commandHandle C.indy_handle_t, indyError C.indy_error_
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{
 			string(C.GoString(did))}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -522,8 +521,8 @@ func GetDidWithMetadata(walletHandle int, did unsafe.Pointer) chan indyUtils.Ind
 		(*C.char)(did),
 		(C.cb_getDidWithMetadata)(unsafe.Pointer(C.getDidWithMetadataCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -536,8 +535,8 @@ func listDidsWithMetaCB(commandHandle C.indy_handle_t, indyError C.indy_error_t,
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{
 			string(C.GoString(dids))}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -558,8 +557,8 @@ func ListDidsWithMeta(walletHandle int) chan indyUtils.IndyResult {
 		C.indy_handle_t(walletHandle),
 		(C.cb_listDidsWithMeta)(unsafe.Pointer(C.listDidsWithMetaCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -572,8 +571,8 @@ func abbreviateVerKeyCB(commandHandle C.indy_handle_t, indyError C.indy_error_t,
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{
 			string(C.GoString(fullVerKey))}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -596,8 +595,8 @@ func AbbreviateVerKey(did, verKey unsafe.Pointer) chan indyUtils.IndyResult {
 		(*C.char)(verKey),
 		(C.cb_abbreviateVerKey)(unsafe.Pointer(C.abbreviateVerKeyCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -610,8 +609,8 @@ func qualifyDidCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, quali
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{
 			string(C.GoString(qualifiedDid))}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -639,8 +638,8 @@ func QualifyDid(walletHandle int, did, method unsafe.Pointer) chan indyUtils.Ind
 		(*C.char)(method),
 		(C.cb_qualifyDid)(unsafe.Pointer(C.qualifyDidCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
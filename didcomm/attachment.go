@@ -0,0 +1,72 @@
+/*
+// ******************************************************************
+// Purpose: ~attach decorator (Aries RFC 0017), embeds libindy json
+// in protocol messages
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package didcomm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrMissingAttachment is returned when a message lacks the attachment of a protocol step
+var ErrMissingAttachment = errors.New("missing attachment")
+
+// AttachmentData content of an attachment, inline as base64 or json
+type AttachmentData struct {
+	Base64 string          `json:"base64,omitempty"`
+	Json   json.RawMessage `json:"json,omitempty"`
+}
+
+// Attachment embedded data of a message
+type Attachment struct {
+	Id       string         `json:"@id"`
+	MimeType string         `json:"mime-type,omitempty"`
+	Data     AttachmentData `json:"data"`
+}
+
+// NewJsonAttachment attaches a json document as base64, the way the libindy objects are exchanged
+func NewJsonAttachment(id string, content string) Attachment {
+	return Attachment{
+		Id:       id,
+		MimeType: "application/json",
+		Data:     AttachmentData{Base64: base64.StdEncoding.EncodeToString([]byte(content))},
+	}
+}
+
+// Content returns the attached data
+func (a *Attachment) Content() ([]byte, error) {
+	if len(a.Data.Json) > 0 {
+		return a.Data.Json, nil
+	}
+	if a.Data.Base64 == "" {
+		return nil, fmt.Errorf("%w: %s has no inline data", ErrMissingAttachment, a.Id)
+	}
+	content, err := base64.StdEncoding.DecodeString(a.Data.Base64)
+	if err != nil {
+		return decodeBase64Url(a.Data.Base64)
+	}
+	return content, nil
+}
+
+// firstAttachment returns the content of the first attachment of a protocol message
+func firstAttachment(attachments []Attachment, name string) (string, error) {
+	if len(attachments) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrMissingAttachment, name)
+	}
+	content, err := attachments[0].Content()
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
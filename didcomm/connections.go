@@ -0,0 +1,557 @@
+/*
+// ******************************************************************
+// Purpose: connection protocol (Aries RFC 0160), exchanges the DIDs of
+// two agents and ends with a pairwise in both wallets
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package didcomm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	indySDK "github.com/joyride9999/IndySdkGoBindings"
+	"github.com/joyride9999/IndySdkGoBindings/crypto"
+	"github.com/joyride9999/IndySdkGoBindings/did"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// message types of the connection protocol
+var (
+	InvitationType         = NewMessageType("connections", "1.0", "invitation")
+	ConnectionRequestType  = NewMessageType("connections", "1.0", "request")
+	ConnectionResponseType = NewMessageType("connections", "1.0", "response")
+	AckType                = NewMessageType("notification", "1.0", "ack")
+)
+
+// ConnectionRecordType non secret record type of the connections, the value is a ConnectionRecord and the record id its Id
+const ConnectionRecordType = "didcomm_connection"
+
+// roles and states of a connection (Aries RFC 0160)
+const (
+	ConnectionRoleInviter = "inviter"
+	ConnectionRoleInvitee = "invitee"
+
+	ConnectionStateInvited   = "invited"
+	ConnectionStateRequested = "requested"
+	ConnectionStateResponded = "responded"
+	ConnectionStateComplete  = "complete"
+)
+
+// ErrConnectionState is returned for a message the connection does not expect in its state
+var ErrConnectionState = errors.New("unexpected connection message")
+
+// Invitation out of band message offering a connection
+type Invitation struct {
+	Header
+	Label           string   `json:"label,omitempty"`
+	RecipientKeys   []string `json:"recipientKeys"`
+	RoutingKeys     []string `json:"routingKeys,omitempty"`
+	ServiceEndpoint string   `json:"serviceEndpoint"`
+}
+
+// Service returns the service the connection request is sent to
+func (i *Invitation) Service() Service {
+	return Service{RecipientKeys: i.RecipientKeys, RoutingKeys: i.RoutingKeys, ServiceEndpoint: i.ServiceEndpoint}
+}
+
+// URL encodes the invitation in the c_i parameter of baseUrl
+func (i *Invitation) URL(baseUrl string) (string, error) {
+	invitationJson, err := json.Marshal(i)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(baseUrl)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set("c_i", base64.URLEncoding.EncodeToString(invitationJson))
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// ParseInvitationURL decodes the invitation of an invitation url
+func ParseInvitationURL(invitationUrl string) (*Invitation, error) {
+	u, err := url.Parse(invitationUrl)
+	if err != nil {
+		return nil, err
+	}
+	invitationJson, err := decodeBase64Url(u.Query().Get("c_i"))
+	if err != nil {
+		return nil, err
+	}
+	var invitation Invitation
+	err = json.Unmarshal(invitationJson, &invitation)
+	if err != nil {
+		return nil, err
+	}
+	if len(invitation.RecipientKeys) == 0 || invitation.ServiceEndpoint == "" {
+		return nil, fmt.Errorf("%w: invitation without recipient keys or endpoint", ErrInvalidMessageType)
+	}
+	return &invitation, nil
+}
+
+// DIDDocKey public key of a DIDDoc
+type DIDDocKey struct {
+	Id              string `json:"id"`
+	Type            string `json:"type"`
+	Controller      string `json:"controller"`
+	PublicKeyBase58 string `json:"publicKeyBase58"`
+}
+
+// DIDDocAuthentication authentication key reference of a DIDDoc
+type DIDDocAuthentication struct {
+	Type      string `json:"type"`
+	PublicKey string `json:"publicKey"`
+}
+
+// DIDDoc DID document exchanged by the connection protocol
+type DIDDoc struct {
+	Context        string                 `json:"@context"`
+	Id             string                 `json:"id"`
+	PublicKey      []DIDDocKey            `json:"publicKey"`
+	Authentication []DIDDocAuthentication `json:"authentication"`
+	Service        []Service              `json:"service"`
+}
+
+// NewDIDDoc returns the DIDDoc of a did with one key and the agent service
+func NewDIDDoc(did string, verkey string, endpoint string, routingKeys []string) DIDDoc {
+	keyId := did + "#1"
+	return DIDDoc{
+		Context:        "https://w3id.org/did/v1",
+		Id:             did,
+		PublicKey:      []DIDDocKey{{Id: keyId, Type: "Ed25519VerificationKey2018", Controller: did, PublicKeyBase58: verkey}},
+		Authentication: []DIDDocAuthentication{{Type: "Ed25519SignatureAuthentication2018", PublicKey: keyId}},
+		Service: []Service{{
+			Id:              did + ";indy",
+			Type:            "IndyAgent",
+			RecipientKeys:   []string{verkey},
+			RoutingKeys:     routingKeys,
+			ServiceEndpoint: endpoint,
+		}},
+	}
+}
+
+// AgentService returns the first service of the DIDDoc messages can be packed for
+func (d *DIDDoc) AgentService() (Service, error) {
+	for _, service := range d.Service {
+		if len(service.RecipientKeys) > 0 && service.ServiceEndpoint != "" {
+			return service, nil
+		}
+	}
+	return Service{}, fmt.Errorf("%w: %s", ErrNoEndpoint, d.Id)
+}
+
+// Verkey returns the first public key of the DIDDoc
+func (d *DIDDoc) Verkey() (string, error) {
+	if len(d.PublicKey) == 0 || d.PublicKey[0].PublicKeyBase58 == "" {
+		return "", fmt.Errorf("DIDDoc of %s has no public key", d.Id)
+	}
+	return d.PublicKey[0].PublicKeyBase58, nil
+}
+
+// Connection did and DIDDoc sent in a connection request and signed in the response
+type Connection struct {
+	Did    string `json:"DID"`
+	DIDDoc DIDDoc `json:"DIDDoc"`
+}
+
+// ConnectionRequest answer of the invitee to an invitation
+type ConnectionRequest struct {
+	Header
+	Label      string     `json:"label,omitempty"`
+	Connection Connection `json:"connection"`
+}
+
+// ConnectionResponse answer of the inviter to a request, the connection is signed with the invitation key
+type ConnectionResponse struct {
+	Header
+	ConnectionSig SignatureDecorator `json:"connection~sig"`
+}
+
+// Ack acknowledges the last message of a thread (Aries RFC 0015)
+type Ack struct {
+	Header
+	Status string `json:"status"`
+}
+
+// ConnectionRecord state of a connection kept in the wallet
+type ConnectionRecord struct {
+	Id            string      `json:"id"`
+	Role          string      `json:"role"`
+	State         string      `json:"state"`
+	ThreadId      string      `json:"thread_id,omitempty"` // @id of the connection request
+	InvitationKey string      `json:"invitation_key"`
+	Invitation    *Invitation `json:"invitation,omitempty"` // kept by the invitee
+	MyDid         string      `json:"my_did,omitempty"`
+	TheirDid      string      `json:"their_did,omitempty"`
+	TheirLabel    string      `json:"their_label,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+func (r *ConnectionRecord) tags() map[string]string {
+	return map[string]string{
+		"role":           r.Role,
+		"state":          r.State,
+		"thread_id":      r.ThreadId,
+		"invitation_key": r.InvitationKey,
+		"my_did":         r.MyDid,
+		"their_did":      r.TheirDid,
+	}
+}
+
+// Connections runs the connection protocol of an agent. The exchange ends with the did of the other agent stored
+// (StoreTheirDid) and a pairwise holding the connection id and their service in its metadata.
+type Connections struct {
+	WalletHandle int
+	Label        string   // sent in the invitations and requests
+	Endpoint     string   // endpoint of the agent Inbound
+	RoutingKeys  []string // keys of the mediators between the Endpoint and the agent
+	Outbound     *Outbound
+
+	// OnStateChange is called after a connection record is saved, e.g. to wait for the complete state
+	OnStateChange func(record ConnectionRecord)
+
+	mutex       sync.Mutex
+	invitations map[string]*invitationLock // serializes the requests per invitation key, an invitation is answered once
+}
+
+type invitationLock struct {
+	mutex sync.Mutex
+	users int
+}
+
+// lockInvitation serializes the handling of the requests sent to an invitation key, returns the unlock func
+func (c *Connections) lockInvitation(invitationKey string) func() {
+	c.mutex.Lock()
+	if c.invitations == nil {
+		c.invitations = make(map[string]*invitationLock)
+	}
+	lock, ok := c.invitations[invitationKey]
+	if !ok {
+		lock = &invitationLock{}
+		c.invitations[invitationKey] = lock
+	}
+	lock.users++
+	c.mutex.Unlock()
+
+	lock.mutex.Lock()
+	return func() {
+		lock.mutex.Unlock()
+		c.mutex.Lock()
+		lock.users--
+		if lock.users == 0 {
+			delete(c.invitations, invitationKey)
+		}
+		c.mutex.Unlock()
+	}
+}
+
+// NewConnections creates the connection protocol of an agent reachable on endpoint
+func NewConnections(wh int, label string, endpoint string, outbound *Outbound) *Connections {
+	return &Connections{WalletHandle: wh, Label: label, Endpoint: endpoint, Outbound: outbound}
+}
+
+// Register handles the connection messages received by the inbound transport
+func (c *Connections) Register(inbound *Inbound) {
+	inbound.Handle(ConnectionRequestType, c.HandleRequest)
+	inbound.Handle(ConnectionResponseType, c.HandleResponse)
+	inbound.Handle(AckType, c.HandleAck)
+}
+
+func (c *Connections) saveCtx(ctx context.Context, record *ConnectionRecord) error {
+	record.UpdatedAt = time.Now().UTC()
+	err := saveRecordCtx(ctx, c.WalletHandle, ConnectionRecordType, record.Id, record, record.tags())
+	if err != nil {
+		return err
+	}
+	if c.OnStateChange != nil {
+		c.OnStateChange(*record)
+	}
+	return nil
+}
+
+// Connection returns a connection record
+func (c *Connections) Connection(id string) (*ConnectionRecord, error) {
+	return c.ConnectionCtx(context.Background(), id)
+}
+
+// ConnectionCtx is like Connection but returns ctx.Err() if ctx is done before libindy answers.
+func (c *Connections) ConnectionCtx(ctx context.Context, id string) (*ConnectionRecord, error) {
+	var record ConnectionRecord
+	err := getRecordCtx(ctx, c.WalletHandle, ConnectionRecordType, id, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// ConnectionByDid returns the connection with the agent of their did
+func (c *Connections) ConnectionByDid(theirDid string) (*ConnectionRecord, error) {
+	return c.ConnectionByDidCtx(context.Background(), theirDid)
+}
+
+// ConnectionByDidCtx is like ConnectionByDid but returns ctx.Err() if ctx is done before libindy answers.
+func (c *Connections) ConnectionByDidCtx(ctx context.Context, theirDid string) (*ConnectionRecord, error) {
+	var record ConnectionRecord
+	err := findRecordCtx(ctx, c.WalletHandle, ConnectionRecordType, map[string]string{"their_did": theirDid}, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// CreateInvitation creates an invitation with a new key, the connection waits for the request in the invited state
+func (c *Connections) CreateInvitation() (*Invitation, *ConnectionRecord, error) {
+	return c.CreateInvitationCtx(context.Background())
+}
+
+// CreateInvitationCtx is like CreateInvitation but returns ctx.Err() if ctx is done before libindy answers.
+func (c *Connections) CreateInvitationCtx(ctx context.Context) (*Invitation, *ConnectionRecord, error) {
+	invitationKey, err := indySDK.CreateKeyCtx(ctx, c.WalletHandle, crypto.Key{})
+	if err != nil {
+		return nil, nil, err
+	}
+	invitation := &Invitation{
+		Header:          NewHeader(InvitationType),
+		Label:           c.Label,
+		RecipientKeys:   []string{invitationKey},
+		RoutingKeys:     c.RoutingKeys,
+		ServiceEndpoint: c.Endpoint,
+	}
+
+	now := time.Now().UTC()
+	record := &ConnectionRecord{
+		Id:            invitation.Id,
+		Role:          ConnectionRoleInviter,
+		State:         ConnectionStateInvited,
+		InvitationKey: invitationKey,
+		CreatedAt:     now,
+	}
+	err = c.saveCtx(ctx, record)
+	if err != nil {
+		return nil, nil, err
+	}
+	return invitation, record, nil
+}
+
+// ReceiveInvitation answers an invitation with a connection request from a new did
+func (c *Connections) ReceiveInvitation(invitation *Invitation) (*ConnectionRecord, error) {
+	return c.ReceiveInvitationCtx(context.Background(), invitation)
+}
+
+// ReceiveInvitationCtx is like ReceiveInvitation but returns ctx.Err() if ctx is done before libindy or the inviter answer.
+func (c *Connections) ReceiveInvitationCtx(ctx context.Context, invitation *Invitation) (*ConnectionRecord, error) {
+	if len(invitation.RecipientKeys) == 0 {
+		return nil, fmt.Errorf("%w: invitation without recipient keys", ErrInvalidMessageType)
+	}
+	myDid, myKey, err := indySDK.CreateAndStoreDIDCtx(ctx, c.WalletHandle, "")
+	if err != nil {
+		return nil, err
+	}
+	request := ConnectionRequest{
+		Header:     NewHeader(ConnectionRequestType),
+		Label:      c.Label,
+		Connection: Connection{Did: myDid, DIDDoc: NewDIDDoc(myDid, myKey, c.Endpoint, c.RoutingKeys)},
+	}
+	request.SetParentThread(invitation.Id)
+
+	record := &ConnectionRecord{
+		Id:            request.Id,
+		Role:          ConnectionRoleInvitee,
+		State:         ConnectionStateRequested,
+		ThreadId:      request.Id,
+		InvitationKey: invitation.RecipientKeys[0],
+		Invitation:    invitation,
+		MyDid:         myDid,
+		TheirLabel:    invitation.Label,
+		CreatedAt:     time.Now().UTC(),
+	}
+	// saved first, the response can arrive before the request post returns
+	err = c.saveCtx(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	err = c.Outbound.SendToServiceCtx(ctx, invitation.Service(), request, myKey)
+	if err != nil {
+		return record, err
+	}
+	return c.ConnectionCtx(ctx, record.Id)
+}
+
+// HandleRequest answers the connection request sent to one of the invitations, it is a HandlerFunc
+func (c *Connections) HandleRequest(ctx context.Context, message *Unpacked) error {
+	var request ConnectionRequest
+	err := message.Decode(&request)
+	if err != nil {
+		return err
+	}
+	// the record is looked up and moved out of the invited state by one request at a time
+	unlock := c.lockInvitation(message.RecipientKey)
+	defer unlock()
+	var record ConnectionRecord
+	err = findRecordCtx(ctx, c.WalletHandle, ConnectionRecordType, map[string]string{
+		"invitation_key": message.RecipientKey,
+		"role":           ConnectionRoleInviter,
+		"state":          ConnectionStateInvited,
+	}, &record)
+	if errors.Is(err, ErrRecordNotFound) {
+		return fmt.Errorf("%w: request for unknown invitation key %s", ErrConnectionState, message.RecipientKey)
+	}
+	if err != nil {
+		return err
+	}
+
+	theirService, err := request.Connection.DIDDoc.AgentService()
+	if err != nil {
+		return err
+	}
+	theirKey, err := request.Connection.DIDDoc.Verkey()
+	if err != nil {
+		return err
+	}
+	// the request is authcrypted with the key of the did it offers
+	if message.SenderKey != theirKey {
+		return fmt.Errorf("%w: request not sent by the key of %s", ErrConnectionState, request.Connection.Did)
+	}
+	myDid, myKey, err := indySDK.CreateAndStoreDIDCtx(ctx, c.WalletHandle, "")
+	if err != nil {
+		return err
+	}
+	err = c.storePairwiseCtx(ctx, record.Id, myDid, request.Connection.Did, theirKey, theirService)
+	if err != nil {
+		return err
+	}
+
+	connection := Connection{Did: myDid, DIDDoc: NewDIDDoc(myDid, myKey, c.Endpoint, c.RoutingKeys)}
+	connectionSig, err := SignFieldCtx(ctx, c.WalletHandle, record.InvitationKey, connection)
+	if err != nil {
+		return err
+	}
+	response := ConnectionResponse{Header: NewHeader(ConnectionResponseType), ConnectionSig: *connectionSig}
+	response.ReplyTo(&request)
+
+	record.State = ConnectionStateResponded
+	record.ThreadId = request.ThreadId()
+	record.MyDid, record.TheirDid, record.TheirLabel = myDid, request.Connection.Did, request.Label
+	// saved first, the ack can arrive before the response post returns
+	err = c.saveCtx(ctx, &record)
+	if err != nil {
+		return err
+	}
+	return c.Outbound.SendCtx(ctx, record.TheirDid, response)
+}
+
+// HandleResponse checks the inviter signature of a connection response and acknowledges it, it is a HandlerFunc
+func (c *Connections) HandleResponse(ctx context.Context, message *Unpacked) error {
+	var response ConnectionResponse
+	err := message.Decode(&response)
+	if err != nil {
+		return err
+	}
+	var record ConnectionRecord
+	err = findRecordCtx(ctx, c.WalletHandle, ConnectionRecordType, map[string]string{
+		"thread_id": response.ThreadId(),
+		"role":      ConnectionRoleInvitee,
+		"state":     ConnectionStateRequested,
+	}, &record)
+	if errors.Is(err, ErrRecordNotFound) {
+		return fmt.Errorf("%w: response in unknown thread %s", ErrConnectionState, response.ThreadId())
+	}
+	if err != nil {
+		return err
+	}
+
+	// only the holder of the invitation key can answer the request
+	if response.ConnectionSig.Signer != record.InvitationKey {
+		return fmt.Errorf("%w: connection signed by %s instead of the invitation key", ErrInvalidSignature, response.ConnectionSig.Signer)
+	}
+	var connection Connection
+	err = response.ConnectionSig.VerifyCtx(ctx, &connection)
+	if err != nil {
+		return err
+	}
+	theirService, err := connection.DIDDoc.AgentService()
+	if err != nil {
+		return err
+	}
+	theirKey, err := connection.DIDDoc.Verkey()
+	if err != nil {
+		return err
+	}
+	if message.SenderKey != theirKey {
+		return fmt.Errorf("%w: response not sent by the key of %s", ErrConnectionState, connection.Did)
+	}
+	err = c.storePairwiseCtx(ctx, record.Id, record.MyDid, connection.Did, theirKey, theirService)
+	if err != nil {
+		return err
+	}
+
+	record.State, record.TheirDid = ConnectionStateResponded, connection.Did
+	err = c.saveCtx(ctx, &record)
+	if err != nil {
+		return err
+	}
+
+	ack := Ack{Header: NewHeader(AckType), Status: "OK"}
+	ack.ReplyTo(&response)
+	err = c.Outbound.SendCtx(ctx, record.TheirDid, ack)
+	if err != nil {
+		return err
+	}
+	record.State = ConnectionStateComplete
+	return c.saveCtx(ctx, &record)
+}
+
+// HandleAck completes the connection acknowledged by the invitee, it is a HandlerFunc
+func (c *Connections) HandleAck(ctx context.Context, message *Unpacked) error {
+	var ack Ack
+	err := message.Decode(&ack)
+	if err != nil {
+		return err
+	}
+	var record ConnectionRecord
+	err = findRecordCtx(ctx, c.WalletHandle, ConnectionRecordType, map[string]string{
+		"thread_id": ack.ThreadId(),
+		"role":      ConnectionRoleInviter,
+		"state":     ConnectionStateResponded,
+	}, &record)
+	if errors.Is(err, ErrRecordNotFound) {
+		return fmt.Errorf("%w: ack in unknown thread %s", ErrConnectionState, ack.ThreadId())
+	}
+	if err != nil {
+		return err
+	}
+
+	record.State = ConnectionStateComplete
+	return c.saveCtx(ctx, &record)
+}
+
+// storePairwiseCtx stores their did and the pairwise of the connection with their service in the metadata
+func (c *Connections) storePairwiseCtx(ctx context.Context, connectionId string, myDid string, theirDid string, theirKey string, theirService Service) error {
+	identity, err := json.Marshal(did.IdentityDID{Did: theirDid, VerKey: theirKey})
+	if err != nil {
+		return err
+	}
+	err = indySDK.StoreTheirDidCtx(ctx, c.WalletHandle, string(identity))
+	if err != nil {
+		return err
+	}
+	metadata, err := json.Marshal(map[string]interface{}{"connection_id": connectionId, "their_service": theirService})
+	if err != nil {
+		return err
+	}
+	return indySDK.CreatePairwiseCtx(ctx, c.WalletHandle, theirDid, myDid, string(metadata))
+}
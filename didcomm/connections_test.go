@@ -0,0 +1,244 @@
+/*
+// ******************************************************************
+// Purpose: connection protocol unit testing
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package didcomm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	indySDK "github.com/joyride9999/IndySdkGoBindings"
+	"net/http/httptest"
+	"testing"
+)
+
+// agent wallet with its transports listening on an httptest server
+type testAgent struct {
+	wh       int
+	inbound  *Inbound
+	outbound *Outbound
+	server   *httptest.Server
+}
+
+func newTestAgent(t *testing.T, id string) (*testAgent, func()) {
+	wh, closeWallet := openWallet(t, id)
+	agent := &testAgent{wh: wh, inbound: NewInbound(wh), outbound: NewOutbound(wh, 0)}
+	agent.server = httptest.NewServer(agent.inbound)
+	return agent, func() {
+		agent.server.Close()
+		closeWallet()
+	}
+}
+
+// connect runs the connection protocol between two agents, returns the records of the inviter and the invitee
+func connect(t *testing.T, inviter *testAgent, invitee *testAgent) (*ConnectionRecord, *ConnectionRecord) {
+	inviterConnections := NewConnections(inviter.wh, "inviter", inviter.server.URL, inviter.outbound)
+	inviterConnections.Register(inviter.inbound)
+	inviteeConnections := NewConnections(invitee.wh, "invitee", invitee.server.URL, invitee.outbound)
+	inviteeConnections.Register(invitee.inbound)
+
+	invitation, _, errInvitation := inviterConnections.CreateInvitation()
+	if errInvitation != nil {
+		t.Fatalf("CreateInvitation() error = '%v'", errInvitation)
+	}
+	invitationUrl, errUrl := invitation.URL("https://example.com/invite")
+	if errUrl != nil {
+		t.Fatalf("URL() error = '%v'", errUrl)
+	}
+	received, errParse := ParseInvitationURL(invitationUrl)
+	if errParse != nil || received.Id != invitation.Id {
+		t.Fatalf("ParseInvitationURL() invitation = '%v', error = '%v'", received, errParse)
+	}
+
+	// the handlers answer synchronously, the whole exchange is done once the request is posted
+	inviteeRecord, errReceive := inviteeConnections.ReceiveInvitation(received)
+	if errReceive != nil {
+		t.Fatalf("ReceiveInvitation() error = '%v'", errReceive)
+	}
+	inviterRecord, errRecord := inviterConnections.Connection(invitation.Id)
+	if errRecord != nil {
+		t.Fatalf("Connection() error = '%v'", errRecord)
+	}
+	return inviterRecord, inviteeRecord
+}
+
+func TestConnections(t *testing.T) {
+	alice, closeAlice := newTestAgent(t, "connections_alice")
+	defer closeAlice()
+	bob, closeBob := newTestAgent(t, "connections_bob")
+	defer closeBob()
+
+	aliceRecord, bobRecord := connect(t, alice, bob)
+	if aliceRecord.State != ConnectionStateComplete || bobRecord.State != ConnectionStateComplete {
+		t.Errorf("connection states = '%v', '%v'", aliceRecord.State, bobRecord.State)
+		return
+	}
+	if aliceRecord.TheirDid != bobRecord.MyDid || bobRecord.TheirDid != aliceRecord.MyDid || aliceRecord.TheirLabel != "invitee" {
+		t.Errorf("connection dids = '%v', '%v'", aliceRecord, bobRecord)
+		return
+	}
+
+	// both wallets hold the pairwise and the did of the other agent
+	pairwiseJson, errPairwise := indySDK.GetPairwise(alice.wh, aliceRecord.TheirDid)
+	var pairwise pairwiseInfo
+	json.Unmarshal([]byte(pairwiseJson), &pairwise)
+	if errPairwise != nil || pairwise.MyDid != aliceRecord.MyDid {
+		t.Errorf("GetPairwise() pairwise = '%v', error = '%v'", pairwiseJson, errPairwise)
+		return
+	}
+	bobKey, errKey := indySDK.KeyForLocalDID(alice.wh, aliceRecord.TheirDid)
+	myBobKey, _ := indySDK.KeyForLocalDID(bob.wh, bobRecord.MyDid)
+	if errKey != nil || bobKey != myBobKey {
+		t.Errorf("KeyForLocalDID() key = '%v', error = '%v'", bobKey, errKey)
+		return
+	}
+	exists, _ := indySDK.IsPairwiseExists(bob.wh, bobRecord.TheirDid)
+	if !exists {
+		t.Errorf("IsPairwiseExists() invitee pairwise missing")
+		return
+	}
+
+	// the connection is usable by the outbound transport
+	_, senderKey, errResolve := alice.outbound.Resolve(aliceRecord.TheirDid)
+	aliceKey, _ := indySDK.KeyForLocalDID(alice.wh, aliceRecord.MyDid)
+	if errResolve != nil || senderKey != aliceKey {
+		t.Errorf("Resolve() sender key = '%v', error = '%v'", senderKey, errResolve)
+		return
+	}
+}
+
+func TestSignField(t *testing.T) {
+	wh, closeWallet := openWallet(t, "didcomm_sig")
+	defer closeWallet()
+	_, key, _ := indySDK.CreateAndStoreDID(wh, "")
+	_, otherKey, _ := indySDK.CreateAndStoreDID(wh, "")
+
+	connection := Connection{Did: "did", DIDDoc: NewDIDDoc("did", key, "http://agent", nil)}
+	sig, errSign := SignField(wh, key, connection)
+	if errSign != nil {
+		t.Errorf("SignField() error = '%v'", errSign)
+		return
+	}
+	var verified Connection
+	errVerify := sig.Verify(&verified)
+	if errVerify != nil || verified.Did != "did" {
+		t.Errorf("Verify() connection = '%v', error = '%v'", verified, errVerify)
+		return
+	}
+
+	forged := *sig
+	forged.Signer = otherKey
+	errVerify = forged.Verify(&verified)
+	if !errors.Is(errVerify, ErrInvalidSignature) {
+		t.Errorf("Verify() forged signer error = '%v'", errVerify)
+	}
+}
+
+func TestConnectionsSenderKey(t *testing.T) {
+	alice, closeAlice := newTestAgent(t, "connections_sender_alice")
+	defer closeAlice()
+	bob, closeBob := newTestAgent(t, "connections_sender_bob")
+	defer closeBob()
+	// alice does not register her handlers, the messages are passed to them by the test
+	aliceConnections := NewConnections(alice.wh, "inviter", alice.server.URL, alice.outbound)
+	bobConnections := NewConnections(bob.wh, "invitee", bob.server.URL, bob.outbound)
+
+	invitation, _, errInvitation := aliceConnections.CreateInvitation()
+	if errInvitation != nil {
+		t.Errorf("CreateInvitation() error = '%v'", errInvitation)
+		return
+	}
+	invitationKey := invitation.RecipientKeys[0]
+
+	// a request offering the did of bob, authcrypted by another key
+	bobDid, bobKey, _ := indySDK.CreateAndStoreDID(bob.wh, "")
+	_, otherKey, _ := indySDK.CreateAndStoreDID(bob.wh, "")
+	request := ConnectionRequest{
+		Header:     NewHeader(ConnectionRequestType),
+		Connection: Connection{Did: bobDid, DIDDoc: NewDIDDoc(bobDid, bobKey, bob.server.URL, nil)},
+	}
+	requestJson, _ := json.Marshal(request)
+	errRequest := aliceConnections.HandleRequest(context.Background(), &Unpacked{Message: requestJson, SenderKey: otherKey, RecipientKey: invitationKey})
+	if !errors.Is(errRequest, ErrConnectionState) {
+		t.Errorf("HandleRequest() other sender error = '%v'", errRequest)
+		return
+	}
+
+	// the request post fails, bob keeps waiting for the response
+	bobRecord, _ := bobConnections.ReceiveInvitation(invitation)
+	if bobRecord == nil || bobRecord.State != ConnectionStateRequested {
+		t.Errorf("ReceiveInvitation() record = '%v'", bobRecord)
+		return
+	}
+	aliceDid, aliceKey, _ := indySDK.CreateAndStoreDID(alice.wh, "")
+	connectionSig, errSign := SignField(alice.wh, invitationKey, Connection{Did: aliceDid, DIDDoc: NewDIDDoc(aliceDid, aliceKey, alice.server.URL, nil)})
+	if errSign != nil {
+		t.Errorf("SignField() error = '%v'", errSign)
+		return
+	}
+	response := ConnectionResponse{Header: NewHeader(ConnectionResponseType), ConnectionSig: *connectionSig}
+	response.SetThread(bobRecord.ThreadId)
+	responseJson, _ := json.Marshal(response)
+	errResponse := bobConnections.HandleResponse(context.Background(), &Unpacked{Message: responseJson, SenderKey: invitationKey, RecipientKey: bobKey})
+	if !errors.Is(errResponse, ErrConnectionState) {
+		t.Errorf("HandleResponse() other sender error = '%v'", errResponse)
+		return
+	}
+	unchanged, _ := bobConnections.Connection(bobRecord.Id)
+	if unchanged.State != ConnectionStateRequested || unchanged.TheirDid != "" {
+		t.Errorf("HandleResponse() record = '%v'", unchanged)
+	}
+}
+
+func TestConnectionsConcurrentRequests(t *testing.T) {
+	alice, closeAlice := newTestAgent(t, "connections_concurrent_alice")
+	defer closeAlice()
+	bob, closeBob := newTestAgent(t, "connections_concurrent_bob")
+	defer closeBob()
+	aliceConnections := NewConnections(alice.wh, "inviter", alice.server.URL, alice.outbound)
+
+	invitation, _, errInvitation := aliceConnections.CreateInvitation()
+	if errInvitation != nil {
+		t.Errorf("CreateInvitation() error = '%v'", errInvitation)
+		return
+	}
+
+	// two requests for the single use invitation arrive at the same time, bob does not answer the response
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		bobDid, bobKey, _ := indySDK.CreateAndStoreDID(bob.wh, "")
+		request := ConnectionRequest{
+			Header:     NewHeader(ConnectionRequestType),
+			Connection: Connection{Did: bobDid, DIDDoc: NewDIDDoc(bobDid, bobKey, bob.server.URL, nil)},
+		}
+		requestJson, _ := json.Marshal(request)
+		go func() {
+			errs <- aliceConnections.HandleRequest(context.Background(), &Unpacked{Message: requestJson, SenderKey: bobKey, RecipientKey: invitation.RecipientKeys[0]})
+		}()
+	}
+	refused := 0
+	for i := 0; i < 2; i++ {
+		if errors.Is(<-errs, ErrConnectionState) {
+			refused++
+		}
+	}
+	if refused != 1 {
+		t.Errorf("HandleRequest() refused requests = %d, want = 1", refused)
+		return
+	}
+
+	pairwiseJson, _ := indySDK.ListPairwise(alice.wh)
+	var pairwise []string
+	json.Unmarshal([]byte(pairwiseJson), &pairwise)
+	if len(pairwise) != 1 {
+		t.Errorf("ListPairwise() pairwise = '%v', want one", pairwiseJson)
+	}
+}
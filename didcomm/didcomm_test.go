@@ -0,0 +1,138 @@
+/*
+// ******************************************************************
+// Purpose: didcomm unit testing
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package didcomm
+
+import (
+	"errors"
+	indySDK "github.com/joyride9999/IndySdkGoBindings"
+	"github.com/joyride9999/IndySdkGoBindings/wallet"
+	"testing"
+)
+
+// openWallet creates a wallet in a temporary directory, the returned func closes and deletes it
+func openWallet(t *testing.T, id string) (int, func()) {
+	config := wallet.Config{ID: id, StorageType: "default", StorageConfig: wallet.StorageConfig{Path: t.TempDir()}}
+	credentials := wallet.Credential{Key: "8dvfYSt5d1taSd6yJdpjq4emkwsPDDLYxkNFysFD2cZY", KeyDerivationMethod: "RAW"}
+	errCreate := indySDK.CreateWallet(config, credentials)
+	if errCreate != nil {
+		t.Fatalf("CreateWallet() error = '%v'", errCreate)
+	}
+	wh, errOpen := indySDK.OpenWallet(config, credentials)
+	if errOpen != nil {
+		t.Fatalf("OpenWallet() error = '%v'", errOpen)
+	}
+	return wh, func() {
+		indySDK.CloseWallet(wh)
+		indySDK.DeleteWallet(config, credentials)
+	}
+}
+
+func TestParseMessageType(t *testing.T) {
+	tests := []struct {
+		name        string
+		messageType string
+		want        MessageType
+		wantErr     bool
+	}{
+		{"parse-didcomm-org", "https://didcomm.org/connections/1.0/request", MessageType{DocUri, "connections", "1.0", "request"}, false},
+		{"parse-legacy", "did:sov:BzCbsNYhMrjHiqZDTUASHg;spec/routing/1.0/forward", MessageType{LegacyDocUri, "routing", "1.0", "forward"}, false},
+		{"parse-no-version", "https://didcomm.org/connections/request", MessageType{}, true},
+		{"parse-empty-name", "https://didcomm.org/connections/1.0/", MessageType{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMessageType(tt.messageType)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrInvalidMessageType)) {
+				t.Errorf("ParseMessageType() error = '%v', wantErr = '%v'", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseMessageType() = '%v', want '%v'", got, tt.want)
+				return
+			}
+			if !tt.wantErr && got.String() != tt.messageType {
+				t.Errorf("String() = '%v', want '%v'", got.String(), tt.messageType)
+			}
+		})
+	}
+
+	if !ForwardType.Is(MessageType{LegacyDocUri, "routing", "1.0", "forward"}) {
+		t.Errorf("Is() legacy forward type does not match")
+	}
+}
+
+type ping struct {
+	Header
+	Comment string `json:"comment"`
+}
+
+func TestThread(t *testing.T) {
+	request := ping{Header: NewHeader(NewMessageType("trust_ping", "1.0", "ping"))}
+	if request.ThreadId() != request.Id || request.ParentThreadId() != "" {
+		t.Errorf("ThreadId() = '%v', want '%v'", request.ThreadId(), request.Id)
+		return
+	}
+
+	response := ping{Header: NewHeader(NewMessageType("trust_ping", "1.0", "ping_response"))}
+	response.ReplyTo(&request)
+	response.SetParentThread("invitation-id")
+	if response.ThreadId() != request.Id || response.ParentThreadId() != "invitation-id" {
+		t.Errorf("ReplyTo() thread = '%v'", response.Thread)
+	}
+}
+
+func TestPackUnpack(t *testing.T) {
+	wh, closeWallet := openWallet(t, "didcomm_pack")
+	defer closeWallet()
+
+	_, aliceKey, _ := indySDK.CreateAndStoreDID(wh, "")
+	_, bobKey, _ := indySDK.CreateAndStoreDID(wh, "")
+	_, mediatorKey, _ := indySDK.CreateAndStoreDID(wh, "")
+
+	message := ping{Header: NewHeader(NewMessageType("trust_ping", "1.0", "ping")), Comment: "hi"}
+	service := Service{RecipientKeys: []string{bobKey}, RoutingKeys: []string{mediatorKey}, ServiceEndpoint: "http://mediator"}
+	packed, errPack := PackForService(wh, message, service, aliceKey)
+	if errPack != nil {
+		t.Errorf("PackForService() error = '%v'", errPack)
+		return
+	}
+
+	// the mediator gets an anoncrypted forward to bob
+	var forward Forward
+	unpacked, errUnpack := UnpackInto(wh, packed, &forward)
+	if errUnpack != nil || unpacked.SenderKey != "" || unpacked.RecipientKey != mediatorKey || forward.To != bobKey {
+		t.Errorf("UnpackInto() forward = '%v', unpacked = '%v', error = '%v'", forward, unpacked, errUnpack)
+		return
+	}
+	header, _ := unpacked.Header()
+	if header == nil || header.Type != ForwardType.String() {
+		t.Errorf("Header() = '%v'", header)
+		return
+	}
+
+	var received ping
+	unpacked, errUnpack = UnpackInto(wh, forward.Msg, &received)
+	if errUnpack != nil || unpacked.SenderKey != aliceKey || unpacked.RecipientKey != bobKey {
+		t.Errorf("UnpackInto() unpacked = '%v', error = '%v'", unpacked, errUnpack)
+		return
+	}
+	if received.Id != message.Id || received.Comment != "hi" {
+		t.Errorf("UnpackInto() message = '%v'", received)
+		return
+	}
+
+	_, errPack = Pack(wh, message, nil, aliceKey)
+	if !errors.Is(errPack, ErrNoRecipientKeys) {
+		t.Errorf("Pack() without recipients error = '%v'", errPack)
+	}
+}
@@ -0,0 +1,155 @@
+/*
+// ******************************************************************
+// Purpose: DIDComm v1 envelopes, packs and unpacks messages with the
+// wallet keys and wraps them in forward messages for the mediators
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package didcomm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	indySDK "github.com/joyride9999/IndySdkGoBindings"
+)
+
+// ForwardType @type of the routing forward message (Aries RFC 0094)
+var ForwardType = NewMessageType("routing", "1.0", "forward")
+
+// ErrNoRecipientKeys is returned when packing a message for nobody
+var ErrNoRecipientKeys = errors.New("no recipient keys")
+
+// Forward asks a mediator to pass the packed message to the key or did in To
+type Forward struct {
+	Header
+	To  string          `json:"to"`
+	Msg json.RawMessage `json:"msg"`
+}
+
+// Service DIDDoc service block of an agent (Aries RFC 0067), the messages are packed for RecipientKeys
+// and forwarded through RoutingKeys, the last routing key belongs to the mediator listening on ServiceEndpoint
+type Service struct {
+	Id              string   `json:"id,omitempty"`
+	Type            string   `json:"type,omitempty"`
+	Priority        int      `json:"priority,omitempty"`
+	RecipientKeys   []string `json:"recipientKeys"`
+	RoutingKeys     []string `json:"routingKeys,omitempty"`
+	ServiceEndpoint string   `json:"serviceEndpoint"`
+}
+
+// Unpacked message read from an envelope with the keys libindy reported
+type Unpacked struct {
+	Message      json.RawMessage `json:"message"`
+	SenderKey    string          `json:"sender_verkey,omitempty"` // empty for anoncrypted messages
+	RecipientKey string          `json:"recipient_verkey"`
+}
+
+// Header reads the header of the unpacked message
+func (u *Unpacked) Header() (*Header, error) {
+	return ParseHeader(u.Message)
+}
+
+// Decode decodes the unpacked message into v
+func (u *Unpacked) Decode(v interface{}) error {
+	return json.Unmarshal(u.Message, v)
+}
+
+// Pack encodes the message and packs it for the recipient keys, authcrypted with senderKey or anoncrypted if it is empty
+func Pack(wh int, message interface{}, recipientKeys []string, senderKey string) ([]byte, error) {
+	return PackCtx(context.Background(), wh, message, recipientKeys, senderKey)
+}
+
+// PackCtx is like Pack but returns ctx.Err() if ctx is done before libindy answers.
+func PackCtx(ctx context.Context, wh int, message interface{}, recipientKeys []string, senderKey string) ([]byte, error) {
+	if len(recipientKeys) == 0 {
+		return nil, ErrNoRecipientKeys
+	}
+	messageJson, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	receiverKeys, err := json.Marshal(recipientKeys)
+	if err != nil {
+		return nil, err
+	}
+	return indySDK.PackMsgCtx(ctx, wh, messageJson, uint32(len(messageJson)), string(receiverKeys), senderKey)
+}
+
+// WrapForward wraps a packed message addressed to `to` in an anoncrypted forward for each routing key in turn,
+// the result is packed for the last routing key
+func WrapForward(wh int, packed []byte, to string, routingKeys []string) ([]byte, error) {
+	return WrapForwardCtx(context.Background(), wh, packed, to, routingKeys)
+}
+
+// WrapForwardCtx is like WrapForward but returns ctx.Err() if ctx is done before libindy answers.
+func WrapForwardCtx(ctx context.Context, wh int, packed []byte, to string, routingKeys []string) ([]byte, error) {
+	for _, routingKey := range routingKeys {
+		forward := Forward{Header: NewHeader(ForwardType), To: to, Msg: packed}
+		var err error
+		packed, err = PackCtx(ctx, wh, forward, []string{routingKey}, "")
+		if err != nil {
+			return nil, err
+		}
+		to = routingKey
+	}
+	return packed, nil
+}
+
+// PackForService packs the message for the recipients of the service and wraps it for its routing keys,
+// the result is ready to be posted to the service endpoint
+func PackForService(wh int, message interface{}, service Service, senderKey string) ([]byte, error) {
+	return PackForServiceCtx(context.Background(), wh, message, service, senderKey)
+}
+
+// PackForServiceCtx is like PackForService but returns ctx.Err() if ctx is done before libindy answers.
+func PackForServiceCtx(ctx context.Context, wh int, message interface{}, service Service, senderKey string) ([]byte, error) {
+	packed, err := PackCtx(ctx, wh, message, service.RecipientKeys, senderKey)
+	if err != nil || len(service.RoutingKeys) == 0 {
+		return packed, err
+	}
+	return WrapForwardCtx(ctx, wh, packed, service.RecipientKeys[0], service.RoutingKeys)
+}
+
+// Unpack decrypts an envelope packed for a key of the wallet
+func Unpack(wh int, packed []byte) (*Unpacked, error) {
+	return UnpackCtx(context.Background(), wh, packed)
+}
+
+// UnpackCtx is like Unpack but returns ctx.Err() if ctx is done before libindy answers.
+func UnpackCtx(ctx context.Context, wh int, packed []byte) (*Unpacked, error) {
+	unpackedJson, err := indySDK.UnpackMsgCtx(ctx, wh, packed, uint32(len(packed)))
+	if err != nil {
+		return nil, err
+	}
+	// libindy returns the message as a json string
+	var unpacked struct {
+		Message      string `json:"message"`
+		SenderKey    string `json:"sender_verkey"`
+		RecipientKey string `json:"recipient_verkey"`
+	}
+	err = json.Unmarshal(unpackedJson, &unpacked)
+	if err != nil {
+		return nil, err
+	}
+	return &Unpacked{Message: json.RawMessage(unpacked.Message), SenderKey: unpacked.SenderKey, RecipientKey: unpacked.RecipientKey}, nil
+}
+
+// UnpackInto decrypts an envelope and decodes its message into v, returns the envelope keys
+func UnpackInto(wh int, packed []byte, v interface{}) (*Unpacked, error) {
+	return UnpackIntoCtx(context.Background(), wh, packed, v)
+}
+
+// UnpackIntoCtx is like UnpackInto but returns ctx.Err() if ctx is done before libindy answers.
+func UnpackIntoCtx(ctx context.Context, wh int, packed []byte, v interface{}) (*Unpacked, error) {
+	unpacked, err := UnpackCtx(ctx, wh, packed)
+	if err != nil {
+		return nil, err
+	}
+	return unpacked, unpacked.Decode(v)
+}
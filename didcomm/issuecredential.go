@@ -0,0 +1,724 @@
+/*
+// ******************************************************************
+// Purpose: issue credential protocol (Aries RFC 0036), drives the
+// anoncreds issuance between an issuer and a holder agent
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package didcomm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	indySDK "github.com/joyride9999/IndySdkGoBindings"
+	"github.com/joyride9999/IndySdkGoBindings/anoncreds"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// message types of the issue credential protocol
+var (
+	CredentialOfferType         = NewMessageType("issue-credential", "1.0", "offer-credential")
+	CredentialRequestType       = NewMessageType("issue-credential", "1.0", "request-credential")
+	CredentialIssueType         = NewMessageType("issue-credential", "1.0", "issue-credential")
+	CredentialAckType           = NewMessageType("issue-credential", "1.0", "ack")
+	CredentialProblemReportType = NewMessageType("issue-credential", "1.0", "problem-report")
+	CredentialPreviewType       = NewMessageType("issue-credential", "1.0", "credential-preview")
+)
+
+// CredentialExchangeRecordType non secret record type of the credential exchanges, the value is a
+// CredentialExchangeRecord and the record id its Id
+const CredentialExchangeRecordType = "didcomm_credential_exchange"
+
+// roles and states of a credential exchange (Aries RFC 0036)
+const (
+	CredentialRoleIssuer = "issuer"
+	CredentialRoleHolder = "holder"
+
+	CredentialStateOfferSent          = "offer-sent"
+	CredentialStateOfferReceived      = "offer-received"
+	CredentialStateRequestSent        = "request-sent"
+	CredentialStateRequestReceived    = "request-received"
+	CredentialStateCredentialIssued   = "credential-issued"
+	CredentialStateCredentialReceived = "credential-received"
+	CredentialStateDone               = "done"
+	CredentialStateAbandoned          = "abandoned"
+)
+
+// problem codes sent in the problem reports of the protocol
+const (
+	ProblemIssuanceAbandoned = "issuance-abandoned"
+	ProblemRequestRejected   = "request-not-accepted"
+	ProblemCredentialInvalid = "credential-not-stored"
+)
+
+// ErrCredentialExchangeState is returned for a message the credential exchange does not expect in its state
+var ErrCredentialExchangeState = errors.New("unexpected credential exchange message")
+
+// ErrCredentialExchangeAbandoned is returned when the other agent reported a problem or abandoned the exchange
+var ErrCredentialExchangeAbandoned = errors.New("credential exchange abandoned")
+
+// PreviewAttribute attribute of a credential preview
+type PreviewAttribute struct {
+	Name     string `json:"name"`
+	MimeType string `json:"mime-type,omitempty"`
+	Value    string `json:"value"`
+}
+
+// CredentialPreview raw values of the offered credential
+type CredentialPreview struct {
+	Type       string             `json:"@type"`
+	Attributes []PreviewAttribute `json:"attributes"`
+}
+
+// NewCredentialPreview returns the preview of the raw values, attributes sorted by name
+func NewCredentialPreview(values map[string]string) CredentialPreview {
+	preview := CredentialPreview{Type: CredentialPreviewType.String(), Attributes: make([]PreviewAttribute, 0, len(values))}
+	for name, value := range values {
+		preview.Attributes = append(preview.Attributes, PreviewAttribute{Name: name, Value: value})
+	}
+	sort.Slice(preview.Attributes, func(i, j int) bool {
+		return preview.Attributes[i].Name < preview.Attributes[j].Name
+	})
+	return preview
+}
+
+// Values returns the raw values of the preview
+func (p *CredentialPreview) Values() map[string]string {
+	values := make(map[string]string, len(p.Attributes))
+	for _, attribute := range p.Attributes {
+		values[attribute.Name] = attribute.Value
+	}
+	return values
+}
+
+// credentialValuesJson returns the raw and encoded values given to IssuerCreateCredential. Values in the 32 bits
+// integer range are encoded as themselves (EncodeValue of an int), the others as the sha256 of the raw value.
+func (p *CredentialPreview) credentialValuesJson() (string, error) {
+	type credentialValue struct {
+		Raw     string `json:"raw"`
+		Encoded string `json:"encoded"`
+	}
+	values := make(map[string]credentialValue, len(p.Attributes))
+	for _, attribute := range p.Attributes {
+		var encoded string
+		number, err := strconv.ParseInt(attribute.Value, 10, 64)
+		if err == nil && number >= math.MinInt32 && number <= math.MaxInt32 {
+			encoded = indySDK.EncodeValue(int(number))
+		} else {
+			encoded = indySDK.EncodeValue(attribute.Value)
+		}
+		values[attribute.Name] = credentialValue{Raw: attribute.Value, Encoded: encoded}
+	}
+	valuesJson, err := json.Marshal(values)
+	return string(valuesJson), err
+}
+
+// CredentialOffer libindy credential offer with the preview of the values
+type CredentialOffer struct {
+	Header
+	Comment           string            `json:"comment,omitempty"`
+	CredentialPreview CredentialPreview `json:"credential_preview"`
+	OffersAttach      []Attachment      `json:"offers~attach"`
+}
+
+// CredentialRequest libindy credential request answering an offer
+type CredentialRequest struct {
+	Header
+	Comment        string       `json:"comment,omitempty"`
+	RequestsAttach []Attachment `json:"requests~attach"`
+}
+
+// CredentialIssue libindy credential answering a request
+type CredentialIssue struct {
+	Header
+	Comment           string       `json:"comment,omitempty"`
+	CredentialsAttach []Attachment `json:"credentials~attach"`
+}
+
+// ProblemDescription code and text of a problem report
+type ProblemDescription struct {
+	En   string `json:"en,omitempty"`
+	Code string `json:"code"`
+}
+
+// ProblemReport reports the failure of a thread (Aries RFC 0035)
+type ProblemReport struct {
+	Header
+	Description ProblemDescription `json:"description"`
+}
+
+// CredentialExchangeRecord state of a credential exchange kept in the wallet
+type CredentialExchangeRecord struct {
+	Id                  string            `json:"id"`
+	Role                string            `json:"role"`
+	State               string            `json:"state"`
+	ThreadId            string            `json:"thread_id"` // @id of the offer
+	TheirDid            string            `json:"their_did"`
+	CredDefId           string            `json:"cred_def_id"`
+	Comment             string            `json:"comment,omitempty"`
+	Preview             CredentialPreview `json:"credential_preview"`
+	OfferJson           string            `json:"offer,omitempty"`
+	RequestJson         string            `json:"request,omitempty"`
+	RequestMetadataJson string            `json:"request_metadata,omitempty"` // kept by the holder until the credential is stored
+	CredentialId        string            `json:"credential_id,omitempty"`    // wallet id of the stored credential
+	RevRegId            string            `json:"rev_reg_id,omitempty"`
+	CredRevId           string            `json:"cred_rev_id,omitempty"`
+	ErrorMessage        string            `json:"error_msg,omitempty"`
+	CreatedAt           time.Time         `json:"created_at"`
+	UpdatedAt           time.Time         `json:"updated_at"`
+}
+
+func (r *CredentialExchangeRecord) tags() map[string]string {
+	return map[string]string{
+		"role":        r.Role,
+		"state":       r.State,
+		"thread_id":   r.ThreadId,
+		"their_did":   r.TheirDid,
+		"cred_def_id": r.CredDefId,
+	}
+}
+
+// CredentialLedger reads the ledger objects the holder needs to request and store a credential
+type CredentialLedger interface {
+	CredDefCtx(ctx context.Context, credDefId string) (credDefJson string, err error)
+	RevRegDefCtx(ctx context.Context, revRegId string) (revRegDefJson string, err error)
+}
+
+// PoolLedger CredentialLedger reading the pool of PoolHandle
+type PoolLedger struct {
+	PoolHandle   int
+	SubmitterDid string // optional
+}
+
+// CredDefCtx implements CredentialLedger
+func (l PoolLedger) CredDefCtx(ctx context.Context, credDefId string) (string, error) {
+	_, credDefJson, _, err := indySDK.GetCredDefCtx(ctx, l.PoolHandle, l.SubmitterDid, credDefId)
+	return credDefJson, err
+}
+
+// RevRegDefCtx implements CredentialLedger
+func (l PoolLedger) RevRegDefCtx(ctx context.Context, revRegId string) (string, error) {
+	request, err := indySDK.BuildGetRevRegDefRequestCtx(ctx, l.SubmitterDid, revRegId)
+	if err != nil {
+		return "", err
+	}
+	response, err := indySDK.SubmitRequestCtx(ctx, l.PoolHandle, request)
+	if err != nil {
+		return "", err
+	}
+	_, revRegDefJson, err := indySDK.ParseGetRevocRegDefResponseCtx(ctx, response)
+	return revRegDefJson, err
+}
+
+// IssueCredentials runs the issue credential protocol of an agent, as issuer and as holder. The exchanges are
+// kept in the wallet, each protocol step saves the record before its message is sent.
+type IssueCredentials struct {
+	WalletHandle int
+	Outbound     *Outbound
+
+	// issuer
+	Registries  *indySDK.RevocRegistryManager // issues in its registries when set, for revocable cred defs
+	Revocations *indySDK.RevocationManager    // tracks the revocable credentials issued when set
+
+	// holder
+	Ledger         CredentialLedger
+	MasterSecretId string // created on the first request when empty
+	AutoRequest    bool   // answers every offer with a request, otherwise see RequestCredential
+
+	// OnStateChange is called after an exchange record is saved, e.g. to wait for the done state
+	OnStateChange func(record CredentialExchangeRecord)
+
+	mutex sync.Mutex // serializes the creation of the master secret
+}
+
+// NewIssueCredentials creates the issue credential protocol of an agent, ledger is needed by holders only
+func NewIssueCredentials(wh int, outbound *Outbound, ledger CredentialLedger) *IssueCredentials {
+	return &IssueCredentials{WalletHandle: wh, Outbound: outbound, Ledger: ledger}
+}
+
+// Register handles the issue credential messages received by the inbound transport
+func (ic *IssueCredentials) Register(inbound *Inbound) {
+	inbound.Handle(CredentialOfferType, ic.HandleOffer)
+	inbound.Handle(CredentialRequestType, ic.HandleRequest)
+	inbound.Handle(CredentialIssueType, ic.HandleCredential)
+	inbound.Handle(CredentialAckType, ic.HandleAck)
+	inbound.Handle(CredentialProblemReportType, ic.HandleProblemReport)
+}
+
+func (ic *IssueCredentials) saveCtx(ctx context.Context, record *CredentialExchangeRecord) error {
+	record.UpdatedAt = time.Now().UTC()
+	err := saveRecordCtx(ctx, ic.WalletHandle, CredentialExchangeRecordType, record.Id, record, record.tags())
+	if err != nil {
+		return err
+	}
+	if ic.OnStateChange != nil {
+		ic.OnStateChange(*record)
+	}
+	return nil
+}
+
+// Exchange returns a credential exchange record
+func (ic *IssueCredentials) Exchange(id string) (*CredentialExchangeRecord, error) {
+	return ic.ExchangeCtx(context.Background(), id)
+}
+
+// ExchangeCtx is like Exchange but returns ctx.Err() if ctx is done before libindy answers.
+func (ic *IssueCredentials) ExchangeCtx(ctx context.Context, id string) (*CredentialExchangeRecord, error) {
+	var record CredentialExchangeRecord
+	err := getRecordCtx(ctx, ic.WalletHandle, CredentialExchangeRecordType, id, &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Exchanges returns the credential exchanges matching the tags, e.g. {"their_did": did, "state": "done"}
+func (ic *IssueCredentials) Exchanges(tags map[string]string) ([]CredentialExchangeRecord, error) {
+	return ic.ExchangesCtx(context.Background(), tags)
+}
+
+// ExchangesCtx is like Exchanges but returns ctx.Err() if ctx is done before libindy answers.
+func (ic *IssueCredentials) ExchangesCtx(ctx context.Context, tags map[string]string) ([]CredentialExchangeRecord, error) {
+	records, err := searchRecordsCtx(ctx, ic.WalletHandle, CredentialExchangeRecordType, tags)
+	if err != nil {
+		return nil, err
+	}
+	exchanges := make([]CredentialExchangeRecord, 0, len(records))
+	for _, record := range records {
+		var exchange CredentialExchangeRecord
+		err = json.Unmarshal([]byte(record.Value), &exchange)
+		if err != nil {
+			return nil, err
+		}
+		exchanges = append(exchanges, exchange)
+	}
+	return exchanges, nil
+}
+
+// findCtx returns the exchange of the thread, with the role, expected in the state
+func (ic *IssueCredentials) findCtx(ctx context.Context, threadId string, role string, state string) (*CredentialExchangeRecord, error) {
+	var record CredentialExchangeRecord
+	err := findRecordCtx(ctx, ic.WalletHandle, CredentialExchangeRecordType, map[string]string{
+		"thread_id": threadId,
+		"role":      role,
+		"state":     state,
+	}, &record)
+	if errors.Is(err, ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: no %s exchange in state %s for thread %s", ErrCredentialExchangeState, role, state, threadId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// checkSenderCtx rejects a message of the exchange not sent by the agent of their did
+func (ic *IssueCredentials) checkSenderCtx(ctx context.Context, record *CredentialExchangeRecord, message *Unpacked) error {
+	theirKey, err := indySDK.KeyForLocalDIDCtx(ctx, ic.WalletHandle, record.TheirDid)
+	if err != nil {
+		return err
+	}
+	if message.SenderKey != theirKey {
+		return fmt.Errorf("%w: thread %s message not sent by %s", ErrCredentialExchangeState, record.ThreadId, record.TheirDid)
+	}
+	return nil
+}
+
+// abandonCtx saves the exchange as abandoned and reports the problem to the other agent, returns cause
+func (ic *IssueCredentials) abandonCtx(ctx context.Context, record *CredentialExchangeRecord, code string, cause error) error {
+	record.State, record.ErrorMessage = CredentialStateAbandoned, cause.Error()
+	err := ic.saveCtx(ctx, record)
+	if err != nil {
+		return err
+	}
+	report := ProblemReport{Header: NewHeader(CredentialProblemReportType), Description: ProblemDescription{En: cause.Error(), Code: code}}
+	report.SetThread(record.ThreadId)
+	err = ic.Outbound.SendCtx(ctx, record.TheirDid, report)
+	if err != nil {
+		return fmt.Errorf("%v, problem report not sent: %w", cause, err)
+	}
+	return cause
+}
+
+// Abandon stops an exchange, e.g. to decline an offer, and reports it to the other agent
+func (ic *IssueCredentials) Abandon(id string, reason string) error {
+	return ic.AbandonCtx(context.Background(), id, reason)
+}
+
+// AbandonCtx is like Abandon but returns ctx.Err() if ctx is done before libindy or the other agent answer.
+func (ic *IssueCredentials) AbandonCtx(ctx context.Context, id string, reason string) error {
+	record, err := ic.ExchangeCtx(ctx, id)
+	if err != nil {
+		return err
+	}
+	if record.State == CredentialStateDone || record.State == CredentialStateAbandoned {
+		return fmt.Errorf("%w: exchange %s is %s", ErrCredentialExchangeState, id, record.State)
+	}
+	cause := errors.New(reason)
+	err = ic.abandonCtx(ctx, record, ProblemIssuanceAbandoned, cause)
+	if err == cause {
+		return nil
+	}
+	return err
+}
+
+// OfferCredential offers a credential of the cred def with the raw values to the agent of their did
+func (ic *IssueCredentials) OfferCredential(theirDid string, credDefId string, values map[string]string, comment string) (*CredentialExchangeRecord, error) {
+	return ic.OfferCredentialCtx(context.Background(), theirDid, credDefId, values, comment)
+}
+
+// OfferCredentialCtx is like OfferCredential but returns ctx.Err() if ctx is done before libindy or the holder answer.
+func (ic *IssueCredentials) OfferCredentialCtx(ctx context.Context, theirDid string, credDefId string, values map[string]string, comment string) (*CredentialExchangeRecord, error) {
+	offerJson, err := indySDK.IssuerCreateCredentialOfferCtx(ctx, ic.WalletHandle, credDefId)
+	if err != nil {
+		return nil, err
+	}
+	offer := CredentialOffer{
+		Header:            NewHeader(CredentialOfferType),
+		Comment:           comment,
+		CredentialPreview: NewCredentialPreview(values),
+		OffersAttach:      []Attachment{NewJsonAttachment("libindy-cred-offer-0", offerJson)},
+	}
+
+	record := &CredentialExchangeRecord{
+		Id:        uuid.NewString(),
+		Role:      CredentialRoleIssuer,
+		State:     CredentialStateOfferSent,
+		ThreadId:  offer.Id,
+		TheirDid:  theirDid,
+		CredDefId: credDefId,
+		Comment:   comment,
+		Preview:   offer.CredentialPreview,
+		OfferJson: offerJson,
+		CreatedAt: time.Now().UTC(),
+	}
+	// saved first, the request can arrive before the offer post returns
+	err = ic.saveCtx(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	err = ic.Outbound.SendCtx(ctx, theirDid, offer)
+	if err != nil {
+		return record, err
+	}
+	return ic.ExchangeCtx(ctx, record.Id)
+}
+
+// HandleOffer saves a credential offer of a pairwise agent and requests it with AutoRequest, it is a HandlerFunc
+func (ic *IssueCredentials) HandleOffer(ctx context.Context, message *Unpacked) error {
+	var offer CredentialOffer
+	err := message.Decode(&offer)
+	if err != nil {
+		return err
+	}
+	theirDid, err := TheirDidForKeyCtx(ctx, ic.WalletHandle, message.SenderKey)
+	if err != nil {
+		return err
+	}
+	offerJson, err := firstAttachment(offer.OffersAttach, "offers~attach")
+	if err != nil {
+		return err
+	}
+	var libindyOffer struct {
+		CredDefId string `json:"cred_def_id"`
+	}
+	err = json.Unmarshal([]byte(offerJson), &libindyOffer)
+	if err != nil {
+		return err
+	}
+
+	record := &CredentialExchangeRecord{
+		Id:        uuid.NewString(),
+		Role:      CredentialRoleHolder,
+		State:     CredentialStateOfferReceived,
+		ThreadId:  offer.ThreadId(),
+		TheirDid:  theirDid,
+		CredDefId: libindyOffer.CredDefId,
+		Comment:   offer.Comment,
+		Preview:   offer.CredentialPreview,
+		OfferJson: offerJson,
+		CreatedAt: time.Now().UTC(),
+	}
+	err = ic.saveCtx(ctx, record)
+	if err != nil {
+		return err
+	}
+	if !ic.AutoRequest {
+		return nil
+	}
+	return ic.requestCtx(ctx, record)
+}
+
+// RequestCredential answers a received offer with a credential request from the pairwise did
+func (ic *IssueCredentials) RequestCredential(id string) (*CredentialExchangeRecord, error) {
+	return ic.RequestCredentialCtx(context.Background(), id)
+}
+
+// RequestCredentialCtx is like RequestCredential but returns ctx.Err() if ctx is done before libindy or the issuer answer.
+func (ic *IssueCredentials) RequestCredentialCtx(ctx context.Context, id string) (*CredentialExchangeRecord, error) {
+	record, err := ic.ExchangeCtx(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if record.Role != CredentialRoleHolder || record.State != CredentialStateOfferReceived {
+		return record, fmt.Errorf("%w: %s exchange %s is %s", ErrCredentialExchangeState, record.Role, id, record.State)
+	}
+	err = ic.requestCtx(ctx, record)
+	if err != nil {
+		return record, err
+	}
+	return ic.ExchangeCtx(ctx, id)
+}
+
+// credDefCtx reads a cred def from the ledger of the holder
+func (ic *IssueCredentials) credDefCtx(ctx context.Context, credDefId string) (string, error) {
+	if ic.Ledger == nil {
+		return "", fmt.Errorf("no ledger to read the cred def %s", credDefId)
+	}
+	return ic.Ledger.CredDefCtx(ctx, credDefId)
+}
+
+// masterSecretCtx returns the master secret of the holder, created on the first call when MasterSecretId is empty
+func (ic *IssueCredentials) masterSecretCtx(ctx context.Context) (string, error) {
+	ic.mutex.Lock()
+	defer ic.mutex.Unlock()
+	if ic.MasterSecretId != "" {
+		return ic.MasterSecretId, nil
+	}
+	masterSecretId, err := indySDK.ProverCreateMasterSecretCtx(ctx, ic.WalletHandle, "")
+	if err != nil {
+		return "", err
+	}
+	ic.MasterSecretId = masterSecretId
+	return masterSecretId, nil
+}
+
+// requestCtx creates the libindy credential request of an offer and sends it, failures abandon the exchange
+func (ic *IssueCredentials) requestCtx(ctx context.Context, record *CredentialExchangeRecord) error {
+	pairwiseJson, err := indySDK.GetPairwiseCtx(ctx, ic.WalletHandle, record.TheirDid)
+	if err != nil {
+		return err
+	}
+	var pairwise pairwiseInfo
+	err = json.Unmarshal([]byte(pairwiseJson), &pairwise)
+	if err != nil {
+		return err
+	}
+	credDefJson, err := ic.credDefCtx(ctx, record.CredDefId)
+	if err != nil {
+		return ic.abandonCtx(ctx, record, ProblemRequestRejected, err)
+	}
+	masterSecretId, err := ic.masterSecretCtx(ctx)
+	if err != nil {
+		return err
+	}
+	requestJson, requestMetadataJson, err := indySDK.ProverCreateCredentialRequestCtx(ctx, ic.WalletHandle, pairwise.MyDid, record.OfferJson, credDefJson, masterSecretId)
+	if err != nil {
+		return ic.abandonCtx(ctx, record, ProblemRequestRejected, err)
+	}
+
+	request := CredentialRequest{
+		Header:         NewHeader(CredentialRequestType),
+		RequestsAttach: []Attachment{NewJsonAttachment("libindy-cred-request-0", requestJson)},
+	}
+	request.SetThread(record.ThreadId)
+
+	record.State = CredentialStateRequestSent
+	record.RequestJson, record.RequestMetadataJson = requestJson, requestMetadataJson
+	// saved first, the credential can arrive before the request post returns
+	err = ic.saveCtx(ctx, record)
+	if err != nil {
+		return err
+	}
+	return ic.Outbound.SendCtx(ctx, record.TheirDid, request)
+}
+
+// HandleRequest issues the credential requested for an offer, it is a HandlerFunc
+func (ic *IssueCredentials) HandleRequest(ctx context.Context, message *Unpacked) error {
+	var request CredentialRequest
+	err := message.Decode(&request)
+	if err != nil {
+		return err
+	}
+	record, err := ic.findCtx(ctx, request.ThreadId(), CredentialRoleIssuer, CredentialStateOfferSent)
+	if err != nil {
+		return err
+	}
+	err = ic.checkSenderCtx(ctx, record, message)
+	if err != nil {
+		return err
+	}
+	requestJson, err := firstAttachment(request.RequestsAttach, "requests~attach")
+	if err != nil {
+		return ic.abandonCtx(ctx, record, ProblemIssuanceAbandoned, err)
+	}
+	record.State, record.RequestJson = CredentialStateRequestReceived, requestJson
+	err = ic.saveCtx(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	valuesJson, err := record.Preview.credentialValuesJson()
+	if err != nil {
+		return ic.abandonCtx(ctx, record, ProblemIssuanceAbandoned, err)
+	}
+	var credentialJson, revRegDeltaJson string
+	var errRollover error
+	if ic.Registries != nil {
+		credentialJson, record.RevRegId, record.CredRevId, revRegDeltaJson, err = ic.Registries.IssueCredentialCtx(ctx, record.OfferJson, requestJson, valuesJson)
+		if err != nil && credentialJson != "" {
+			// the credential is issued, only the preparation of the next registry failed
+			errRollover, err = err, nil
+		}
+	} else {
+		credentialJson, _, _, err = indySDK.IssuerCreateCredentialCtx(ctx, ic.WalletHandle, record.OfferJson, requestJson, valuesJson, "", 0)
+	}
+	if err != nil {
+		return ic.abandonCtx(ctx, record, ProblemIssuanceAbandoned, err)
+	}
+	if ic.Revocations != nil && record.RevRegId != "" {
+		err = ic.Revocations.RecordIssuedCtx(ctx, anoncreds.CredentialInfo{
+			SubjectDid:             record.TheirDid,
+			CredentialDefinitionId: record.CredDefId,
+			RevocRegId:             record.RevRegId,
+			CredRevocId:            record.CredRevId,
+		}, revRegDeltaJson)
+		if err != nil {
+			return ic.abandonCtx(ctx, record, ProblemIssuanceAbandoned, err)
+		}
+	}
+
+	issue := CredentialIssue{
+		Header:            NewHeader(CredentialIssueType),
+		CredentialsAttach: []Attachment{NewJsonAttachment("libindy-cred-0", credentialJson)},
+	}
+	issue.ReplyTo(&request)
+
+	record.State = CredentialStateCredentialIssued
+	// saved first, the ack can arrive before the credential post returns
+	err = ic.saveCtx(ctx, record)
+	if err != nil {
+		return err
+	}
+	err = ic.Outbound.SendCtx(ctx, record.TheirDid, issue)
+	if err != nil {
+		return err
+	}
+	return errRollover
+}
+
+// HandleCredential stores the issued credential in the wallet and acknowledges it, it is a HandlerFunc
+func (ic *IssueCredentials) HandleCredential(ctx context.Context, message *Unpacked) error {
+	var issue CredentialIssue
+	err := message.Decode(&issue)
+	if err != nil {
+		return err
+	}
+	record, err := ic.findCtx(ctx, issue.ThreadId(), CredentialRoleHolder, CredentialStateRequestSent)
+	if err != nil {
+		return err
+	}
+	err = ic.checkSenderCtx(ctx, record, message)
+	if err != nil {
+		return err
+	}
+	credentialJson, err := firstAttachment(issue.CredentialsAttach, "credentials~attach")
+	if err != nil {
+		return ic.abandonCtx(ctx, record, ProblemCredentialInvalid, err)
+	}
+	var credential struct {
+		RevRegId *string `json:"rev_reg_id"`
+	}
+	err = json.Unmarshal([]byte(credentialJson), &credential)
+	if err != nil {
+		return ic.abandonCtx(ctx, record, ProblemCredentialInvalid, err)
+	}
+	record.State = CredentialStateCredentialReceived
+	err = ic.saveCtx(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	credDefJson, err := ic.credDefCtx(ctx, record.CredDefId)
+	if err != nil {
+		return ic.abandonCtx(ctx, record, ProblemCredentialInvalid, err)
+	}
+	var revRegDefJson string
+	if credential.RevRegId != nil && *credential.RevRegId != "" {
+		record.RevRegId = *credential.RevRegId
+		revRegDefJson, err = ic.Ledger.RevRegDefCtx(ctx, record.RevRegId)
+		if err != nil {
+			return ic.abandonCtx(ctx, record, ProblemCredentialInvalid, err)
+		}
+	}
+	record.CredentialId, err = indySDK.ProverStoreCredentialCtx(ctx, ic.WalletHandle, "", record.RequestMetadataJson, credentialJson, credDefJson, revRegDefJson)
+	if err != nil {
+		return ic.abandonCtx(ctx, record, ProblemCredentialInvalid, err)
+	}
+
+	ack := Ack{Header: NewHeader(CredentialAckType), Status: "OK"}
+	ack.ReplyTo(&issue)
+	record.State, record.RequestMetadataJson = CredentialStateDone, ""
+	err = ic.saveCtx(ctx, record)
+	if err != nil {
+		return err
+	}
+	return ic.Outbound.SendCtx(ctx, record.TheirDid, ack)
+}
+
+// HandleAck completes the exchange acknowledged by the holder, it is a HandlerFunc
+func (ic *IssueCredentials) HandleAck(ctx context.Context, message *Unpacked) error {
+	var ack Ack
+	err := message.Decode(&ack)
+	if err != nil {
+		return err
+	}
+	record, err := ic.findCtx(ctx, ack.ThreadId(), CredentialRoleIssuer, CredentialStateCredentialIssued)
+	if err != nil {
+		return err
+	}
+	err = ic.checkSenderCtx(ctx, record, message)
+	if err != nil {
+		return err
+	}
+	record.State = CredentialStateDone
+	return ic.saveCtx(ctx, record)
+}
+
+// HandleProblemReport abandons the exchange the other agent reported a problem for, it is a HandlerFunc
+func (ic *IssueCredentials) HandleProblemReport(ctx context.Context, message *Unpacked) error {
+	var report ProblemReport
+	err := message.Decode(&report)
+	if err != nil {
+		return err
+	}
+	records, err := ic.ExchangesCtx(ctx, map[string]string{"thread_id": report.ThreadId()})
+	if err != nil {
+		return err
+	}
+	for i := range records {
+		record := &records[i]
+		if record.State == CredentialStateDone || record.State == CredentialStateAbandoned {
+			continue
+		}
+		if ic.checkSenderCtx(ctx, record, message) != nil {
+			continue
+		}
+		record.State = CredentialStateAbandoned
+		record.ErrorMessage = fmt.Sprintf("%v: %s %s", ErrCredentialExchangeAbandoned, report.Description.Code, report.Description.En)
+		return ic.saveCtx(ctx, record)
+	}
+	return fmt.Errorf("%w: problem report for unknown thread %s", ErrCredentialExchangeState, report.ThreadId())
+}
@@ -0,0 +1,148 @@
+/*
+// ******************************************************************
+// Purpose: issue credential protocol unit testing
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package didcomm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	indySDK "github.com/joyride9999/IndySdkGoBindings"
+	"testing"
+)
+
+// testLedger offline CredentialLedger holding the cred defs of the issuer
+type testLedger map[string]string
+
+func (l testLedger) CredDefCtx(ctx context.Context, credDefId string) (string, error) {
+	credDefJson, ok := l[credDefId]
+	if !ok {
+		return "", fmt.Errorf("unknown cred def %s", credDefId)
+	}
+	return credDefJson, nil
+}
+
+func (l testLedger) RevRegDefCtx(ctx context.Context, revRegId string) (string, error) {
+	return "", fmt.Errorf("unknown revocation registry %s", revRegId)
+}
+
+// issuerCredDef creates a non revocable cred def in the issuer wallet
+func issuerCredDef(t *testing.T, wh int) (string, string) {
+	issuerDid, _, errDid := indySDK.CreateAndStoreDID(wh, "")
+	if errDid != nil {
+		t.Fatalf("CreateAndStoreDID() error = '%v'", errDid)
+	}
+	_, schemaJson, errSchema := indySDK.IssuerCreateSchema(issuerDid, "gvt", "1.0", `["name","age"]`)
+	if errSchema != nil {
+		t.Fatalf("IssuerCreateSchema() error = '%v'", errSchema)
+	}
+	credDefId, credDefJson, errCredDef := indySDK.IssuerCreateAndStoreCredentialDefinition(wh, issuerDid, schemaJson, "TAG1", "CL", `{"support_revocation": false}`)
+	if errCredDef != nil {
+		t.Fatalf("IssuerCreateAndStoreCredentialDefinition() error = '%v'", errCredDef)
+	}
+	return credDefId, credDefJson
+}
+
+func TestIssueCredential(t *testing.T) {
+	alice, closeAlice := newTestAgent(t, "issue_credential_alice")
+	defer closeAlice()
+	bob, closeBob := newTestAgent(t, "issue_credential_bob")
+	defer closeBob()
+	aliceConnection, bobConnection := connect(t, alice, bob)
+
+	credDefId, credDefJson := issuerCredDef(t, alice.wh)
+	issuer := NewIssueCredentials(alice.wh, alice.outbound, nil)
+	issuer.Register(alice.inbound)
+	holder := NewIssueCredentials(bob.wh, bob.outbound, testLedger{credDefId: credDefJson})
+	holder.AutoRequest = true
+	holder.Register(bob.inbound)
+
+	// the handlers answer synchronously, the whole exchange is done once the offer is posted
+	values := map[string]string{"name": "Alex", "age": "28"}
+	issued, errOffer := issuer.OfferCredential(aliceConnection.TheirDid, credDefId, values, "gvt credential")
+	if errOffer != nil {
+		t.Errorf("OfferCredential() error = '%v'", errOffer)
+		return
+	}
+	if issued.State != CredentialStateDone || issued.Role != CredentialRoleIssuer {
+		t.Errorf("OfferCredential() issuer exchange = '%v'", issued)
+		return
+	}
+
+	received, errExchanges := holder.Exchanges(map[string]string{"thread_id": issued.ThreadId})
+	if errExchanges != nil || len(received) != 1 {
+		t.Errorf("Exchanges() exchanges = '%v', error = '%v'", received, errExchanges)
+		return
+	}
+	if received[0].State != CredentialStateDone || received[0].TheirDid != bobConnection.TheirDid || received[0].CredentialId == "" {
+		t.Errorf("Exchanges() holder exchange = '%v'", received[0])
+		return
+	}
+
+	credentialJson, errCredential := indySDK.ProverGetCredential(bob.wh, received[0].CredentialId)
+	if errCredential != nil {
+		t.Errorf("ProverGetCredential() error = '%v'", errCredential)
+		return
+	}
+	var credential struct {
+		Attrs     map[string]string `json:"attrs"`
+		CredDefId string            `json:"cred_def_id"`
+	}
+	json.Unmarshal([]byte(credentialJson), &credential)
+	if credential.CredDefId != credDefId || credential.Attrs["name"] != "Alex" || credential.Attrs["age"] != "28" {
+		t.Errorf("ProverGetCredential() credential = '%v'", credentialJson)
+	}
+}
+
+func TestIssueCredentialProblemReport(t *testing.T) {
+	alice, closeAlice := newTestAgent(t, "issue_problem_alice")
+	defer closeAlice()
+	bob, closeBob := newTestAgent(t, "issue_problem_bob")
+	defer closeBob()
+	aliceConnection, _ := connect(t, alice, bob)
+
+	credDefId, _ := issuerCredDef(t, alice.wh)
+	issuer := NewIssueCredentials(alice.wh, alice.outbound, nil)
+	issuer.Register(alice.inbound)
+	// the ledger of the holder does not know the cred def, the request can't be created
+	holder := NewIssueCredentials(bob.wh, bob.outbound, testLedger{})
+	holder.Register(bob.inbound)
+
+	offered, errOffer := issuer.OfferCredential(aliceConnection.TheirDid, credDefId, map[string]string{"name": "Alex", "age": "28"}, "")
+	if errOffer != nil || offered.State != CredentialStateOfferSent {
+		t.Errorf("OfferCredential() exchange = '%v', error = '%v'", offered, errOffer)
+		return
+	}
+	received, _ := holder.Exchanges(map[string]string{"thread_id": offered.ThreadId})
+	if len(received) != 1 || received[0].State != CredentialStateOfferReceived {
+		t.Errorf("Exchanges() holder exchanges = '%v'", received)
+		return
+	}
+
+	_, errRequest := holder.RequestCredential(received[0].Id)
+	if errRequest == nil {
+		t.Errorf("RequestCredential() unknown cred def error = '%v'", errRequest)
+		return
+	}
+	abandoned, _ := holder.Exchange(received[0].Id)
+	reported, _ := issuer.Exchange(offered.Id)
+	if abandoned.State != CredentialStateAbandoned || reported.State != CredentialStateAbandoned || reported.ErrorMessage == "" {
+		t.Errorf("exchange states = '%v', '%v'", abandoned, reported)
+		return
+	}
+
+	// abandoned exchanges can't go on
+	_, errRequest = holder.RequestCredential(received[0].Id)
+	if !errors.Is(errRequest, ErrCredentialExchangeState) {
+		t.Errorf("RequestCredential() abandoned exchange error = '%v'", errRequest)
+	}
+}
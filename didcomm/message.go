@@ -0,0 +1,162 @@
+/*
+// ******************************************************************
+// Purpose: DIDComm v1 message models, message types and decorators
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package didcomm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+)
+
+// DocUri prefix of the message types (Aries RFC 0020), LegacyDocUri is still sent by older agents
+const (
+	DocUri       = "https://didcomm.org/"
+	LegacyDocUri = "did:sov:BzCbsNYhMrjHiqZDTUASHg;spec/"
+)
+
+// ErrInvalidMessageType is returned for a @type that is not <doc uri><family>/<version>/<name>
+var ErrInvalidMessageType = errors.New("invalid message type")
+
+// MessageType parsed @type of a message
+type MessageType struct {
+	DocUri  string
+	Family  string
+	Version string
+	Name    string
+}
+
+// NewMessageType returns the type of a message of the https://didcomm.org/ protocols
+func NewMessageType(family string, version string, name string) MessageType {
+	return MessageType{DocUri: DocUri, Family: family, Version: version, Name: name}
+}
+
+// ParseMessageType parses a @type value
+func ParseMessageType(messageType string) (MessageType, error) {
+	// <doc uri> ends with '/' or ';spec/', the message is named by the three last path segments
+	segments := strings.Split(messageType, "/")
+	if len(segments) < 4 {
+		return MessageType{}, fmt.Errorf("%w: %s", ErrInvalidMessageType, messageType)
+	}
+	n := len(segments)
+	t := MessageType{
+		DocUri:  strings.Join(segments[:n-3], "/") + "/",
+		Family:  segments[n-3],
+		Version: segments[n-2],
+		Name:    segments[n-1],
+	}
+	if t.Family == "" || t.Version == "" || t.Name == "" {
+		return MessageType{}, fmt.Errorf("%w: %s", ErrInvalidMessageType, messageType)
+	}
+	return t, nil
+}
+
+// String returns the @type value
+func (t MessageType) String() string {
+	return t.DocUri + t.Family + "/" + t.Version + "/" + t.Name
+}
+
+// Protocol returns <family>/<version> of the type
+func (t MessageType) Protocol() string {
+	return t.Family + "/" + t.Version
+}
+
+// Is reports whether both types name the same message, the doc uri is ignored so legacy types match
+func (t MessageType) Is(other MessageType) bool {
+	return t.Family == other.Family && t.Version == other.Version && t.Name == other.Name
+}
+
+// Thread ~thread decorator (Aries RFC 0008)
+type Thread struct {
+	Thid           string         `json:"thid,omitempty"`
+	Pthid          string         `json:"pthid,omitempty"`
+	SenderOrder    int            `json:"sender_order,omitempty"`
+	ReceivedOrders map[string]int `json:"received_orders,omitempty"`
+}
+
+// Header fields every message carries, message structs embed it
+type Header struct {
+	Id     string  `json:"@id"`
+	Type   string  `json:"@type"`
+	Thread *Thread `json:"~thread,omitempty"`
+}
+
+// Message is implemented by the structs embedding a Header
+type Message interface {
+	MessageHeader() *Header
+}
+
+// NewHeader returns the header of a new message of the given type with a fresh @id
+func NewHeader(messageType MessageType) Header {
+	return Header{Id: uuid.NewString(), Type: messageType.String()}
+}
+
+// MessageHeader returns the header, it makes the embedding structs a Message
+func (h *Header) MessageHeader() *Header {
+	return h
+}
+
+// MessageType parses the @type of the message
+func (h *Header) MessageType() (MessageType, error) {
+	return ParseMessageType(h.Type)
+}
+
+// ThreadId returns the id of the thread of the message, a message without ~thread starts its own thread
+func (h *Header) ThreadId() string {
+	if h.Thread != nil && h.Thread.Thid != "" {
+		return h.Thread.Thid
+	}
+	return h.Id
+}
+
+// ParentThreadId returns the parent thread id of the message, empty if there is none
+func (h *Header) ParentThreadId() string {
+	if h.Thread == nil {
+		return ""
+	}
+	return h.Thread.Pthid
+}
+
+// SetThread places the message in a thread
+func (h *Header) SetThread(thid string) {
+	if h.Thread == nil {
+		h.Thread = &Thread{}
+	}
+	h.Thread.Thid = thid
+}
+
+// SetParentThread sets the parent thread of the message, e.g. the invitation a connection request answers
+func (h *Header) SetParentThread(pthid string) {
+	if h.Thread == nil {
+		h.Thread = &Thread{}
+	}
+	h.Thread.Pthid = pthid
+}
+
+// ReplyTo places the message in the thread of the message it answers
+func (h *Header) ReplyTo(message Message) {
+	h.SetThread(message.MessageHeader().ThreadId())
+}
+
+// ParseHeader reads the header of an encoded message
+func ParseHeader(message []byte) (*Header, error) {
+	var header Header
+	err := json.Unmarshal(message, &header)
+	if err != nil {
+		return nil, err
+	}
+	if header.Type == "" {
+		return nil, fmt.Errorf("%w: message has no @type", ErrInvalidMessageType)
+	}
+	return &header, nil
+}
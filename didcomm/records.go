@@ -0,0 +1,85 @@
+/*
+// ******************************************************************
+// Purpose: wallet persistence of the protocol records
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package didcomm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	indySDK "github.com/joyride9999/IndySdkGoBindings"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"github.com/joyride9999/IndySdkGoBindings/nonsecrets"
+)
+
+// ErrRecordNotFound is returned when no protocol record matches
+var ErrRecordNotFound = errors.New("record not found")
+
+// saveRecordCtx adds or replaces a non secret record holding the json of value
+func saveRecordCtx(ctx context.Context, wh int, recordType string, id string, value interface{}, tags map[string]string) error {
+	valueJson, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	tagsJson, err := json.Marshal(tags)
+	if err != nil {
+		return err
+	}
+
+	err = indySDK.IndyAddWalletRecordCtx(ctx, wh, recordType, id, string(valueJson), string(tagsJson))
+	if !errors.Is(err, indyUtils.ErrWalletItemAlreadyExists) {
+		return err
+	}
+	err = indySDK.IndyUpdateWalletRecordValueCtx(ctx, wh, recordType, id, string(valueJson))
+	if err != nil {
+		return err
+	}
+	return indySDK.IndyUpdateWalletRecordTagsCtx(ctx, wh, recordType, id, string(tagsJson))
+}
+
+// getRecordCtx decodes the value of a non secret record into value
+func getRecordCtx(ctx context.Context, wh int, recordType string, id string, value interface{}) error {
+	recordJson, err := indySDK.IndyGetWalletRecordCtx(ctx, wh, recordType, id, `{"retrieveValue":true,"retrieveTags":false}`)
+	if errors.Is(err, indyUtils.ErrWalletItemNotFound) {
+		return fmt.Errorf("%w: %s %s", ErrRecordNotFound, recordType, id)
+	}
+	if err != nil {
+		return err
+	}
+	var record nonsecrets.Record
+	err = json.Unmarshal([]byte(recordJson), &record)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(record.Value), value)
+}
+
+// findRecordCtx decodes the value of the first non secret record matching the tags into value
+func findRecordCtx(ctx context.Context, wh int, recordType string, tags map[string]string, value interface{}) error {
+	records, err := searchRecordsCtx(ctx, wh, recordType, tags)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("%w: %s %v", ErrRecordNotFound, recordType, tags)
+	}
+	return json.Unmarshal([]byte(records[0].Value), value)
+}
+
+// searchRecordsCtx returns the non secret records matching the tags
+func searchRecordsCtx(ctx context.Context, wh int, recordType string, tags map[string]string) ([]nonsecrets.Record, error) {
+	query, err := json.Marshal(tags)
+	if err != nil {
+		return nil, err
+	}
+	return indySDK.IndySearchWalletRecordsCtx(ctx, wh, recordType, string(query))
+}
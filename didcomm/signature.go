@@ -0,0 +1,102 @@
+/*
+// ******************************************************************
+// Purpose: ~sig field decorator (Aries RFC 0234), signs a field of a
+// message with a wallet key
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package didcomm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	indySDK "github.com/joyride9999/IndySdkGoBindings"
+	"time"
+)
+
+// SignatureType @type of the ed25519 field signature
+var SignatureType = NewMessageType("signature", "1.0", "ed25519Sha512_single")
+
+// ErrInvalidSignature is returned for a field signature that does not verify
+var ErrInvalidSignature = errors.New("invalid field signature")
+
+// SignatureDecorator signed field of a message, sig_data is the 8 bytes big endian signing time followed by the field json
+type SignatureDecorator struct {
+	Type      string `json:"@type"`
+	Signature string `json:"signature"`
+	SigData   string `json:"sig_data"`
+	Signer    string `json:"signer"`
+}
+
+// SignField signs the json of value with a key of the wallet
+func SignField(wh int, signerKey string, value interface{}) (*SignatureDecorator, error) {
+	return SignFieldCtx(context.Background(), wh, signerKey, value)
+}
+
+// SignFieldCtx is like SignField but returns ctx.Err() if ctx is done before libindy answers.
+func SignFieldCtx(ctx context.Context, wh int, signerKey string, value interface{}) (*SignatureDecorator, error) {
+	valueJson, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	sigData := make([]byte, 8, 8+len(valueJson))
+	binary.BigEndian.PutUint64(sigData, uint64(time.Now().Unix()))
+	sigData = append(sigData, valueJson...)
+
+	signature, err := indySDK.SignCtx(ctx, wh, signerKey, sigData, uint32(len(sigData)))
+	if err != nil {
+		return nil, err
+	}
+	return &SignatureDecorator{
+		Type:      SignatureType.String(),
+		Signature: base64.URLEncoding.EncodeToString(signature),
+		SigData:   base64.URLEncoding.EncodeToString(sigData),
+		Signer:    signerKey,
+	}, nil
+}
+
+// Verify checks the signature and decodes the signed field into value
+func (s *SignatureDecorator) Verify(value interface{}) error {
+	return s.VerifyCtx(context.Background(), value)
+}
+
+// VerifyCtx is like Verify but returns ctx.Err() if ctx is done before libindy answers.
+func (s *SignatureDecorator) VerifyCtx(ctx context.Context, value interface{}) error {
+	signature, err := decodeBase64Url(s.Signature)
+	if err != nil {
+		return err
+	}
+	sigData, err := decodeBase64Url(s.SigData)
+	if err != nil {
+		return err
+	}
+	if len(sigData) < 8 {
+		return ErrInvalidSignature
+	}
+
+	valid, err := indySDK.VerifyCtx(ctx, s.Signer, sigData, uint32(len(sigData)), signature, uint32(len(signature)))
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+	return json.Unmarshal(sigData[8:], value)
+}
+
+// decodeBase64Url accepts padded and unpadded base64url, agents send both
+func decodeBase64Url(s string) ([]byte, error) {
+	decoded, err := base64.URLEncoding.DecodeString(s)
+	if err != nil {
+		return base64.RawURLEncoding.DecodeString(s)
+	}
+	return decoded, nil
+}
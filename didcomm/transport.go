@@ -0,0 +1,335 @@
+/*
+// ******************************************************************
+// Purpose: DIDComm v1 HTTP transport, receives packed messages and
+// dispatches them by @type, resolves and posts outbound messages
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package didcomm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	indySDK "github.com/joyride9999/IndySdkGoBindings"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// ContentType media type of the posted envelopes (Aries RFC 0025), LegacyContentType is still sent by older agents
+const (
+	ContentType       = "application/didcomm-envelope-enc"
+	LegacyContentType = "application/ssi-agent-wire"
+)
+
+// MaxMessageSize largest envelope accepted by Inbound
+var MaxMessageSize int64 = 1 << 20
+
+// ErrNoEndpoint is returned when no endpoint is known for a did
+var ErrNoEndpoint = errors.New("no endpoint for did")
+
+// ErrDeliveryFailed is returned when the recipient endpoint rejects a message
+var ErrDeliveryFailed = errors.New("message delivery failed")
+
+// ErrNoHandler is returned by Dispatch for a message type without handler
+var ErrNoHandler = errors.New("no handler for message type")
+
+// ErrUnknownSender is returned when the sender key of a message belongs to no pairwise
+var ErrUnknownSender = errors.New("sender is not a pairwise")
+
+// HandlerFunc handles an inbound message, the message is decoded with Unpacked.Decode
+type HandlerFunc func(ctx context.Context, message *Unpacked) error
+
+// Inbound is an http.Handler unpacking the posted envelopes with the keys of a wallet and passing each message
+// to the handler registered for its @type. Answers 202 once the handler returned, 501 for a type without handler.
+// The sender of a failed message only gets the status text, the error itself is passed to OnError.
+type Inbound struct {
+	WalletHandle int
+	OnError      func(r *http.Request, status int, err error) // reports the failed requests, may be nil
+
+	handlers map[string]HandlerFunc // keyed by <family>/<version>/<name>, the doc uri is ignored
+	mutex    sync.RWMutex
+}
+
+// NewInbound creates the inbound transport of an agent wallet
+func NewInbound(wh int) *Inbound {
+	return &Inbound{WalletHandle: wh, handlers: make(map[string]HandlerFunc)}
+}
+
+func handlerKey(messageType MessageType) string {
+	return messageType.Protocol() + "/" + messageType.Name
+}
+
+// Handle registers the handler of a message type, it replaces the previous one
+func (in *Inbound) Handle(messageType MessageType, handler HandlerFunc) {
+	in.mutex.Lock()
+	defer in.mutex.Unlock()
+	in.handlers[handlerKey(messageType)] = handler
+}
+
+// ServeHTTP implements http.Handler
+func (in *Inbound) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "envelopes are posted", http.StatusMethodNotAllowed)
+		return
+	}
+	packed, err := ioutil.ReadAll(io.LimitReader(r.Body, MaxMessageSize+1))
+	if err != nil {
+		in.fail(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if int64(len(packed)) > MaxMessageSize {
+		http.Error(w, "envelope too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	unpacked, err := UnpackCtx(r.Context(), in.WalletHandle, packed)
+	if err != nil {
+		in.fail(w, r, http.StatusBadRequest, err)
+		return
+	}
+	err = in.Dispatch(r.Context(), unpacked)
+	if errors.Is(err, ErrInvalidMessageType) {
+		in.fail(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if errors.Is(err, ErrNoHandler) {
+		in.fail(w, r, http.StatusNotImplemented, err)
+		return
+	}
+	if err != nil {
+		in.fail(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// fail answers the status text only, the wallet and libindy errors may name keys, records or threads
+func (in *Inbound) fail(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if in.OnError != nil {
+		in.OnError(r, status, err)
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+// Dispatch passes an unpacked message to the handler of its type, e.g. for messages received on another transport
+func (in *Inbound) Dispatch(ctx context.Context, unpacked *Unpacked) error {
+	header, err := unpacked.Header()
+	if err != nil {
+		return err
+	}
+	messageType, err := header.MessageType()
+	if err != nil {
+		return err
+	}
+
+	in.mutex.RLock()
+	handler, ok := in.handlers[handlerKey(messageType)]
+	in.mutex.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNoHandler, header.Type)
+	}
+	return handler(ctx, unpacked)
+}
+
+// PairwiseMetadata didcomm part of the pairwise metadata, the other keys of the metadata json are kept
+type PairwiseMetadata struct {
+	TheirService *Service `json:"their_service,omitempty"`
+}
+
+type pairwiseInfo struct {
+	MyDid    string `json:"my_did"`
+	Metadata string `json:"metadata"`
+}
+
+// SetPairwiseService saves the service of their agent in the pairwise metadata
+func SetPairwiseService(wh int, theirDid string, service Service) error {
+	return SetPairwiseServiceCtx(context.Background(), wh, theirDid, service)
+}
+
+// SetPairwiseServiceCtx is like SetPairwiseService but returns ctx.Err() if ctx is done before libindy answers.
+func SetPairwiseServiceCtx(ctx context.Context, wh int, theirDid string, service Service) error {
+	pairwiseJson, err := indySDK.GetPairwiseCtx(ctx, wh, theirDid)
+	if err != nil {
+		return err
+	}
+	var pairwise pairwiseInfo
+	err = json.Unmarshal([]byte(pairwiseJson), &pairwise)
+	if err != nil {
+		return err
+	}
+	metadata := make(map[string]json.RawMessage)
+	if pairwise.Metadata != "" {
+		err = json.Unmarshal([]byte(pairwise.Metadata), &metadata)
+		if err != nil {
+			return fmt.Errorf("pairwise metadata is not a json object: %w", err)
+		}
+	}
+	metadata["their_service"], err = json.Marshal(service)
+	if err != nil {
+		return err
+	}
+	metadataJson, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	return indySDK.SetPairwiseMetadataCtx(ctx, wh, theirDid, string(metadataJson))
+}
+
+// TheirDidForKey returns their did of the pairwise whose key authcrypted a message (Unpacked.SenderKey)
+func TheirDidForKey(wh int, senderKey string) (string, error) {
+	return TheirDidForKeyCtx(context.Background(), wh, senderKey)
+}
+
+// TheirDidForKeyCtx is like TheirDidForKey but returns ctx.Err() if ctx is done before libindy answers.
+func TheirDidForKeyCtx(ctx context.Context, wh int, senderKey string) (string, error) {
+	if senderKey == "" {
+		return "", fmt.Errorf("%w: anoncrypted message", ErrUnknownSender)
+	}
+	listJson, err := indySDK.ListPairwiseCtx(ctx, wh)
+	if err != nil {
+		return "", err
+	}
+	var list []string
+	err = json.Unmarshal([]byte(listJson), &list)
+	if err != nil {
+		return "", err
+	}
+	for _, pairwiseJson := range list {
+		var pairwise struct {
+			TheirDid string `json:"their_did"`
+		}
+		err = json.Unmarshal([]byte(pairwiseJson), &pairwise)
+		if err != nil {
+			return "", err
+		}
+		theirKey, err := indySDK.KeyForLocalDIDCtx(ctx, wh, pairwise.TheirDid)
+		if err != nil {
+			return "", err
+		}
+		if theirKey == senderKey {
+			return pairwise.TheirDid, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownSender, senderKey)
+}
+
+// Outbound resolves the agent of a did, packs messages for it and posts them
+type Outbound struct {
+	WalletHandle int
+	PoolHandle   int // ledger of the did endpoints and keys, with 0 only the wallet is looked up
+	HTTPClient   *http.Client
+}
+
+// NewOutbound creates the outbound transport of an agent wallet
+func NewOutbound(wh int, ph int) *Outbound {
+	return &Outbound{WalletHandle: wh, PoolHandle: ph, HTTPClient: http.DefaultClient}
+}
+
+// Resolve returns the service of the agent of their did and the key to authcrypt with, empty if there is no
+// pairwise with it. The service in the pairwise metadata wins over the endpoint of the did (GetEndPointForDid),
+// the transport key of such an endpoint is used as routing key.
+func (o *Outbound) Resolve(theirDid string) (service Service, senderKey string, err error) {
+	return o.ResolveCtx(context.Background(), theirDid)
+}
+
+// ResolveCtx is like Resolve but returns ctx.Err() if ctx is done before libindy answers.
+func (o *Outbound) ResolveCtx(ctx context.Context, theirDid string) (service Service, senderKey string, err error) {
+	pairwiseJson, err := indySDK.GetPairwiseCtx(ctx, o.WalletHandle, theirDid)
+	if err != nil && !errors.Is(err, indyUtils.ErrWalletItemNotFound) {
+		return Service{}, "", err
+	}
+	if err == nil {
+		var pairwise pairwiseInfo
+		err = json.Unmarshal([]byte(pairwiseJson), &pairwise)
+		if err != nil {
+			return Service{}, "", err
+		}
+		senderKey, err = indySDK.KeyForLocalDIDCtx(ctx, o.WalletHandle, pairwise.MyDid)
+		if err != nil {
+			return Service{}, "", err
+		}
+		var metadata PairwiseMetadata
+		if pairwise.Metadata != "" && json.Unmarshal([]byte(pairwise.Metadata), &metadata) == nil &&
+			metadata.TheirService != nil && metadata.TheirService.ServiceEndpoint != "" {
+			return *metadata.TheirService, senderKey, nil
+		}
+	}
+
+	endpoint, transportKey, err := indySDK.GetEndPointForDidCtx(ctx, o.WalletHandle, o.PoolHandle, theirDid)
+	if errors.Is(err, indyUtils.ErrWalletItemNotFound) || errors.Is(err, indyUtils.ErrLedgerNotFound) || (err == nil && endpoint == "") {
+		return Service{}, "", fmt.Errorf("%w: %s", ErrNoEndpoint, theirDid)
+	}
+	if err != nil {
+		return Service{}, "", err
+	}
+	recipientKey, err := indySDK.KeyForDidCtx(ctx, o.PoolHandle, o.WalletHandle, theirDid)
+	if err != nil {
+		return Service{}, "", err
+	}
+	service = Service{RecipientKeys: []string{recipientKey}, ServiceEndpoint: endpoint}
+	if transportKey != "" && transportKey != recipientKey {
+		service.RoutingKeys = []string{transportKey}
+	}
+	return service, senderKey, nil
+}
+
+// Send resolves the agent of their did and posts the message to it
+func (o *Outbound) Send(theirDid string, message interface{}) error {
+	return o.SendCtx(context.Background(), theirDid, message)
+}
+
+// SendCtx is like Send but returns ctx.Err() if ctx is done before libindy or the recipient answer.
+func (o *Outbound) SendCtx(ctx context.Context, theirDid string, message interface{}) error {
+	service, senderKey, err := o.ResolveCtx(ctx, theirDid)
+	if err != nil {
+		return err
+	}
+	return o.SendToServiceCtx(ctx, service, message, senderKey)
+}
+
+// SendToService packs the message for the service and posts it to the service endpoint, e.g. to answer an invitation
+func (o *Outbound) SendToService(service Service, message interface{}, senderKey string) error {
+	return o.SendToServiceCtx(context.Background(), service, message, senderKey)
+}
+
+// SendToServiceCtx is like SendToService but returns ctx.Err() if ctx is done before libindy or the recipient answer.
+func (o *Outbound) SendToServiceCtx(ctx context.Context, service Service, message interface{}, senderKey string) error {
+	if service.ServiceEndpoint == "" {
+		return ErrNoEndpoint
+	}
+	packed, err := PackForServiceCtx(ctx, o.WalletHandle, message, service, senderKey)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, service.ServiceEndpoint, bytes.NewReader(packed))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", ContentType)
+	client := o.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(io.LimitReader(response.Body, 512))
+		return fmt.Errorf("%w: %s %s", ErrDeliveryFailed, response.Status, bytes.TrimSpace(body))
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+// ******************************************************************
+// Purpose: didcomm HTTP transport unit testing
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package didcomm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	indySDK "github.com/joyride9999/IndySdkGoBindings"
+	"github.com/joyride9999/IndySdkGoBindings/did"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPTransport(t *testing.T) {
+	whAlice, closeAlice := openWallet(t, "didcomm_alice")
+	defer closeAlice()
+	whBob, closeBob := openWallet(t, "didcomm_bob")
+	defer closeBob()
+
+	aliceDid, aliceKey, _ := indySDK.CreateAndStoreDID(whAlice, "")
+	bobDid, bobKey, _ := indySDK.CreateAndStoreDID(whBob, "")
+
+	pingType := NewMessageType("trust_ping", "1.0", "ping")
+	received := make(chan *Unpacked, 1)
+	inbound := NewInbound(whBob)
+	failures := make(chan error, 1)
+	inbound.OnError = func(r *http.Request, status int, err error) {
+		failures <- err
+	}
+	inbound.Handle(pingType, func(ctx context.Context, message *Unpacked) error {
+		received <- message
+		return nil
+	})
+	server := httptest.NewServer(inbound)
+	defer server.Close()
+
+	// alice knows bob through a pairwise holding the service of his agent
+	bobIdentity, _ := json.Marshal(did.IdentityDID{Did: bobDid, VerKey: bobKey})
+	errStore := indySDK.StoreTheirDid(whAlice, string(bobIdentity))
+	if errStore != nil {
+		t.Errorf("StoreTheirDid() error = '%v'", errStore)
+		return
+	}
+	errPairwise := indySDK.CreatePairwise(whAlice, bobDid, aliceDid, `{"alias": "bob"}`)
+	if errPairwise != nil {
+		t.Errorf("CreatePairwise() error = '%v'", errPairwise)
+		return
+	}
+	errService := SetPairwiseService(whAlice, bobDid, Service{RecipientKeys: []string{bobKey}, ServiceEndpoint: server.URL})
+	if errService != nil {
+		t.Errorf("SetPairwiseService() error = '%v'", errService)
+		return
+	}
+
+	outbound := NewOutbound(whAlice, 0)
+	message := ping{Header: NewHeader(pingType), Comment: "hi"}
+	errSend := outbound.Send(bobDid, message)
+	if errSend != nil {
+		t.Errorf("Send() error = '%v'", errSend)
+		return
+	}
+	unpacked := <-received
+	var got ping
+	errDecode := unpacked.Decode(&got)
+	if errDecode != nil || got.Id != message.Id || unpacked.SenderKey != aliceKey || unpacked.RecipientKey != bobKey {
+		t.Errorf("inbound message = '%v', unpacked = '%v', error = '%v'", got, unpacked, errDecode)
+		return
+	}
+
+	// the pairwise metadata keeps the other keys
+	pairwiseJson, _ := indySDK.GetPairwise(whAlice, bobDid)
+	var pairwise pairwiseInfo
+	json.Unmarshal([]byte(pairwiseJson), &pairwise)
+	var metadata map[string]interface{}
+	json.Unmarshal([]byte(pairwise.Metadata), &metadata)
+	if metadata["alias"] != "bob" || metadata["their_service"] == nil {
+		t.Errorf("pairwise metadata = '%v'", pairwise.Metadata)
+		return
+	}
+
+	// without pairwise the endpoint of the did is used and the message is anoncrypted
+	carolDid, carolKey, _ := indySDK.CreateAndStoreDID(whBob, "")
+	carolIdentity, _ := json.Marshal(did.IdentityDID{Did: carolDid, VerKey: carolKey})
+	indySDK.StoreTheirDid(whAlice, string(carolIdentity))
+	errEndpoint := indySDK.SetEndPointForDid(whAlice, carolDid, server.URL, carolKey)
+	if errEndpoint != nil {
+		t.Errorf("SetEndPointForDid() error = '%v'", errEndpoint)
+		return
+	}
+	errSend = outbound.Send(carolDid, message)
+	if errSend != nil {
+		t.Errorf("Send() error = '%v'", errSend)
+		return
+	}
+	unpacked = <-received
+	if unpacked.SenderKey != "" || unpacked.RecipientKey != carolKey {
+		t.Errorf("inbound anoncrypted message = '%v'", unpacked)
+		return
+	}
+
+	errSend = outbound.Send(carolDid, ping{Header: NewHeader(NewMessageType("trust_ping", "1.0", "ping_response"))})
+	if !errors.Is(errSend, ErrDeliveryFailed) {
+		t.Errorf("Send() without handler error = '%v'", errSend)
+		return
+	}
+	// the sender only gets the status text, the error is reported to OnError
+	if errFailure := <-failures; !errors.Is(errFailure, ErrNoHandler) || strings.Contains(errSend.Error(), errFailure.Error()) {
+		t.Errorf("OnError() error = '%v', Send() error = '%v'", errFailure, errSend)
+		return
+	}
+	_, _, errSend = outbound.Resolve("did:sov:unknown")
+	if errSend == nil {
+		t.Errorf("Resolve() unknown did succeeded")
+	}
+}
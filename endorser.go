@@ -0,0 +1,111 @@
+/*
+// ******************************************************************
+// Purpose: author / endorser workflow, the author signs a request for an
+// endorser, the endorser validates, counter-signs and submits it
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package indySDK
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/joyride9999/IndySdkGoBindings/ledger/types"
+)
+
+// RequiresEndorser reports whether the writes of a DID must go through an endorser, that is when its NYM has no
+// role allowed to write. Fails when the DID is not on the ledger.
+func RequiresEndorser(ph int, did string) (bool, error) {
+	return RequiresEndorserCtx(context.Background(), ph, did)
+}
+
+// RequiresEndorserCtx is like RequiresEndorser but returns ctx.Err() if ctx is done before libindy answers.
+func RequiresEndorserCtx(ctx context.Context, ph int, did string) (bool, error) {
+	getNymRequest, err := BuildGetNymRequestCtx(ctx, did, did)
+	if err != nil {
+		return false, err
+	}
+	getNymResponse, err := SubmitRequestCtx(ctx, ph, getNymRequest)
+	if err != nil {
+		return false, err
+	}
+	nym, _, err := ParseGetNymResponseTypedCtx(ctx, getNymResponse)
+	if err != nil {
+		return false, err
+	}
+	return !types.CanWriteWithoutEndorser(nym.Role), nil
+}
+
+// PrepareForEndorser is the author side of the workflow: appends the endorser to the request and multi signs it
+// with the author DID. Append the TAA acceptance (AppendTaaAcceptance) before, the signature covers it.
+func PrepareForEndorser(wh int, authorDid string, endorserDid string, requestJson string) (*types.EndorsementEnvelope, error) {
+	return PrepareForEndorserCtx(context.Background(), wh, authorDid, endorserDid, requestJson)
+}
+
+// PrepareForEndorserCtx is like PrepareForEndorser but returns ctx.Err() if ctx is done before libindy answers.
+func PrepareForEndorserCtx(ctx context.Context, wh int, authorDid string, endorserDid string, requestJson string) (*types.EndorsementEnvelope, error) {
+	request, err := types.ParseRequest(requestJson)
+	if err != nil {
+		return nil, err
+	}
+	if request.Identifier != authorDid {
+		return nil, fmt.Errorf("request identifier '%s' is not the author '%s'", request.Identifier, authorDid)
+	}
+
+	withEndorser, err := AppendRequestEndorserCtx(ctx, requestJson, endorserDid)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := MultiSignRequestCtx(ctx, wh, authorDid, withEndorser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.EndorsementEnvelope{
+		AuthorDid:   authorDid,
+		EndorserDid: endorserDid,
+		Request:     []byte(signed),
+	}, nil
+}
+
+// EndorseRequest is the endorser side of the workflow: checks the envelope against the policy and the author
+// signature against the author verkey (wallet or ledger), counter-signs the request with the endorser DID and
+// submits it. Refused envelopes fail with types.ErrEndorsementRejected, ledger rejections with types.ErrRequestRejected.
+func EndorseRequest(ph int, wh int, endorserDid string, envelope *types.EndorsementEnvelope, policy types.EndorsementPolicy) (*types.Reply, error) {
+	return EndorseRequestCtx(context.Background(), ph, wh, endorserDid, envelope, policy)
+}
+
+// EndorseRequestCtx is like EndorseRequest but returns ctx.Err() if ctx is done before libindy answers.
+func EndorseRequestCtx(ctx context.Context, ph int, wh int, endorserDid string, envelope *types.EndorsementEnvelope, policy types.EndorsementPolicy) (*types.Reply, error) {
+	if envelope == nil {
+		return nil, errors.New("nil endorsement envelope")
+	}
+	_, err := policy.Check(envelope, endorserDid)
+	if err != nil {
+		return nil, err
+	}
+	authorVerkey, err := KeyForDidCtx(ctx, ph, wh, envelope.AuthorDid)
+	if err != nil {
+		return nil, err
+	}
+	err = envelope.VerifyAuthorSignature(authorVerkey)
+	if err != nil {
+		return nil, err
+	}
+
+	endorsed, err := MultiSignRequestCtx(ctx, wh, endorserDid, string(envelope.Request))
+	if err != nil {
+		return nil, err
+	}
+	response, err := SubmitRequestCtx(ctx, ph, endorsed)
+	if err != nil {
+		return nil, err
+	}
+	return parseTypedReply(response)
+}
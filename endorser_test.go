@@ -0,0 +1,128 @@
+/*
+// ******************************************************************
+// Purpose: author / endorser workflow unit testing
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package indySDK
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"github.com/joyride9999/IndySdkGoBindings/ledger/types"
+	"testing"
+)
+
+func TestEndorserWorkflow(t *testing.T) {
+	poolHandle, errPool := getPoolLedger("testpool2")
+	if errPool != nil {
+		t.Errorf("getPoolLedger() error = '%v'", errPool)
+		return
+	}
+	defer ClosePoolHandle(poolHandle)
+
+	walletHandle, errCreate := createWallet(testConfig(), testCredentials())
+	if errCreate != nil && !errors.Is(errCreate, indyUtils.ErrWalletAlreadyExists) {
+		t.Errorf("createWallet() error = '%v'", errCreate)
+		return
+	}
+	defer walletCleanup(walletHandle, testConfig(), testCredentials())
+
+	whTrustee, errCreate := createWallet(trusteeConfig(), trusteeCredentials())
+	if errCreate != nil && !errors.Is(errCreate, indyUtils.ErrWalletAlreadyExists) {
+		t.Errorf("createWallet() error = '%v'", errCreate)
+		return
+	}
+	defer walletCleanup(whTrustee, trusteeConfig(), trusteeCredentials())
+
+	trusteeDid, _, errDid := CreateAndStoreDID(whTrustee, seedTrustee1)
+	if errDid != nil {
+		t.Errorf("CreateAndStoreDid() error = '%v'", errDid)
+		return
+	}
+
+	// the endorser lives in the trustee wallet, the author in its own wallet
+	endorserDid, endorserVerKey, _ := CreateAndStoreDID(whTrustee, "")
+	authorDid, authorVerKey, _ := CreateAndStoreDID(walletHandle, "")
+	for _, nym := range []types.Nym{{Did: endorserDid, Verkey: endorserVerKey, Role: "ENDORSER"}, {Did: authorDid, Verkey: authorVerKey}} {
+		nymRequest, _ := BuildNymRequest(trusteeDid, nym.Did, nym.Verkey, "", nym.Role)
+		_, errNym := SignAndSubmitRequestTyped(poolHandle, whTrustee, trusteeDid, mustParseRequest(nymRequest))
+		if errNym != nil {
+			t.Errorf("SignAndSubmitRequest() nym error = '%v'", errNym)
+			return
+		}
+	}
+
+	authorNeedsEndorser, errRole := RequiresEndorser(poolHandle, authorDid)
+	if errRole != nil || !authorNeedsEndorser {
+		t.Errorf("RequiresEndorser() author = '%v', error = '%v'", authorNeedsEndorser, errRole)
+		return
+	}
+	endorserNeedsEndorser, errRole := RequiresEndorser(poolHandle, endorserDid)
+	if errRole != nil || endorserNeedsEndorser {
+		t.Errorf("RequiresEndorser() endorser = '%v', error = '%v'", endorserNeedsEndorser, errRole)
+		return
+	}
+
+	_, schemaJson, errSchema := IssuerCreateSchema(authorDid, "gvt", "1.0", `["name", "age"]`)
+	if errSchema != nil {
+		t.Errorf("IssuerCreateSchema() error = '%v'", errSchema)
+		return
+	}
+	schemaRequest, _ := BuildSchemaRequest(authorDid, schemaJson)
+
+	type args struct {
+		EndorserDid string
+		Policy      types.EndorsementPolicy
+		Tamper      bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{"test-endorse-policy-rejects", args{EndorserDid: endorserDid, Policy: types.EndorsementPolicy{AllowedTxnTypes: []string{types.TxnTypeNym}}}, types.ErrEndorsementRejected},
+		{"test-endorse-other-endorser", args{EndorserDid: trusteeDid}, types.ErrEndorsementRejected},
+		{"test-endorse-tampered-request", args{EndorserDid: endorserDid, Tamper: true}, types.ErrEndorsementRejected},
+		{"test-endorse-works", args{EndorserDid: endorserDid, Policy: types.EndorsementPolicy{AllowedTxnTypes: []string{types.TxnTypeSchema}}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope, errPrepare := PrepareForEndorser(walletHandle, authorDid, endorserDid, schemaRequest)
+			if errPrepare != nil {
+				t.Errorf("PrepareForEndorser() error = '%v'", errPrepare)
+				return
+			}
+			// the envelope travels to the endorser as json
+			envelopeJson, _ := envelope.Json()
+			received, errParse := types.ParseEndorsementEnvelope(envelopeJson)
+			if errParse != nil {
+				t.Errorf("ParseEndorsementEnvelope() error = '%v'", errParse)
+				return
+			}
+			if tt.args.Tamper {
+				// the author signature no longer covers the request
+				var request map[string]interface{}
+				json.Unmarshal(received.Request, &request)
+				request["reqId"] = 1
+				received.Request, _ = json.Marshal(request)
+			}
+
+			reply, errEndorse := EndorseRequest(poolHandle, whTrustee, tt.args.EndorserDid, received, tt.args.Policy)
+			if !errors.Is(errEndorse, tt.wantErr) {
+				t.Errorf("EndorseRequest() error = '%v', want = '%v'", errEndorse, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && reply.Metadata().SeqNo == 0 {
+				t.Errorf("EndorseRequest() reply without seqNo")
+			}
+		})
+	}
+}
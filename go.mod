@@ -8,7 +8,6 @@ require (
 	github.com/jackc/pgconn v1.10.1
 	github.com/orcaman/concurrent-map v0.0.0-20210501183033-44dafcb38ecc
 	github.com/stretchr/testify v1.7.0
-	github.com/viney-shih/go-lock v1.1.1
 	golang.org/x/crypto v0.0.0-20211117183948-ae814b36b871
 	gorm.io/driver/postgres v1.2.3
 	gorm.io/gorm v1.22.4
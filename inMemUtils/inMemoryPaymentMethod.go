@@ -0,0 +1,513 @@
+/*
+// ******************************************************************
+// Purpose: Implements a mock token payment method in memory
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package inMemUtils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"github.com/joyride9999/IndySdkGoBindings/payments"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// operation types of the requests built by the in memory payment method
+const (
+	MockOpMint       = "mock_mint"
+	MockOpPayment    = "mock_payment"
+	MockOpGetSources = "mock_get_sources"
+	MockOpSetFees    = "mock_set_fees"
+	MockOpGetFees    = "mock_get_fees"
+	MockOpVerify     = "mock_verify"
+)
+
+// indy error codes answered by the handlers
+const (
+	codeInvalidStructure = 113
+	codeItemNotFound     = 212
+	codeIncompatible     = 701
+	codeSourceNotFound   = 703
+)
+
+// NewInMemoryPaymentMethod creates a token payment method whose ledger and keys live in memory,
+// addresses look like pay:<name>:<hex ed25519 verkey> and sources like txo:<name>:<seqNo>
+func NewInMemoryPaymentMethod(name string) *InMemoryPaymentMethod {
+	return &InMemoryPaymentMethod{
+		Name:    name,
+		keys:    make(map[string]ed25519.PrivateKey),
+		sources: make(map[string]payments.Source),
+		txns:    make(map[string]payments.VerifyPaymentResult),
+		fees:    make(payments.Fees),
+	}
+}
+
+// InMemoryPaymentMethod mock token payment method, register it with RegisterPaymentMethod.
+// The requests it builds are not sent to a pool, Submit executes them against the in memory ledger
+// and returns the response the parse handlers expect.
+type InMemoryPaymentMethod struct {
+	Name string
+
+	mutex   sync.Mutex
+	keys    map[string]ed25519.PrivateKey           // by payment address
+	sources map[string]payments.Source              // unspent sources by source id
+	txns    map[string]payments.VerifyPaymentResult // by receipt
+	fees    payments.Fees
+	seqNo   int
+}
+
+type mockFees struct {
+	Inputs     []string          `json:"inputs"`
+	Outputs    []payments.Output `json:"outputs"`
+	Extra      string            `json:"extra,omitempty"`
+	Signatures map[string]string `json:"signatures,omitempty"` // hex signature by input
+}
+
+type mockOperation struct {
+	Type       string            `json:"type"`
+	Inputs     []string          `json:"inputs,omitempty"`
+	Outputs    []payments.Output `json:"outputs,omitempty"`
+	Extra      string            `json:"extra,omitempty"`
+	Signatures map[string]string `json:"signatures,omitempty"` // hex signature by input
+	Address    string            `json:"address,omitempty"`
+	From       int64             `json:"from,omitempty"`
+	Fees       payments.Fees     `json:"fees,omitempty"`
+	Receipt    string            `json:"receipt,omitempty"`
+}
+
+type mockRequest struct {
+	Identifier string          `json:"identifier,omitempty"`
+	Operation  json.RawMessage `json:"operation"`
+	Fees       *mockFees       `json:"fees,omitempty"`
+}
+
+type mockResult struct {
+	Receipts []payments.Receipt            `json:"receipts,omitempty"`
+	Sources  []payments.Source             `json:"sources,omitempty"`
+	Next     int64                         `json:"next,omitempty"`
+	Fees     payments.Fees                 `json:"fees,omitempty"`
+	Txn      *payments.VerifyPaymentResult `json:"txn,omitempty"`
+}
+
+type mockResponse struct {
+	Op     string     `json:"op"`
+	Result mockResult `json:"result"`
+}
+
+// Balance sum of the unspent sources of a payment address
+func (m *InMemoryPaymentMethod) Balance(address string) uint64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var balance uint64
+	for _, source := range m.sources {
+		if source.PaymentAddress == address {
+			balance += source.Amount
+		}
+	}
+	return balance
+}
+
+// Submit executes a request built by the payment method, requests of other transaction types only pay their fees
+func (m *InMemoryPaymentMethod) Submit(requestJson string) (string, error) {
+	var request mockRequest
+	errJ := json.Unmarshal([]byte(requestJson), &request)
+	if errJ != nil {
+		return "", errJ
+	}
+	var operation mockOperation
+	errJ = json.Unmarshal(request.Operation, &operation)
+	if errJ != nil {
+		return "", errJ
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var result mockResult
+	var errOp error
+	switch operation.Type {
+	case MockOpMint:
+		result.Receipts = m.spend(nil, operation.Outputs, operation.Extra)
+	case MockOpPayment:
+		errOp = m.checkTransfer(operation.Inputs, operation.Outputs, operation.Signatures, 0)
+		if errOp == nil {
+			result.Receipts = m.spend(operation.Inputs, operation.Outputs, operation.Extra)
+		}
+	case MockOpGetSources:
+		result.Sources, result.Next = m.sourcesOf(operation.Address, operation.From)
+	case MockOpSetFees:
+		for txnType, amount := range operation.Fees {
+			m.fees[txnType] = amount
+		}
+	case MockOpGetFees:
+		result.Fees = make(payments.Fees, len(m.fees))
+		for txnType, amount := range m.fees {
+			result.Fees[txnType] = amount
+		}
+	case MockOpVerify:
+		txn, ok := m.txns[operation.Receipt]
+		if !ok {
+			return "", indyUtils.ErrPaymentSourceNotFound
+		}
+		result.Txn = &txn
+	default:
+		fee := m.fees[operation.Type]
+		if request.Fees == nil {
+			if fee > 0 {
+				return "", indyUtils.ErrPaymentInsufficientFunds
+			}
+			break
+		}
+		errOp = m.checkTransfer(request.Fees.Inputs, request.Fees.Outputs, request.Fees.Signatures, fee)
+		if errOp == nil {
+			result.Receipts = m.spend(request.Fees.Inputs, request.Fees.Outputs, request.Fees.Extra)
+		}
+	}
+	if errOp != nil {
+		return "", errOp
+	}
+
+	response, errJ := json.Marshal(mockResponse{Op: "REPLY", Result: result})
+	if errJ != nil {
+		return "", errJ
+	}
+	return string(response), nil
+}
+
+// checkTransfer checks the inputs exist, are signed by their owner and cover the outputs plus the fee exactly,
+// call with the mutex held
+func (m *InMemoryPaymentMethod) checkTransfer(inputs []string, outputs []payments.Output, signatures map[string]string, fee uint64) error {
+	message := transferMessage(inputs, outputs)
+	var in, out uint64
+	for _, input := range inputs {
+		source, ok := m.sources[input]
+		if !ok {
+			return indyUtils.ErrPaymentSourceNotFound
+		}
+		verkey := m.verkey(source.PaymentAddress)
+		signature, errH := hex.DecodeString(signatures[input])
+		if verkey == nil || errH != nil || !ed25519.Verify(verkey, message, signature) {
+			return fmt.Errorf("input %s is not signed by %s", input, source.PaymentAddress)
+		}
+		in += source.Amount
+	}
+	for _, output := range outputs {
+		out += output.Amount
+	}
+
+	switch {
+	case in < out+fee:
+		return indyUtils.ErrPaymentInsufficientFunds
+	case in > out+fee:
+		return indyUtils.ErrPaymentExtraFunds
+	}
+	return nil
+}
+
+// spend removes the inputs and creates a source per output, call with the mutex held
+func (m *InMemoryPaymentMethod) spend(inputs []string, outputs []payments.Output, extra string) []payments.Receipt {
+	for _, input := range inputs {
+		delete(m.sources, input)
+	}
+
+	txn := payments.VerifyPaymentResult{Sources: inputs, Extra: extra}
+	for _, output := range outputs {
+		m.seqNo++
+		id := fmt.Sprintf("txo:%s:%d", m.Name, m.seqNo)
+		m.sources[id] = payments.Source{Source: id, PaymentAddress: output.Recipient, Amount: output.Amount, Extra: extra}
+		txn.Receipts = append(txn.Receipts, payments.Receipt{Receipt: id, Recipient: output.Recipient, Amount: output.Amount, Extra: extra})
+	}
+	for _, receipt := range txn.Receipts {
+		m.txns[receipt.Receipt] = txn
+	}
+	return txn.Receipts
+}
+
+// sourcesOf unspent sources of an address ordered by seqNo starting at from, call with the mutex held
+func (m *InMemoryPaymentMethod) sourcesOf(address string, from int64) ([]payments.Source, int64) {
+	sources := make([]payments.Source, 0)
+	for _, source := range m.sources {
+		if source.PaymentAddress == address && m.sourceSeqNo(source.Source) >= from {
+			sources = append(sources, source)
+		}
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		return m.sourceSeqNo(sources[i].Source) < m.sourceSeqNo(sources[j].Source)
+	})
+	return sources, -1
+}
+
+func (m *InMemoryPaymentMethod) sourceSeqNo(source string) int64 {
+	seqNo, _ := strconv.ParseInt(strings.TrimPrefix(source, "txo:"+m.Name+":"), 10, 64)
+	return seqNo
+}
+
+func (m *InMemoryPaymentMethod) verkey(address string) ed25519.PublicKey {
+	verkey, errH := hex.DecodeString(strings.TrimPrefix(address, "pay:"+m.Name+":"))
+	if errH != nil || len(verkey) != ed25519.PublicKeySize || !strings.HasPrefix(address, "pay:"+m.Name+":") {
+		return nil
+	}
+	return verkey
+}
+
+// sign signs the transfer with the key of every input owner
+func (m *InMemoryPaymentMethod) sign(inputs []string, outputs []payments.Output) (map[string]string, int, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	message := transferMessage(inputs, outputs)
+	signatures := make(map[string]string, len(inputs))
+	for _, input := range inputs {
+		source, ok := m.sources[input]
+		if !ok {
+			return nil, codeSourceNotFound, indyUtils.ErrPaymentSourceNotFound
+		}
+		key, ok := m.keys[source.PaymentAddress]
+		if !ok {
+			return nil, codeItemNotFound, fmt.Errorf("no key for payment address %s", source.PaymentAddress)
+		}
+		signatures[input] = hex.EncodeToString(ed25519.Sign(key, message))
+	}
+	return signatures, 0, nil
+}
+
+func transferMessage(inputs []string, outputs []payments.Output) []byte {
+	message, _ := json.Marshal(struct {
+		Inputs  []string          `json:"inputs"`
+		Outputs []payments.Output `json:"outputs"`
+	}{inputs, outputs})
+	return message
+}
+
+func (m *InMemoryPaymentMethod) buildRequest(submitterDid string, operation mockOperation) (string, int, error) {
+	rawOperation, errJ := json.Marshal(operation)
+	if errJ != nil {
+		return "", codeInvalidStructure, errJ
+	}
+	request, errJ := json.Marshal(mockRequest{Identifier: submitterDid, Operation: rawOperation})
+	if errJ != nil {
+		return "", codeInvalidStructure, errJ
+	}
+	return string(request), 0, nil
+}
+
+func parseMockResponse(respJson string) (*mockResult, int, error) {
+	var response mockResponse
+	errJ := json.Unmarshal([]byte(respJson), &response)
+	if errJ != nil {
+		return nil, codeInvalidStructure, errJ
+	}
+	if response.Op != "REPLY" {
+		return nil, codeInvalidStructure, errors.New("not a reply: " + response.Op)
+	}
+	return &response.Result, 0, nil
+}
+
+func marshalResult(v interface{}) (string, int, error) {
+	result, errJ := json.Marshal(v)
+	if errJ != nil {
+		return "", codeInvalidStructure, errJ
+	}
+	return string(result), 0, nil
+}
+
+// CreatePaymentAddress creates an ed25519 key, from the 32 bytes seed of the config if any
+func (m *InMemoryPaymentMethod) CreatePaymentAddress(wh int, config string) (string, int, error) {
+	var addressConfig payments.Config
+	if config != "" {
+		errJ := json.Unmarshal([]byte(config), &addressConfig)
+		if errJ != nil {
+			return "", codeInvalidStructure, errJ
+		}
+	}
+
+	var key ed25519.PrivateKey
+	if addressConfig.Seed != "" {
+		if len(addressConfig.Seed) != ed25519.SeedSize {
+			return "", codeInvalidStructure, fmt.Errorf("seed must have %d bytes", ed25519.SeedSize)
+		}
+		key = ed25519.NewKeyFromSeed([]byte(addressConfig.Seed))
+	} else {
+		var errK error
+		_, key, errK = ed25519.GenerateKey(rand.Reader)
+		if errK != nil {
+			return "", 0, errK
+		}
+	}
+
+	address := "pay:" + m.Name + ":" + hex.EncodeToString(key.Public().(ed25519.PublicKey))
+	m.mutex.Lock()
+	m.keys[address] = key
+	m.mutex.Unlock()
+	return address, 0, nil
+}
+
+// AddRequestFees adds the signed fee transfer to the request
+func (m *InMemoryPaymentMethod) AddRequestFees(wh int, submitterDid string, reqJson string, inputsJson string, outputsJson string, extra string) (string, int, error) {
+	var request map[string]json.RawMessage
+	fees := mockFees{Extra: extra}
+	errJ := json.Unmarshal([]byte(reqJson), &request)
+	if errJ == nil {
+		errJ = json.Unmarshal([]byte(inputsJson), &fees.Inputs)
+	}
+	if errJ == nil {
+		errJ = json.Unmarshal([]byte(outputsJson), &fees.Outputs)
+	}
+	if errJ != nil {
+		return "", codeInvalidStructure, errJ
+	}
+
+	signatures, indyCode, errS := m.sign(fees.Inputs, fees.Outputs)
+	if errS != nil {
+		return "", indyCode, errS
+	}
+	fees.Signatures = signatures
+
+	rawFees, errJ := json.Marshal(fees)
+	if errJ != nil {
+		return "", codeInvalidStructure, errJ
+	}
+	request["fees"] = rawFees
+	return marshalResult(request)
+}
+
+// ParseResponseWithFees returns the receipts of the fee transfer
+func (m *InMemoryPaymentMethod) ParseResponseWithFees(respJson string) (string, int, error) {
+	result, indyCode, errP := parseMockResponse(respJson)
+	if errP != nil {
+		return "", indyCode, errP
+	}
+	return marshalResult(append([]payments.Receipt{}, result.Receipts...))
+}
+
+// BuildGetPaymentSourcesRequest builds a mock_get_sources request
+func (m *InMemoryPaymentMethod) BuildGetPaymentSourcesRequest(wh int, submitterDid string, paymentAddress string, from int64) (string, int, error) {
+	if m.verkey(paymentAddress) == nil {
+		return "", codeIncompatible, fmt.Errorf("%s is not a %s payment address", paymentAddress, m.Name)
+	}
+	return m.buildRequest(submitterDid, mockOperation{Type: MockOpGetSources, Address: paymentAddress, From: from})
+}
+
+// ParseGetPaymentSourcesResponse returns the sources of the response, next is always -1
+func (m *InMemoryPaymentMethod) ParseGetPaymentSourcesResponse(respJson string) (string, int64, int, error) {
+	result, indyCode, errP := parseMockResponse(respJson)
+	if errP != nil {
+		return "", 0, indyCode, errP
+	}
+	sources, indyCode, errJ := marshalResult(append([]payments.Source{}, result.Sources...))
+	return sources, -1, indyCode, errJ
+}
+
+// BuildPaymentReq builds a mock_payment request signed by the owners of the inputs
+func (m *InMemoryPaymentMethod) BuildPaymentReq(wh int, submitterDid string, inputsJson string, outputsJson string, extra string) (string, int, error) {
+	operation := mockOperation{Type: MockOpPayment, Extra: extra}
+	errJ := json.Unmarshal([]byte(inputsJson), &operation.Inputs)
+	if errJ == nil {
+		errJ = json.Unmarshal([]byte(outputsJson), &operation.Outputs)
+	}
+	if errJ != nil {
+		return "", codeInvalidStructure, errJ
+	}
+
+	signatures, indyCode, errS := m.sign(operation.Inputs, operation.Outputs)
+	if errS != nil {
+		return "", indyCode, errS
+	}
+	operation.Signatures = signatures
+	return m.buildRequest(submitterDid, operation)
+}
+
+// ParsePaymentResponse returns the receipts of the payment
+func (m *InMemoryPaymentMethod) ParsePaymentResponse(respJson string) (string, int, error) {
+	return m.ParseResponseWithFees(respJson)
+}
+
+// BuildMintReq builds a mock_mint request
+func (m *InMemoryPaymentMethod) BuildMintReq(wh int, submitterDid string, outputsJson string, extra string) (string, int, error) {
+	operation := mockOperation{Type: MockOpMint, Extra: extra}
+	errJ := json.Unmarshal([]byte(outputsJson), &operation.Outputs)
+	if errJ != nil {
+		return "", codeInvalidStructure, errJ
+	}
+	return m.buildRequest(submitterDid, operation)
+}
+
+// BuildSetTxnFeesReq builds a mock_set_fees request
+func (m *InMemoryPaymentMethod) BuildSetTxnFeesReq(wh int, submitterDid string, feesJson string) (string, int, error) {
+	operation := mockOperation{Type: MockOpSetFees}
+	errJ := json.Unmarshal([]byte(feesJson), &operation.Fees)
+	if errJ != nil {
+		return "", codeInvalidStructure, errJ
+	}
+	return m.buildRequest(submitterDid, operation)
+}
+
+// BuildGetTxnFeesReq builds a mock_get_fees request
+func (m *InMemoryPaymentMethod) BuildGetTxnFeesReq(wh int, submitterDid string) (string, int, error) {
+	return m.buildRequest(submitterDid, mockOperation{Type: MockOpGetFees})
+}
+
+// ParseGetTxnFeesResponse returns the fees of the response
+func (m *InMemoryPaymentMethod) ParseGetTxnFeesResponse(respJson string) (string, int, error) {
+	result, indyCode, errP := parseMockResponse(respJson)
+	if errP != nil {
+		return "", indyCode, errP
+	}
+	fees := result.Fees
+	if fees == nil {
+		fees = payments.Fees{}
+	}
+	return marshalResult(fees)
+}
+
+// BuildVerifyPaymentReq builds a mock_verify request
+func (m *InMemoryPaymentMethod) BuildVerifyPaymentReq(wh int, submitterDid string, receipt string) (string, int, error) {
+	return m.buildRequest(submitterDid, mockOperation{Type: MockOpVerify, Receipt: receipt})
+}
+
+// ParseVerifyPaymentResponse returns the transaction of the verified receipt
+func (m *InMemoryPaymentMethod) ParseVerifyPaymentResponse(respJson string) (string, int, error) {
+	result, indyCode, errP := parseMockResponse(respJson)
+	if errP != nil {
+		return "", indyCode, errP
+	}
+	if result.Txn == nil {
+		return "", codeSourceNotFound, indyUtils.ErrPaymentSourceNotFound
+	}
+	return marshalResult(result.Txn)
+}
+
+// SignWithAddress signs with the key of the payment address
+func (m *InMemoryPaymentMethod) SignWithAddress(wh int, address string, message []byte) ([]byte, int, error) {
+	m.mutex.Lock()
+	key, ok := m.keys[address]
+	m.mutex.Unlock()
+	if !ok {
+		return nil, codeItemNotFound, fmt.Errorf("no key for payment address %s", address)
+	}
+	return ed25519.Sign(key, message), 0, nil
+}
+
+// VerifyWithAddress verifies with the verkey of the payment address
+func (m *InMemoryPaymentMethod) VerifyWithAddress(address string, message []byte, signature []byte) (bool, int, error) {
+	verkey := m.verkey(address)
+	if verkey == nil {
+		return false, codeIncompatible, fmt.Errorf("%s is not a %s payment address", address, m.Name)
+	}
+	return ed25519.Verify(verkey, message, signature), 0, nil
+}
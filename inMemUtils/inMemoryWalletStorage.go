@@ -20,6 +20,7 @@ import (
 	"github.com/Jeffail/gabs/v2"
 	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
 	"github.com/joyride9999/IndySdkGoBindings/wallet"
+	"github.com/joyride9999/IndySdkGoBindings/wql"
 	cmap "github.com/orcaman/concurrent-map"
 	"strconv"
 	"unsafe"
@@ -48,6 +49,7 @@ func NewInMemoryStorage() *InMemoryStorage {
 	customStorage.SearchHandlesValue = cmap.New()
 	customStorage.SearchHandlesType = cmap.New()
 	customStorage.SearchHandlesTags = cmap.New()
+	customStorage.SearchHandlesOptions = cmap.New()
 
 	return customStorage
 }
@@ -64,6 +66,7 @@ type InMemoryStorage struct {
 	SearchHandlesType     cmap.ConcurrentMap
 	SearchHandlesTags     cmap.ConcurrentMap
 	SearchHandlesIterator cmap.ConcurrentMap
+	SearchHandlesOptions  cmap.ConcurrentMap // Search options of the open searches
 	SearchHandleCounter   indyUtils.Counter
 }
 
@@ -183,6 +186,11 @@ func (e *InMemoryStorage) GetRecordHandle(storageHandle int, recordType string,
 	var record StorageRecord
 	count := 0
 
+	options, errO := wallet.ParseRecordOptions(optionsJson)
+	if errO != nil {
+		return 0, 113, indyUtils.NewIndyError(113) // CommonInvalidStructure
+	}
+
 	for i := range e.StoredRecords {
 		if e.StoredRecords[i].Id == recordId && e.StoredRecords[i].Type == recordType {
 			count++
@@ -192,22 +200,35 @@ func (e *InMemoryStorage) GetRecordHandle(storageHandle int, recordType string,
 	}
 
 	if count == 0 {
-		return 0, 212, errors.New(indyUtils.GetIndyError(212))
+		return 0, 212, indyUtils.NewIndyError(212)
 	}
 
 	sh, shKey := e.SearchHandleCounter.Get()
-	e.SearchHandles.Set(shKey, record)
-	e.SearchHandlesName.Set(shKey, C.CString(record.Id))
-	e.SearchHandlesValue.Set(shKey, wallet.RecordValue{
-		Len:   len(record.Value),
-		Value: C.CBytes(record.Value),
-	})
-	e.SearchHandlesType.Set(shKey, C.CString(record.Type))
-	e.SearchHandlesTags.Set(shKey, C.CString(record.Tags))
+	e.setRecordHandle(shKey, record, options.RetrieveValue, options.RetrieveTags)
 
 	return int(sh), 0, nil
 }
 
+// setRecordHandle keeps the fetched record for the GetRecord* callbacks until FreeRecord
+func (e *InMemoryStorage) setRecordHandle(handleKey string, record StorageRecord, withValue bool, withTags bool) {
+	e.SearchHandles.Set(handleKey, record)
+	e.SearchHandlesName.Set(handleKey, C.CString(record.Id))
+	if withValue {
+		e.SearchHandlesValue.Set(handleKey, wallet.RecordValue{
+			Len:   len(record.Value),
+			Value: C.CBytes(record.Value),
+		})
+	}
+	e.SearchHandlesType.Set(handleKey, C.CString(record.Type))
+	if withTags {
+		tags := record.Tags
+		if len(tags) == 0 {
+			tags = "{}"
+		}
+		e.SearchHandlesTags.Set(handleKey, C.CString(tags))
+	}
+}
+
 func (e *InMemoryStorage) GetRecordId(storageHandle int, recordHandle int) (unsafe.Pointer, int, error) {
 	searchHandleKey := strconv.Itoa(recordHandle)
 	tmp, ok := e.SearchHandlesName.Get(searchHandleKey)
@@ -217,7 +238,7 @@ func (e *InMemoryStorage) GetRecordId(storageHandle int, recordHandle int) (unsa
 
 	item, okCast := tmp.(*C.char)
 	if !okCast {
-		return nil, 208, errors.New(indyUtils.GetIndyError(208)) //WalletInputError: Input provided to wallet operations is considered not valid
+		return nil, 208, indyUtils.NewIndyError(208) //WalletInputError: Input provided to wallet operations is considered not valid
 	}
 
 	return unsafe.Pointer(item), 0, nil
@@ -232,7 +253,7 @@ func (e *InMemoryStorage) GetRecordType(storageHandle int, recordHandle int) (un
 
 	item, okCast := tmp.(*C.char)
 	if !okCast {
-		return nil, 208, errors.New(indyUtils.GetIndyError(208)) //WalletInputError: Input provided to wallet operations is considered not valid
+		return nil, 208, indyUtils.NewIndyError(208) //WalletInputError: Input provided to wallet operations is considered not valid
 	}
 
 	return unsafe.Pointer(item), 0, nil
@@ -259,7 +280,7 @@ func (e *InMemoryStorage) GetRecordTags(storageHandle int, recordHandle int) (un
 
 	item, okCast := tmp.(*C.char)
 	if !okCast {
-		return nil, 208, errors.New(indyUtils.GetIndyError(208)) //WalletInputError: Input provided to wallet operations is considered not valid
+		return nil, 208, indyUtils.NewIndyError(208) //WalletInputError: Input provided to wallet operations is considered not valid
 	}
 
 	return unsafe.Pointer(item), 0, nil
@@ -292,11 +313,10 @@ func (e *InMemoryStorage) FreeRecord(storageHandle int, recordHandle int) error
 
 	pTags, okTags := e.SearchHandlesTags.Get(searchHandleKey)
 	if okTags {
-		upTags, okCast := pTags.(unsafe.Pointer)
+		upTags, okCast := pTags.(*C.char)
 		if okCast {
-			C.free(upTags)
+			C.free(unsafe.Pointer(upTags))
 		}
-
 	}
 	e.SearchHandlesTags.Remove(searchHandleKey)
 
@@ -307,12 +327,12 @@ func (e *InMemoryStorage) GetStorageMetadata(storageHandle int) (unsafe.Pointer,
 	handleKey := strconv.Itoa(storageHandle)
 	tmp, ok := e.MetadataHandles.Get(handleKey)
 	if !ok {
-		return nil, 0, 212, errors.New(indyUtils.GetIndyError(212)) //"WalletItemNotFound: Requested wallet item not found"
+		return nil, 0, 212, indyUtils.NewIndyError(212) //"WalletItemNotFound: Requested wallet item not found"
 	}
 
 	metadata, okM := tmp.(Metadata)
 	if !okM {
-		return nil, 0, 210, errors.New(indyUtils.GetIndyError(210)) //"WalletStorageError: Storage error occurred during wallet operation"
+		return nil, 0, 210, indyUtils.NewIndyError(210) //"WalletStorageError: Storage error occurred during wallet operation"
 	}
 	metadataValue := C.CString(metadata.Value)
 
@@ -323,12 +343,12 @@ func (e *InMemoryStorage) SetStorageMetadata(storageHandle int, metadata string)
 	handleKey := strconv.Itoa(storageHandle)
 	tmp, ok := e.MetadataHandles.Get(handleKey)
 	if !ok {
-		return 212, errors.New(indyUtils.GetIndyError(212)) //"WalletItemNotFound: Requested wallet item not found"
+		return 212, indyUtils.NewIndyError(212) //"WalletItemNotFound: Requested wallet item not found"
 	}
 
 	newMeta, okM := tmp.(Metadata)
 	if !okM {
-		return 210, errors.New(indyUtils.GetIndyError(210)) //"WalletStorageError: Storage error occurred during wallet operation"
+		return 210, indyUtils.NewIndyError(210) //"WalletStorageError: Storage error occurred during wallet operation"
 	}
 
 	newMeta.Value = metadata
@@ -351,49 +371,55 @@ func (e *InMemoryStorage) FreeStorageMetadata(storageHandle int, metadataHandle
 
 func (e *InMemoryStorage) OpenSearch(storageHandle int, recordType string, queryJson string, optionsJson string) (int, int, error) {
 	var searchedRecords []StorageRecord
-	notFound := true
 
-	wqlQuery, errGabs := gabs.ParseJSON([]byte(queryJson))
-	if errGabs != nil {
-		return 0, 113, errors.New(indyUtils.GetIndyError(113))
+	options, errO := wallet.ParseSearchOptions(optionsJson)
+	if errO != nil {
+		return 0, 113, indyUtils.NewIndyError(113) // CommonInvalidStructure
 	}
 
-	for index := 0; index < len(e.StoredRecords); index++ {
-		if e.StoredRecords[index].Type == recordType {
-			if wqlQuery != nil {
-				tagsParsed, _ := gabs.ParseJSON([]byte(e.StoredRecords[index].Tags))
-				if IsIncluded(wqlQuery, tagsParsed) {
-					searchedRecords = append(searchedRecords, e.StoredRecords[index])
-					notFound = false
-				}
-			} else {
-				searchedRecords = append(searchedRecords, e.StoredRecords[index])
-				notFound = false
-			}
+	query, errQuery := wql.Parse(queryJson)
+	if errQuery != nil {
+		if errors.Is(errQuery, wql.ErrInvalidJson) {
+			return 0, 113, indyUtils.NewIndyError(113)
 		}
+		return 0, 214, indyUtils.NewIndyError(214) // WalletQueryError: Returned if provided wallet query is invalid
 	}
 
-	if notFound == true {
-		return 0, 208, errors.New(indyUtils.GetIndyError(208))
-	} else {
-		sh, shKey := e.SearchHandleCounter.Get()
-		e.SearchHandles.Set(shKey, searchedRecords)
-		e.SearchHandlesIterator.Set(shKey, 0)
+	for index := 0; index < len(e.StoredRecords); index++ {
+		if e.StoredRecords[index].Type != recordType {
+			continue
+		}
+
+		tags, errTags := ParseTags(e.StoredRecords[index].Tags)
+		if errTags != nil {
+			return 0, 210, indyUtils.NewIndyError(210) // WalletStorageError: Storage error occurred during wallet operation
+		}
 
-		return int(sh), 0, nil
+		match, errMatch := wql.Match(query, tags)
+		if errMatch != nil {
+			return 0, 214, indyUtils.NewIndyError(214) // WalletQueryError: Returned if provided wallet query is invalid
+		}
+		if match {
+			searchedRecords = append(searchedRecords, e.StoredRecords[index])
+		}
 	}
+
+	sh, shKey := e.SearchHandleCounter.Get()
+	e.SearchHandles.Set(shKey, searchedRecords)
+	e.SearchHandlesIterator.Set(shKey, 0)
+	e.SearchHandlesOptions.Set(shKey, options)
+
+	return int(sh), 0, nil
 }
 
 func (e *InMemoryStorage) OpenSearchAll(storageHandle int) (int, int, error) {
-	var searchedRecords []string
-
-	for index := 0; index < len(e.StoredRecords); index++ {
-		searchedRecords = append(searchedRecords, e.StoredRecords[index].Id)
-	}
+	searchedRecords := make([]StorageRecord, len(e.StoredRecords))
+	copy(searchedRecords, e.StoredRecords)
 
 	sh, shKey := e.SearchHandleCounter.Get()
 	e.SearchHandles.Set(shKey, searchedRecords)
 	e.SearchHandlesIterator.Set(shKey, 0)
+	e.SearchHandlesOptions.Set(shKey, wallet.SearchOptions{RetrieveRecords: true, RetrieveType: true, RetrieveValue: true, RetrieveTags: true})
 
 	return int(sh), 0, nil
 }
@@ -402,7 +428,7 @@ func (e *InMemoryStorage) GetSearchTotalCount(storageHandle int, searchHandle in
 	searchHandleKey := strconv.Itoa(searchHandle)
 	items, ok := e.SearchHandles.Get(searchHandleKey)
 	if !ok {
-		return 0, 210, errors.New(indyUtils.GetIndyError(210)) //"WalletStorageError: Storage error occurred during wallet operation"
+		return 0, 210, indyUtils.NewIndyError(210) //"WalletStorageError: Storage error occurred during wallet operation"
 	}
 
 	records := items.([]StorageRecord)
@@ -413,34 +439,34 @@ func (e *InMemoryStorage) FetchSearchNext(storageHandle int, searchHandle int) (
 	searchHandleKey := strconv.Itoa(searchHandle)
 	tmp, ok := e.SearchHandles.Get(searchHandleKey)
 	if !ok {
-		return 0, 208, errors.New(indyUtils.GetIndyError(208)) //WalletInputError: Input provided to wallet operations is considered not valid
+		return 0, 208, indyUtils.NewIndyError(208) //WalletInputError: Input provided to wallet operations is considered not valid
 	}
 
 	records, okCast := tmp.([]StorageRecord)
 	if !okCast {
-		return 0, 208, errors.New(indyUtils.GetIndyError(208)) //WalletInputError: Input provided to wallet operations is considered not valid
+		return 0, 208, indyUtils.NewIndyError(208) //WalletInputError: Input provided to wallet operations is considered not valid
 	}
 
 	tmp1, okI := e.SearchHandlesIterator.Get(searchHandleKey)
 	if !okI {
-		return 0, 208, errors.New(indyUtils.GetIndyError(208)) //WalletInputError: Input provided to wallet operations is considered not valid
+		return 0, 208, indyUtils.NewIndyError(208) //WalletInputError: Input provided to wallet operations is considered not valid
 	}
 	counter := tmp1.(int)
 
-	if counter >= len(records) {
-		return 0, 212, errors.New(indyUtils.GetIndyError(212))
+	tmp2, okO := e.SearchHandlesOptions.Get(searchHandleKey)
+	if !okO {
+		return 0, 208, indyUtils.NewIndyError(208) //WalletInputError: Input provided to wallet operations is considered not valid
+	}
+	options := tmp2.(wallet.SearchOptions)
+
+	if !options.RetrieveRecords || counter >= len(records) {
+		return 0, 212, indyUtils.NewIndyError(212)
 	}
 
 	e.SearchHandlesIterator.Set(searchHandleKey, counter+1)
 	item := records[counter]
 	handleId, handleKey := e.SearchHandleCounter.Get()
-	e.SearchHandles.Set(handleKey, item)
-	e.SearchHandlesName.Set(handleKey, C.CString(item.Id))
-	e.SearchHandlesValue.Set(handleKey, wallet.RecordValue{
-		Len:   len(item.Value),
-		Value: C.CBytes(item.Value),
-	})
-	e.SearchHandlesType.Set(handleKey, C.CString(item.Type))
+	e.setRecordHandle(handleKey, item, options.RetrieveValue, options.RetrieveTags)
 
 	return int(handleId), 0, nil
 }
@@ -449,6 +475,7 @@ func (e *InMemoryStorage) FreeSearch(storageHandle int, searchHandle int) error
 	searchHandleKey := strconv.Itoa(searchHandle)
 	e.SearchHandles.Remove(searchHandleKey)
 	e.SearchHandlesIterator.Remove(searchHandleKey)
+	e.SearchHandlesOptions.Remove(searchHandleKey)
 
 	return nil
 }
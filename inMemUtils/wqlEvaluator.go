@@ -0,0 +1,40 @@
+/*
+// ******************************************************************
+// Purpose: Evaluates WQL queries against in memory wallet records
+// Author:  angel.draghici@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package inMemUtils
+
+import (
+	"encoding/json"
+	"github.com/joyride9999/IndySdkGoBindings/wql"
+)
+
+// ParseTags parses the tags json of a stored record
+func ParseTags(tagsJson string) (map[string]string, error) {
+	tags := make(map[string]string)
+	if len(tagsJson) == 0 {
+		return tags, nil
+	}
+	err := json.Unmarshal([]byte(tagsJson), &tags)
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// MatchWql reports whether the tags satisfy the WQL query, see wql.Match for the semantics
+func MatchWql(queryJson string, tags map[string]string) (bool, error) {
+	query, err := wql.Parse(queryJson)
+	if err != nil {
+		return false, err
+	}
+
+	return wql.Match(query, tags)
+}
@@ -8,6 +8,159 @@
 extern "C" {
 #endif
 
+    /// Register custom payment implementation.
+    ///
+    /// It allows library user to provide custom payment method implementation as set of handlers.
+    ///
+    /// #Params
+    /// command_handle: Command handle to map callback to caller context.
+    /// payment_method: The type of payment method also used as sub-prefix for fully resolvable payment address format ("sov" - for example)
+    /// create_payment_address: "create_payment_address" operation handler
+    /// add_request_fees: "add_request_fees" operation handler
+    /// parse_response_with_fees: "parse_response_with_fees" operation handler
+    /// build_get_payment_sources_request: "build_get_payment_sources_request" operation handler
+    /// parse_get_payment_sources_response: "parse_get_payment_sources_response" operation handler
+    /// build_payment_req: "build_payment_req" operation handler
+    /// parse_payment_response: "parse_payment_response" operation handler
+    /// build_mint_req: "build_mint_req" operation handler
+    /// build_set_txn_fees_req: "build_set_txn_fees_req" operation handler
+    /// build_get_txn_fees_req: "build_get_txn_fees_req" operation handler
+    /// parse_get_txn_fees_response: "parse_get_txn_fees_response" operation handler
+    /// build_verify_payment_req: "build_verify_payment_req" operation handler
+    /// parse_verify_payment_response: "parse_verify_payment_response" operation handler
+    /// sign_with_address: "sign_with_address" operation handler
+    /// verify_with_address: "verify_with_address" operation handler
+    ///
+    /// #Returns
+    /// Error code
+
+    extern indy_error_t indy_register_payment_method(indy_handle_t command_handle,
+                                                     const char*   payment_method,
+
+                                                     indy_error_t (*create_payment_address)(indy_handle_t command_handle,
+                                                                                            indy_handle_t wallet_handle,
+                                                                                            const char*   config,
+                                                                                            indy_error_t (*cb)(indy_handle_t command_handle_,
+                                                                                                               indy_error_t  err,
+                                                                                                               const char*   payment_address)),
+
+                                                     indy_error_t (*add_request_fees)(indy_handle_t command_handle,
+                                                                                      indy_handle_t wallet_handle,
+                                                                                      const char*   submitter_did,
+                                                                                      const char*   req_json,
+                                                                                      const char*   inputs_json,
+                                                                                      const char*   outputs_json,
+                                                                                      const char*   extra,
+                                                                                      indy_error_t (*cb)(indy_handle_t command_handle_,
+                                                                                                         indy_error_t  err,
+                                                                                                         const char*   req_with_fees_json)),
+
+                                                     indy_error_t (*parse_response_with_fees)(indy_handle_t command_handle,
+                                                                                              const char*   resp_json,
+                                                                                              indy_error_t (*cb)(indy_handle_t command_handle_,
+                                                                                                                 indy_error_t  err,
+                                                                                                                 const char*   receipts_json)),
+
+                                                     indy_error_t (*build_get_payment_sources_request)(indy_handle_t command_handle,
+                                                                                                       indy_handle_t wallet_handle,
+                                                                                                       const char*   submitter_did,
+                                                                                                       const char*   payment_address,
+                                                                                                       indy_i64_t    from,
+                                                                                                       indy_error_t (*cb)(indy_handle_t command_handle_,
+                                                                                                                          indy_error_t  err,
+                                                                                                                          const char*   get_sources_txn_json)),
+
+                                                     indy_error_t (*parse_get_payment_sources_response)(indy_handle_t command_handle,
+                                                                                                        const char*   resp_json,
+                                                                                                        indy_error_t (*cb)(indy_handle_t command_handle_,
+                                                                                                                           indy_error_t  err,
+                                                                                                                           const char*   sources_json,
+                                                                                                                           indy_i64_t    next)),
+
+                                                     indy_error_t (*build_payment_req)(indy_handle_t command_handle,
+                                                                                       indy_handle_t wallet_handle,
+                                                                                       const char*   submitter_did,
+                                                                                       const char*   inputs_json,
+                                                                                       const char*   outputs_json,
+                                                                                       const char*   extra,
+                                                                                       indy_error_t (*cb)(indy_handle_t command_handle_,
+                                                                                                          indy_error_t  err,
+                                                                                                          const char*   payment_req_json)),
+
+                                                     indy_error_t (*parse_payment_response)(indy_handle_t command_handle,
+                                                                                            const char*   resp_json,
+                                                                                            indy_error_t (*cb)(indy_handle_t command_handle_,
+                                                                                                               indy_error_t  err,
+                                                                                                               const char*   receipts_json)),
+
+                                                     indy_error_t (*build_mint_req)(indy_handle_t command_handle,
+                                                                                    indy_handle_t wallet_handle,
+                                                                                    const char*   submitter_did,
+                                                                                    const char*   outputs_json,
+                                                                                    const char*   extra,
+                                                                                    indy_error_t (*cb)(indy_handle_t command_handle_,
+                                                                                                       indy_error_t  err,
+                                                                                                       const char*   mint_req_json)),
+
+                                                     indy_error_t (*build_set_txn_fees_req)(indy_handle_t command_handle,
+                                                                                            indy_handle_t wallet_handle,
+                                                                                            const char*   submitter_did,
+                                                                                            const char*   fees_json,
+                                                                                            indy_error_t (*cb)(indy_handle_t command_handle_,
+                                                                                                               indy_error_t  err,
+                                                                                                               const char*   set_txn_fees_json)),
+
+                                                     indy_error_t (*build_get_txn_fees_req)(indy_handle_t command_handle,
+                                                                                            indy_handle_t wallet_handle,
+                                                                                            const char*   submitter_did,
+                                                                                            indy_error_t (*cb)(indy_handle_t command_handle_,
+                                                                                                               indy_error_t  err,
+                                                                                                               const char*   get_txn_fees_json)),
+
+                                                     indy_error_t (*parse_get_txn_fees_response)(indy_handle_t command_handle,
+                                                                                                 const char*   resp_json,
+                                                                                                 indy_error_t (*cb)(indy_handle_t command_handle_,
+                                                                                                                    indy_error_t  err,
+                                                                                                                    const char*   fees_json)),
+
+                                                     indy_error_t (*build_verify_payment_req)(indy_handle_t command_handle,
+                                                                                              indy_handle_t wallet_handle,
+                                                                                              const char*   submitter_did,
+                                                                                              const char*   receipt,
+                                                                                              indy_error_t (*cb)(indy_handle_t command_handle_,
+                                                                                                                 indy_error_t  err,
+                                                                                                                 const char*   verify_txn_json)),
+
+                                                     indy_error_t (*parse_verify_payment_response)(indy_handle_t command_handle,
+                                                                                                   const char*   resp_json,
+                                                                                                   indy_error_t (*cb)(indy_handle_t command_handle_,
+                                                                                                                      indy_error_t  err,
+                                                                                                                      const char*   txn_json)),
+
+                                                     indy_error_t (*sign_with_address)(indy_handle_t     command_handle,
+                                                                                       indy_handle_t     wallet_handle,
+                                                                                       const char*       address,
+                                                                                       const indy_u8_t*  message_raw,
+                                                                                       indy_u32_t        message_len,
+                                                                                       indy_error_t (*cb)(indy_handle_t     command_handle_,
+                                                                                                          indy_error_t      err,
+                                                                                                          const indy_u8_t*  signature_raw,
+                                                                                                          indy_u32_t        signature_len)),
+
+                                                     indy_error_t (*verify_with_address)(indy_handle_t     command_handle,
+                                                                                         const char*       address,
+                                                                                         const indy_u8_t*  message_raw,
+                                                                                         indy_u32_t        message_len,
+                                                                                         const indy_u8_t*  signature_raw,
+                                                                                         indy_u32_t        signature_len,
+                                                                                         indy_error_t (*cb)(indy_handle_t command_handle_,
+                                                                                                            indy_error_t  err,
+                                                                                                            indy_bool_t   result)),
+
+                                                     void (*cb)(indy_handle_t command_handle_,
+                                                                indy_error_t  err)
+                                                     );
+
     /// Create the payment address for specified payment method
     ///
     ///
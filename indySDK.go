@@ -14,12 +14,13 @@ package indySDK
 
 import "C"
 import (
-	"github.com/joyride9999/IndySdkGoBindings/blobstorage"
+	"context"
 	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/Jeffail/gabs/v2"
+	"github.com/joyride9999/IndySdkGoBindings/blobstorage"
+	"github.com/joyride9999/IndySdkGoBindings/ledger/types"
 	"io"
 	"math"
 	"math/big"
@@ -34,35 +35,40 @@ func jsonObjectToString(obj interface{}) string {
 
 // GetRevRegDef - gets rev reg defs
 func GetRevRegDef(poolHandle int, verifierDid string, revRegId string, timeStamp int64) (revRegDefJson string, revRegJson string, ts uint64, err error) {
-	getRevocRegDefRequest, errGetRevRegDefReq := BuildGetRevRegDefRequest(verifierDid, revRegId)
+	return GetRevRegDefCtx(context.Background(), poolHandle, verifierDid, revRegId, timeStamp)
+}
+
+// GetRevRegDefCtx is like GetRevRegDef but returns ctx.Err() if ctx is done before libindy answers.
+func GetRevRegDefCtx(ctx context.Context, poolHandle int, verifierDid string, revRegId string, timeStamp int64) (revRegDefJson string, revRegJson string, ts uint64, err error) {
+	getRevocRegDefRequest, errGetRevRegDefReq := BuildGetRevRegDefRequestCtx(ctx, verifierDid, revRegId)
 	if errGetRevRegDefReq != nil {
 		return "", "", 0, errGetRevRegDefReq
 	}
 
-	getRevocRegDefResponse, errGetRevocRegDefResponse := SubmitRequest(poolHandle, getRevocRegDefRequest)
+	getRevocRegDefResponse, errGetRevocRegDefResponse := SubmitRequestCtx(ctx, poolHandle, getRevocRegDefRequest)
 	if errGetRevocRegDefResponse != nil {
 		return "", "", 0, errGetRevocRegDefResponse
 	}
 
-	rvRegId, revRegDefJson, errParse := ParseGetRevocRegDefResponse(getRevocRegDefResponse)
+	rvRegId, revRegDefJson, errParse := ParseGetRevocRegDefResponseCtx(ctx, getRevocRegDefResponse)
 	if errParse != nil {
 		return "", "", 0, errParse
 	}
 	rvRegId = rvRegId
 
 	if timeStamp > 0 {
-		getRevocRegRequest, errGetRevRegReq := BuildGetRevocRegRequest(verifierDid, revRegId, timeStamp)
+		getRevocRegRequest, errGetRevRegReq := BuildGetRevocRegRequestCtx(ctx, verifierDid, revRegId, timeStamp)
 
 		if errGetRevRegReq != nil {
 			return "", "", 0, errGetRevRegReq
 		}
 
-		getRevocRegResponse, errGetRevocRegResponse := SubmitRequest(poolHandle, getRevocRegRequest)
+		getRevocRegResponse, errGetRevocRegResponse := SubmitRequestCtx(ctx, poolHandle, getRevocRegRequest)
 		if errGetRevocRegResponse != nil {
 			return "", "", 0, errGetRevocRegResponse
 		}
 
-		_, revRegJsonResp, timeStamp2, errParseRevRegResp := ParseGetRevocRegResponse(getRevocRegResponse)
+		_, revRegJsonResp, timeStamp2, errParseRevRegResp := ParseGetRevocRegResponseCtx(ctx, getRevocRegResponse)
 		if errParseRevRegResp != nil {
 			return "", "", 0, errParseRevRegResp
 		}
@@ -77,43 +83,115 @@ func GetRevRegDef(poolHandle int, verifierDid string, revRegId string, timeStamp
 
 // GetRevState  gets rev states
 func GetRevState(poolHandle int, subjectDid string, revRegId string, credRevId string, from, to int64) (string, uint64, error) {
-	getRevocRegDefRequest, errGetRevRegDefReq := BuildGetRevRegDefRequest(subjectDid, revRegId)
+	return GetRevStateCtx(context.Background(), poolHandle, subjectDid, revRegId, credRevId, from, to)
+}
+
+// GetRevStateCtx is like GetRevState but returns ctx.Err() if ctx is done before libindy answers.
+func GetRevStateCtx(ctx context.Context, poolHandle int, subjectDid string, revRegId string, credRevId string, from, to int64) (string, uint64, error) {
+	revRegDefJson, revRegDeltaJson, timeStamp, errDelta := getRevRegDefAndDeltaCtx(ctx, poolHandle, subjectDid, revRegId, from, to)
+	if errDelta != nil {
+		return "", 0, errDelta
+	}
+
+	blobReaderHandle, errBlobHandle := openTailsReaderCtx(ctx, revRegDefJson)
+	if errBlobHandle != nil {
+		return "", 0, errBlobHandle
+	}
+
+	revStateJson, errRevState := CreateRevocationStateCtx(ctx, blobReaderHandle, revRegDefJson, revRegDeltaJson, timeStamp, credRevId)
+	if errRevState != nil {
+		return "", 0, errRevState
+	}
+
+	return revStateJson, timeStamp, nil
+}
+
+// UpdateRevState incremental GetRevState, brings a revocation state returned by GetRevState or UpdateRevState
+// up to date with only the delta since the state timestamp. An empty revStateJson builds the state from scratch.
+func UpdateRevState(poolHandle int, subjectDid string, revRegId string, credRevId string, revStateJson string, to int64) (string, uint64, error) {
+	return UpdateRevStateCtx(context.Background(), poolHandle, subjectDid, revRegId, credRevId, revStateJson, to)
+}
+
+// UpdateRevStateCtx is like UpdateRevState but returns ctx.Err() if ctx is done before libindy answers.
+func UpdateRevStateCtx(ctx context.Context, poolHandle int, subjectDid string, revRegId string, credRevId string, revStateJson string, to int64) (string, uint64, error) {
+	if revStateJson == "" {
+		return GetRevStateCtx(ctx, poolHandle, subjectDid, revRegId, credRevId, 0, to)
+	}
+
+	var revState struct {
+		Timestamp uint64 `json:"timestamp"`
+	}
+	errParseJson := json.Unmarshal([]byte(revStateJson), &revState)
+	if errParseJson != nil {
+		return "", 0, errParseJson
+	}
+
+	revRegDefJson, revRegDeltaJson, timeStamp, errDelta := getRevRegDefAndDeltaCtx(ctx, poolHandle, subjectDid, revRegId, int64(revState.Timestamp), to)
+	if errDelta != nil {
+		return "", 0, errDelta
+	}
+	if timeStamp <= revState.Timestamp {
+		// nothing changed on the ledger since the state was built
+		return revStateJson, revState.Timestamp, nil
+	}
+
+	blobReaderHandle, errBlobHandle := openTailsReaderCtx(ctx, revRegDefJson)
+	if errBlobHandle != nil {
+		return "", 0, errBlobHandle
+	}
+
+	updatedRevStateJson, errRevState := UpdateRevocationStateCtx(ctx, blobReaderHandle, revStateJson, revRegDefJson, revRegDeltaJson, timeStamp, credRevId)
+	if errRevState != nil {
+		return "", 0, errRevState
+	}
+
+	return updatedRevStateJson, timeStamp, nil
+}
+
+// getRevRegDefAndDeltaCtx reads the revocation registry definition and its delta between from and to from the ledger
+func getRevRegDefAndDeltaCtx(ctx context.Context, poolHandle int, subjectDid string, revRegId string, from, to int64) (string, string, uint64, error) {
+	getRevocRegDefRequest, errGetRevRegDefReq := BuildGetRevRegDefRequestCtx(ctx, subjectDid, revRegId)
 	if errGetRevRegDefReq != nil {
-		return "", 0, errGetRevRegDefReq
+		return "", "", 0, errGetRevRegDefReq
 	}
 
-	getRevocRegDefResponse, errGetRevocRegDefResponse := SubmitRequest(poolHandle, getRevocRegDefRequest)
+	getRevocRegDefResponse, errGetRevocRegDefResponse := SubmitRequestCtx(ctx, poolHandle, getRevocRegDefRequest)
 	if errGetRevocRegDefResponse != nil {
-		return "", 0, errGetRevocRegDefResponse
+		return "", "", 0, errGetRevocRegDefResponse
 	}
 
-	_, revRegDefJson, errParse := ParseGetRevocRegDefResponse(getRevocRegDefResponse)
+	_, revRegDefJson, errParse := ParseGetRevocRegDefResponseCtx(ctx, getRevocRegDefResponse)
 	if errParse != nil {
-		return "", 0, errParse
+		return "", "", 0, errParse
 	}
 
-	getRevRegDeltaRequest, errGetDelta := BuildGetRevocRegDeltaRequest(subjectDid, revRegId, from, to)
+	getRevRegDeltaRequest, errGetDelta := BuildGetRevocRegDeltaRequestCtx(ctx, subjectDid, revRegId, from, to)
 	if errGetDelta != nil {
-		return "", 0, errGetDelta
+		return "", "", 0, errGetDelta
 	}
 
-	getRevRegDeltaResponse, errGetRevRegDeltaResp := SubmitRequest(poolHandle, getRevRegDeltaRequest)
+	getRevRegDeltaResponse, errGetRevRegDeltaResp := SubmitRequestCtx(ctx, poolHandle, getRevRegDeltaRequest)
 	if errGetRevRegDeltaResp != nil {
-		return "", 0, errGetRevRegDeltaResp
+		return "", "", 0, errGetRevRegDeltaResp
 	}
 
-	_, revRegDeltaJson, timeStamp, errParseDelta := ParseGetRevocRegDeltaResponse(getRevRegDeltaResponse)
+	_, revRegDeltaJson, timeStamp, errParseDelta := ParseGetRevocRegDeltaResponseCtx(ctx, getRevRegDeltaResponse)
 	if errParseDelta != nil {
-		return "", 0, errParseDelta
+		return "", "", 0, errParseDelta
 	}
 
-	revRegDefObj, errParseJson := gabs.ParseJSON([]byte(revRegDefJson))
+	return revRegDefJson, revRegDeltaJson, timeStamp, nil
+}
+
+// openTailsReaderCtx opens a blob storage reader on the directory of the registry tails file
+func openTailsReaderCtx(ctx context.Context, revRegDefJson string) (int, error) {
+	var revRegDef types.RevocRegDef
+	errParseJson := json.Unmarshal([]byte(revRegDefJson), &revRegDef)
 	if errParseJson != nil {
-		return "", 0, errParseJson
+		return 0, errParseJson
 	}
 
-	tailsLocation := revRegDefObj.Path("value.tailsLocation").Data().(string)
-	dir := filepath.Dir(tailsLocation)
+	dir := filepath.Dir(revRegDef.Value.TailsLocation)
 
 	config := blobstorage.ConfigBlobStorage{
 		BaseDir:    dir,
@@ -121,31 +199,26 @@ func GetRevState(poolHandle int, subjectDid string, revRegId string, credRevId s
 	}
 	configStr := jsonObjectToString(&config)
 
-	blobReaderHandle, errBlobHandle := IndyOpenBlobStorageReader("default", configStr)
-	if errBlobHandle != nil {
-		return "", 0, errBlobHandle
-	}
-
-	revStateJson, errRevState := CreateRevocationState(blobReaderHandle, revRegDefJson, revRegDeltaJson, timeStamp, credRevId)
-	if errRevState != nil {
-		return "", 0, errRevState
-	}
-
-	return revStateJson, timeStamp, nil
+	return IndyOpenBlobStorageReaderCtx(ctx, "default", configStr)
 }
 
 // GetSchema - gets schema
 func GetSchema(ph int, did string, schemaId string) (string, string, error) {
-	getSchemaRequestJson, errGet := BuildGetSchemaRequest(did, schemaId)
+	return GetSchemaCtx(context.Background(), ph, did, schemaId)
+}
+
+// GetSchemaCtx is like GetSchema but returns ctx.Err() if ctx is done before libindy answers.
+func GetSchemaCtx(ctx context.Context, ph int, did string, schemaId string) (string, string, error) {
+	getSchemaRequestJson, errGet := BuildGetSchemaRequestCtx(ctx, did, schemaId)
 	if errGet != nil {
 		return "", "", errGet
 	}
-	response, errSubmit := SubmitRequest(ph, getSchemaRequestJson)
+	response, errSubmit := SubmitRequestCtx(ctx, ph, getSchemaRequestJson)
 	if errSubmit != nil {
 		return "", "", errSubmit
 	}
 
-	id, js, errParse := ParseGetSchemaResponse(response)
+	id, js, errParse := ParseGetSchemaResponseCtx(ctx, response)
 	if errParse != nil {
 		return "", "", errParse
 	}
@@ -155,28 +228,31 @@ func GetSchema(ph int, did string, schemaId string) (string, string, error) {
 
 // GetCredDef gets cred def
 func GetCredDef(ph int, did string, credDefId string) (string, string, uint64, error) {
-	getCredDefRequestJson, errGet := BuildGetCredentialDefinitionRequest(did, credDefId)
+	return GetCredDefCtx(context.Background(), ph, did, credDefId)
+}
+
+// GetCredDefCtx is like GetCredDef but returns ctx.Err() if ctx is done before libindy answers.
+func GetCredDefCtx(ctx context.Context, ph int, did string, credDefId string) (string, string, uint64, error) {
+	getCredDefRequestJson, errGet := BuildGetCredentialDefinitionRequestCtx(ctx, did, credDefId)
 	if errGet != nil {
 		return "", "", 0, errGet
 	}
-	response, errSubmit := SubmitRequest(ph, getCredDefRequestJson)
+	response, errSubmit := SubmitRequestCtx(ctx, ph, getCredDefRequestJson)
 	if errSubmit != nil {
 		return "", "", 0, errSubmit
 	}
 
-	responseJson, errParse := gabs.ParseJSON([]byte(response))
-	if errParse != nil {
-		return "", "", 0, errSubmit
+	meta, errMeta := replyMetadata(response)
+	if errMeta != nil {
+		return "", "", 0, errMeta
 	}
 
-	creationTS := uint64(responseJson.Path("result.txnTime").Data().(float64))
-
-	id, js, errParse := ParseGetCredDefResponse(response)
+	id, js, errParse := ParseGetCredDefResponseCtx(ctx, response)
 	if errParse != nil {
 		return "", "", 0, errParse
 	}
 
-	return id, js, creationTS, nil
+	return id, js, meta.TxnTime, nil
 }
 
 // EncodeValue - helper function to encode the raw value ...
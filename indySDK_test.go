@@ -12,8 +12,12 @@
 package indySDK
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
 	"testing"
+	"time"
 )
 
 func Test_EncodeValue(t *testing.T) {
@@ -59,4 +63,205 @@ func Test_EncodeValue(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func Test_WaitForResult(t *testing.T) {
+	// Result available before the context is done
+	_, future := indyUtils.NewFutureCommand()
+	future <- indyUtils.IndyResult{Results: []interface{}{"ok"}}
+	result := indyUtils.WaitForResult(context.Background(), future)
+	if result.Error != nil || result.Results[0].(string) != "ok" {
+		t.Errorf("WaitForResult() result = '%v', want = 'ok'", result)
+	}
+
+	// Context done before libindy answers
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, future = indyUtils.NewFutureCommand()
+	result = indyUtils.WaitForResult(ctx, future)
+	if !errors.Is(result.Error, context.DeadlineExceeded) {
+		t.Errorf("WaitForResult() error = '%v', want = '%v'", result.Error, context.DeadlineExceeded)
+	}
+
+	// A late callback must not block
+	select {
+	case future <- indyUtils.IndyResult{}:
+	default:
+		t.Errorf("WaitForResult() late result blocked")
+	}
+}
+
+func Test_WaitForHandle(t *testing.T) {
+	// Context done before libindy opens the handle, the late handle is closed
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, future := indyUtils.NewFutureCommand()
+	closed := make(chan int, 1)
+	result := indyUtils.WaitForHandle(ctx, future, func(handle int) { closed <- handle })
+	if !errors.Is(result.Error, context.Canceled) {
+		t.Errorf("WaitForHandle() error = '%v', want = '%v'", result.Error, context.Canceled)
+		return
+	}
+	future <- indyUtils.IndyResult{Results: []interface{}{7}}
+	select {
+	case handle := <-closed:
+		if handle != 7 {
+			t.Errorf("WaitForHandle() closed handle = '%v', want = '7'", handle)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("WaitForHandle() late handle not closed")
+	}
+}
+
+func Test_IndyError(t *testing.T) {
+	var err error = indyUtils.NewIndyError(212)
+	wrapped := fmt.Errorf("get record: %w", err)
+
+	if !errors.Is(wrapped, indyUtils.ErrWalletItemNotFound) {
+		t.Errorf("errors.Is() = false, want = true")
+	}
+	if errors.Is(wrapped, indyUtils.ErrWalletNotFound) {
+		t.Errorf("errors.Is() = true, want = false")
+	}
+
+	var indyErr *indyUtils.IndyError
+	if !errors.As(wrapped, &indyErr) || indyErr.Code != 212 {
+		t.Errorf("errors.As() code = '%v', want = '212'", indyErr)
+	}
+	if err.Error() != indyUtils.GetIndyError(212) {
+		t.Errorf("Error() = '%s', want = '%s'", err.Error(), indyUtils.GetIndyError(212))
+	}
+}
+
+func Test_IndyErrorFromBinding(t *testing.T) {
+	walletHandle, errCreate := createWallet(testConfig(), testCredentials())
+	if errCreate != nil {
+		t.Errorf("createWallet() error = '%v'", errCreate)
+		return
+	}
+	defer walletCleanup(walletHandle, testConfig(), testCredentials())
+
+	// the bindings return the sentinels, the text of the error is unchanged
+	errCreate = CreateWallet(testConfig(), testCredentials())
+	if !errors.Is(errCreate, indyUtils.ErrWalletAlreadyExists) {
+		t.Errorf("CreateWallet() error = '%v', want = '%v'", errCreate, indyUtils.ErrWalletAlreadyExists)
+		return
+	}
+	if errCreate.Error() != indyUtils.GetIndyError(203) {
+		t.Errorf("Error() = '%s', want = '%s'", errCreate.Error(), indyUtils.GetIndyError(203))
+	}
+}
+
+func Test_RequestScheduler(t *testing.T) {
+	scheduler := indyUtils.NewRequestScheduler(indyUtils.RequestLimits{MaxInFlight: 2, QueueTimeout: 20 * time.Millisecond})
+	ctx := context.Background()
+
+	// Two slots on pool 1, the third request times out in queue
+	release1, err1 := scheduler.Acquire(ctx, 1)
+	release2, err2 := scheduler.Acquire(ctx, 1)
+	if err1 != nil || err2 != nil {
+		t.Errorf("Acquire() errors = '%v', '%v'", err1, err2)
+		return
+	}
+	_, err3 := scheduler.Acquire(ctx, 1)
+	if !errors.Is(err3, indyUtils.ErrRequestQueueTimeout) {
+		t.Errorf("Acquire() error = '%v', want = '%v'", err3, indyUtils.ErrRequestQueueTimeout)
+	}
+
+	// Another pool handle does not wait for pool 1
+	releaseOther, errOther := scheduler.Acquire(ctx, 2)
+	if errOther != nil {
+		t.Errorf("Acquire() on another pool error = '%v'", errOther)
+		return
+	}
+	releaseOther()
+
+	// Waiters are served in arrival order
+	scheduler.SetLimits(1, indyUtils.RequestLimits{MaxInFlight: 1})
+	order := make(chan int, 2)
+	for i := 0; i < 2; i++ {
+		go func(i int) {
+			release, err := scheduler.Acquire(ctx, 1)
+			if err != nil {
+				t.Errorf("Acquire() error = '%v'", err)
+				return
+			}
+			order <- i
+			release()
+		}(i)
+		time.Sleep(10 * time.Millisecond)
+	}
+	release1()
+	release2()
+	if first, second := <-order, <-order; first != 0 || second != 1 {
+		t.Errorf("Acquire() order = '%d, %d', want = '0, 1'", first, second)
+	}
+
+	// Context done while waiting
+	block, _ := scheduler.Acquire(ctx, 1)
+	defer block()
+	ctxCancel, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, errCtx := scheduler.Acquire(ctxCancel, 1)
+	if !errors.Is(errCtx, context.DeadlineExceeded) {
+		t.Errorf("Acquire() error = '%v', want = '%v'", errCtx, context.DeadlineExceeded)
+	}
+}
+
+func Test_RequestSchedulerRemove(t *testing.T) {
+	defaults := indyUtils.RequestLimits{MaxInFlight: 1, QueueTimeout: 20 * time.Millisecond}
+	scheduler := indyUtils.NewRequestScheduler(defaults)
+	custom := indyUtils.RequestLimits{MaxInFlight: 3}
+	scheduler.SetLimits(1, custom)
+	release, errSlot := scheduler.Acquire(context.Background(), 1)
+	if errSlot != nil {
+		t.Errorf("Acquire() error = '%v'", errSlot)
+		return
+	}
+
+	// The pool handle is closed with a request in flight, the entry goes away with the last release
+	scheduler.Remove(1)
+	if limits := scheduler.Limits(1); limits != defaults {
+		t.Errorf("Limits() after Remove = '%v', want = '%v'", limits, defaults)
+		return
+	}
+	release()
+	scheduler.SetLimits(1, custom)
+	scheduler.Remove(1)
+	if limits := scheduler.Limits(1); limits != defaults {
+		t.Errorf("Limits() after the last release = '%v', want = '%v'", limits, defaults)
+	}
+}
+
+func Test_WaitForRequest(t *testing.T) {
+	scheduler := indyUtils.NewRequestScheduler(indyUtils.RequestLimits{MaxInFlight: 1, QueueTimeout: 20 * time.Millisecond})
+	release, errSlot := scheduler.Acquire(context.Background(), 1)
+	if errSlot != nil {
+		t.Errorf("Acquire() error = '%v'", errSlot)
+		return
+	}
+
+	// Context done before libindy answers, the request is still in flight and keeps its slot
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, future := indyUtils.NewFutureCommand()
+	result := indyUtils.WaitForRequest(ctx, future, release)
+	if !errors.Is(result.Error, context.Canceled) {
+		t.Errorf("WaitForRequest() error = '%v', want = '%v'", result.Error, context.Canceled)
+		return
+	}
+	_, errQueue := scheduler.Acquire(context.Background(), 1)
+	if !errors.Is(errQueue, indyUtils.ErrRequestQueueTimeout) {
+		t.Errorf("Acquire() error = '%v', want = '%v'", errQueue, indyUtils.ErrRequestQueueTimeout)
+		return
+	}
+
+	// The slot is released once libindy answers
+	future <- indyUtils.IndyResult{}
+	release, errSlot = scheduler.Acquire(context.Background(), 1)
+	if errSlot != nil {
+		t.Errorf("Acquire() after the late result error = '%v'", errSlot)
+		return
+	}
+	release()
+}
@@ -11,6 +11,18 @@
 
 package indyUtils
 
+/*
+#cgo CFLAGS: -I ../include
+#cgo LDFLAGS: -L${SRCDIR}/../lib -lindy
+#include <indy_core.h>
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
 var errorsIndySDK = map[int]string{
 	0:   "Success",
 	100: "CommonInvalidParam1: Caller passed invalid value as param 1 (null, invalid json and etc..)",
@@ -69,6 +81,121 @@ var errorsIndySDK = map[int]string{
 	705: "PaymentExtraFundsError: Extra funds on inputs",
 }
 
+// Sentinel errors to be used with errors.Is
+var (
+	ErrCommonInvalidParam1      = &IndyError{Code: 100}
+	ErrCommonInvalidParam2      = &IndyError{Code: 101}
+	ErrCommonInvalidParam3      = &IndyError{Code: 102}
+	ErrCommonInvalidParam4      = &IndyError{Code: 103}
+	ErrCommonInvalidParam5      = &IndyError{Code: 104}
+	ErrCommonInvalidParam6      = &IndyError{Code: 105}
+	ErrCommonInvalidParam7      = &IndyError{Code: 106}
+	ErrCommonInvalidParam8      = &IndyError{Code: 107}
+	ErrCommonInvalidParam9      = &IndyError{Code: 108}
+	ErrCommonInvalidParam10     = &IndyError{Code: 109}
+	ErrCommonInvalidParam11     = &IndyError{Code: 110}
+	ErrCommonInvalidParam12     = &IndyError{Code: 111}
+	ErrCommonInvalidState       = &IndyError{Code: 112}
+	ErrCommonInvalidStructure   = &IndyError{Code: 113}
+	ErrCommonIOError            = &IndyError{Code: 114}
+	ErrWalletInvalidHandle      = &IndyError{Code: 200}
+	ErrWalletUnknownType        = &IndyError{Code: 201}
+	ErrWalletTypeAlreadyExists  = &IndyError{Code: 202}
+	ErrWalletAlreadyExists      = &IndyError{Code: 203}
+	ErrWalletNotFound           = &IndyError{Code: 204}
+	ErrWalletIncompatiblePool   = &IndyError{Code: 205}
+	ErrWalletAlreadyOpened      = &IndyError{Code: 206}
+	ErrWalletAccessFailed       = &IndyError{Code: 207}
+	ErrWalletInput              = &IndyError{Code: 208}
+	ErrWalletDecoding           = &IndyError{Code: 209}
+	ErrWalletStorage            = &IndyError{Code: 210}
+	ErrWalletEncryption         = &IndyError{Code: 211}
+	ErrWalletItemNotFound       = &IndyError{Code: 212}
+	ErrWalletItemAlreadyExists  = &IndyError{Code: 213}
+	ErrWalletQuery              = &IndyError{Code: 214}
+	ErrPoolLedgerNotCreated     = &IndyError{Code: 300}
+	ErrPoolLedgerInvalidHandle  = &IndyError{Code: 301}
+	ErrPoolLedgerTerminated     = &IndyError{Code: 302}
+	ErrLedgerNoConsensus        = &IndyError{Code: 303}
+	ErrLedgerInvalidTransaction = &IndyError{Code: 304}
+	ErrLedgerSecurity           = &IndyError{Code: 305}
+	ErrPoolConfigAlreadyExists  = &IndyError{Code: 306}
+	ErrPoolLedgerTimeout        = &IndyError{Code: 307}
+	ErrPoolIncompatibleProtocol = &IndyError{Code: 308}
+	ErrLedgerNotFound           = &IndyError{Code: 309}
+	ErrRevocationRegistryFull   = &IndyError{Code: 400}
+	ErrInvalidUserRevocId       = &IndyError{Code: 401}
+	ErrMasterSecretDuplicate    = &IndyError{Code: 404}
+	ErrProofRejected            = &IndyError{Code: 405}
+	ErrCredentialRevoked        = &IndyError{Code: 406}
+	ErrCredDefAlreadyExists     = &IndyError{Code: 407}
+	ErrUnknownCryptoType        = &IndyError{Code: 500}
+	ErrDidAlreadyExists         = &IndyError{Code: 600}
+	ErrPaymentUnknownMethod     = &IndyError{Code: 700}
+	ErrPaymentIncompatible      = &IndyError{Code: 701}
+	ErrPaymentInsufficientFunds = &IndyError{Code: 702}
+	ErrPaymentSourceNotFound    = &IndyError{Code: 703}
+	ErrPaymentNotSupported      = &IndyError{Code: 704}
+	ErrPaymentExtraFunds        = &IndyError{Code: 705}
+)
+
+// IndyError represents an indy_error_t together with the details reported by libindy
+type IndyError struct {
+	Code      int
+	Message   string
+	Backtrace string
+}
+
+// Error returns the same text as GetIndyError so existing string comparisons keep working
+func (e *IndyError) Error() string {
+	if msg, ok := errorsIndySDK[e.Code]; ok {
+		return msg
+	}
+	return fmt.Sprintf("IndyError: unknown error code %d", e.Code)
+}
+
+// Is reports whether target is an IndyError with the same code
+func (e *IndyError) Is(target error) bool {
+	t, ok := target.(*IndyError)
+	if !ok {
+		return false
+	}
+	return t.Code == e.Code
+}
+
+// NewIndyError creates an IndyError for an error code raised on the go side (e.g. custom wallet storages) or
+// returned synchronously by a libindy call, the goroutine may have moved to another thread since the call so
+// the thread local details of indy_get_current_error can't be trusted there
+func NewIndyError(code int) error {
+	return &IndyError{Code: code, Message: errorsIndySDK[code]}
+}
+
+// CurrentIndyError creates an IndyError for an error code passed to a libindy callback and fills in the details
+// from indy_get_current_error. Must be called inside the callback, it runs on the libindy thread that raised
+// the error and libindy keeps the details only until the callback returns.
+func CurrentIndyError(code int) error {
+	indyErr := &IndyError{Code: code, Message: errorsIndySDK[code]}
+
+	var errorJson *C.char
+	C.indy_get_current_error(&errorJson)
+	if errorJson == nil {
+		return indyErr
+	}
+
+	var details struct {
+		Message   string `json:"message"`
+		Backtrace string `json:"backtrace"`
+	}
+	if err := json.Unmarshal([]byte(C.GoString(errorJson)), &details); err != nil {
+		return indyErr
+	}
+	if len(details.Message) > 0 {
+		indyErr.Message = details.Message
+	}
+	indyErr.Backtrace = details.Backtrace
+	return indyErr
+}
+
 // GetIndyError returns the string error of an indy_error_t
 func GetIndyError(err int) string {
 	return errorsIndySDK[err]
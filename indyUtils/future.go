@@ -19,6 +19,7 @@ package indyUtils
 import "C"
 
 import (
+	"context"
 	cmap "github.com/orcaman/concurrent-map"
 	"strconv"
 	"sync"
@@ -56,7 +57,8 @@ var count Counter
 func NewFutureCommand() (C.indy_handle_t, chan IndyResult) {
 	commandHandle, futuresKey := count.Get()
 	//fmt.Println("command handle %d", commandHandle)
-	future := make(chan IndyResult)
+	// Buffered so that a late callback never blocks when the caller stopped waiting (see WaitForResult)
+	future := make(chan IndyResult, 1)
 	// Save to the map our handle
 	futures.Set(futuresKey, future)
 	return (C.indy_handle_t)(commandHandle), future
@@ -72,3 +74,36 @@ func RemoveFuture(commandHandle int, result IndyResult) chan IndyResult {
 	futures.Remove(futuresKey)
 	return future.(chan IndyResult)
 }
+
+// WaitForResult waits for the result of a future command or for ctx to be done, whichever comes first.
+// If ctx is done first the future is abandoned: libindy still calls back later, RemoveFuture drops the
+// result in the buffered channel and deletes the entry from the futures map, so nothing is leaked.
+// Input buffers may be freed right away since libindy copies them before the call returns.
+func WaitForResult(ctx context.Context, future chan IndyResult) IndyResult {
+	select {
+	case result := <-future:
+		return result
+	case <-ctx.Done():
+		return IndyResult{Error: ctx.Err()}
+	}
+}
+
+// WaitForHandle is like WaitForResult for the commands opening a handle. If ctx is done first, the handle
+// libindy returns later has no owner, it is passed to closeHandle instead of staying open.
+func WaitForHandle(ctx context.Context, future chan IndyResult, closeHandle func(handle int)) IndyResult {
+	select {
+	case result := <-future:
+		return result
+	case <-ctx.Done():
+		go func() {
+			result := <-future
+			if result.Error != nil || len(result.Results) == 0 {
+				return
+			}
+			if handle, ok := result.Results[0].(int); ok {
+				closeHandle(handle)
+			}
+		}()
+		return IndyResult{Error: ctx.Err()}
+	}
+}
@@ -0,0 +1,197 @@
+/*
+// ******************************************************************
+// Purpose: bounds the ledger requests in flight on each pool handle
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package indyUtils
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrRequestQueueTimeout is returned when a request waited longer than the queue timeout for a free slot
+var ErrRequestQueueTimeout = errors.New("timed out waiting for a free ledger request slot")
+
+// RequestLimits limits the requests of one pool handle
+type RequestLimits struct {
+	MaxInFlight  int           // requests sent to the pool at the same time, <= 0 means 1
+	QueueTimeout time.Duration // max time a request waits for a slot, 0 means wait until the context is done
+}
+
+type requestWaiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+type poolRequests struct {
+	limits   RequestLimits
+	inFlight int
+	waiters  *list.List // FIFO of *requestWaiter
+	closed   bool       // removed with requests still holding or waiting for a slot
+}
+
+// RequestScheduler hands out request slots per pool handle in arrival order,
+// requests on different pool handles never wait for each other
+type RequestScheduler struct {
+	mutex    sync.Mutex
+	defaults RequestLimits
+	pools    map[int]*poolRequests
+}
+
+// NewRequestScheduler creates a scheduler, pools without their own limits use the defaults
+func NewRequestScheduler(defaults RequestLimits) *RequestScheduler {
+	return &RequestScheduler{defaults: defaults, pools: make(map[int]*poolRequests)}
+}
+
+func (s *RequestScheduler) pool(ph int) *poolRequests {
+	p, ok := s.pools[ph]
+	if !ok || p.closed {
+		p = &poolRequests{limits: s.defaults, waiters: list.New()}
+		s.pools[ph] = p
+	}
+	return p
+}
+
+// SetLimits changes the limits of a pool handle, waiting requests are admitted if the new limit allows it
+func (s *RequestScheduler) SetLimits(ph int, limits RequestLimits) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	p := s.pool(ph)
+	p.limits = limits
+	s.admit(p)
+}
+
+// Limits returns the limits of a pool handle
+func (s *RequestScheduler) Limits(ph int) RequestLimits {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	p, ok := s.pools[ph]
+	if !ok || p.closed {
+		return s.defaults
+	}
+	return p.limits
+}
+
+// Remove forgets a closed pool handle, requests still holding or waiting for a slot finish normally and
+// the last of them deletes the entry
+func (s *RequestScheduler) Remove(ph int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	p, ok := s.pools[ph]
+	if ok {
+		p.closed = true
+		s.forget(ph, p)
+	}
+}
+
+// forget deletes the entry of an idle pool handle that was removed or has the default limits, call with the mutex held
+func (s *RequestScheduler) forget(ph int, p *poolRequests) {
+	if p.inFlight == 0 && p.waiters.Len() == 0 && s.pools[ph] == p && (p.closed || p.limits == s.defaults) {
+		delete(s.pools, ph)
+	}
+}
+
+// Acquire waits for a request slot on the pool handle. The returned release func must be called
+// once the request completes. Fails with ErrRequestQueueTimeout or ctx.Err() if no slot was granted in time.
+func (s *RequestScheduler) Acquire(ctx context.Context, ph int) (release func(), err error) {
+	s.mutex.Lock()
+	p := s.pool(ph)
+	if p.waiters.Len() == 0 && p.inFlight < maxInFlight(p.limits) {
+		p.inFlight++
+		s.mutex.Unlock()
+		return s.releaseFunc(ph, p), nil
+	}
+
+	w := &requestWaiter{ready: make(chan struct{})}
+	elem := p.waiters.PushBack(w)
+	timeout := p.limits.QueueTimeout
+	s.mutex.Unlock()
+
+	var timer <-chan time.Time
+	if timeout > 0 {
+		t := time.NewTimer(timeout)
+		defer t.Stop()
+		timer = t.C
+	}
+
+	var waitErr error
+	select {
+	case <-w.ready:
+		return s.releaseFunc(ph, p), nil
+	case <-ctx.Done():
+		waitErr = ctx.Err()
+	case <-timer:
+		waitErr = fmt.Errorf("pool handle %d: %w after %s", ph, ErrRequestQueueTimeout, timeout)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if w.granted {
+		// the slot was handed over while timing out, use it
+		return s.releaseFunc(ph, p), nil
+	}
+	p.waiters.Remove(elem)
+	s.forget(ph, p)
+	return nil, waitErr
+}
+
+// WaitForRequest is like WaitForResult for a request holding a slot: release is called once libindy answers,
+// also when ctx is done first, so the slot stays taken while the request is still in flight.
+func WaitForRequest(ctx context.Context, future chan IndyResult, release func()) IndyResult {
+	select {
+	case result := <-future:
+		release()
+		return result
+	case <-ctx.Done():
+		go func() {
+			<-future
+			release()
+		}()
+		return IndyResult{Error: ctx.Err()}
+	}
+}
+
+func (s *RequestScheduler) releaseFunc(ph int, p *poolRequests) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+
+			p.inFlight--
+			s.admit(p)
+			s.forget(ph, p)
+		})
+	}
+}
+
+// admit grants free slots to the waiters in arrival order, call with the mutex held
+func (s *RequestScheduler) admit(p *poolRequests) {
+	for p.inFlight < maxInFlight(p.limits) && p.waiters.Len() > 0 {
+		w := p.waiters.Remove(p.waiters.Front()).(*requestWaiter)
+		w.granted = true
+		p.inFlight++
+		close(w.ready)
+	}
+}
+
+func maxInFlight(limits RequestLimits) int {
+	if limits.MaxInFlight <= 0 {
+		return 1
+	}
+	return limits.MaxInFlight
+}
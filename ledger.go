@@ -17,21 +17,32 @@ package indySDK
 */
 import "C"
 import (
+	"context"
+	"encoding/json"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
 	"github.com/joyride9999/IndySdkGoBindings/ledger"
-	"github.com/viney-shih/go-lock"
-	"sync"
 	"time"
 	"unsafe"
 )
 
-type IndyRequest struct {
-	sync.Mutex
-}
+// DefaultRequestLimits limits applied to pool handles without their own SetPoolRequestLimits
+var DefaultRequestLimits = indyUtils.RequestLimits{MaxInFlight: 8, QueueTimeout: 60 * time.Second}
+
+// requestScheduler bounds the SubmitRequest / SignAndSubmitRequest calls in flight per pool handle
+var requestScheduler = indyUtils.NewRequestScheduler(DefaultRequestLimits)
 
-var indyRequest = lock.NewCASMutex()
+// SetPoolRequestLimits sets how many requests may be in flight on a pool handle and how long the others wait in queue
+func SetPoolRequestLimits(ph int, limits indyUtils.RequestLimits) {
+	requestScheduler.SetLimits(ph, limits)
+}
 
 // BuildRevocRegEntryRequest Builds a REVOC_REG_ENTRY request. Request to add the definition of revocation registry  to an exists credential definition.
 func BuildRevocRegEntryRequest(submitterDid string, revocRegDefId string, revDefType string, value string) (string, error) {
+	return BuildRevocRegEntryRequestCtx(context.Background(), submitterDid, revocRegDefId, revDefType, value)
+}
+
+// BuildRevocRegEntryRequestCtx is like BuildRevocRegEntryRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildRevocRegEntryRequestCtx(ctx context.Context, submitterDid string, revocRegDefId string, revDefType string, value string) (string, error) {
 
 	upSubmitterDid := unsafe.Pointer(C.CString(submitterDid))
 	defer C.free(upSubmitterDid)
@@ -43,7 +54,7 @@ func BuildRevocRegEntryRequest(submitterDid string, revocRegDefId string, revDef
 	defer C.free(upValue)
 
 	channel := ledger.BuildRevocRegEntryRequest(upSubmitterDid, upRevocRegDefId, upRevDefType, upValue)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -54,6 +65,11 @@ func BuildRevocRegEntryRequest(submitterDid string, revocRegDefId string, revDef
 //    The Delta is defined by from and to timestamp fields.
 //    If from is not specified, then the whole state till to will be returned.
 func BuildGetRevocRegDeltaRequest(submitterDid string, revocRegDefId string, from int64, to int64) (string, error) {
+	return BuildGetRevocRegDeltaRequestCtx(context.Background(), submitterDid, revocRegDefId, from, to)
+}
+
+// BuildGetRevocRegDeltaRequestCtx is like BuildGetRevocRegDeltaRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildGetRevocRegDeltaRequestCtx(ctx context.Context, submitterDid string, revocRegDefId string, from int64, to int64) (string, error) {
 
 	upSubmitterDid := unsafe.Pointer(GetOptionalValue(submitterDid))
 	defer C.free(upSubmitterDid)
@@ -61,7 +77,7 @@ func BuildGetRevocRegDeltaRequest(submitterDid string, revocRegDefId string, fro
 	defer C.free(upRevocRegDefId)
 
 	channel := ledger.BuildGetRevocRegDeltaRequest(upSubmitterDid, upRevocRegDefId, from, to)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -71,6 +87,11 @@ func BuildGetRevocRegDeltaRequest(submitterDid string, revocRegDefId string, fro
 // BuildRevocRegDefRequest Builds a REVOC_REG_DEF request. Request to add the definition of revocation registry
 //    to an exists credential definition.
 func BuildRevocRegDefRequest(submitterDid string, revocRegDef string) (string, error) {
+	return BuildRevocRegDefRequestCtx(context.Background(), submitterDid, revocRegDef)
+}
+
+// BuildRevocRegDefRequestCtx is like BuildRevocRegDefRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildRevocRegDefRequestCtx(ctx context.Context, submitterDid string, revocRegDef string) (string, error) {
 
 	upSubmitterDid := unsafe.Pointer(C.CString(submitterDid))
 	defer C.free(upSubmitterDid)
@@ -78,7 +99,7 @@ func BuildRevocRegDefRequest(submitterDid string, revocRegDef string) (string, e
 	defer C.free(upRevocRegDef)
 
 	channel := ledger.BuildRevocRegDefRequest(upSubmitterDid, upRevocRegDef)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -87,6 +108,11 @@ func BuildRevocRegDefRequest(submitterDid string, revocRegDef string) (string, e
 
 // BuildGetDdoRequest creates a request to get DDO
 func BuildGetDdoRequest(submitterDid string, targetDid string) (string, error) {
+	return BuildGetDdoRequestCtx(context.Background(), submitterDid, targetDid)
+}
+
+// BuildGetDdoRequestCtx is like BuildGetDdoRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildGetDdoRequestCtx(ctx context.Context, submitterDid string, targetDid string) (string, error) {
 
 	upSubmitterDid := unsafe.Pointer(C.CString(submitterDid))
 	defer C.free(upSubmitterDid)
@@ -94,7 +120,7 @@ func BuildGetDdoRequest(submitterDid string, targetDid string) (string, error) {
 	defer C.free(upTargetDid)
 
 	channel := ledger.BuildGetDdoRequest(upSubmitterDid, upTargetDid)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -103,6 +129,11 @@ func BuildGetDdoRequest(submitterDid string, targetDid string) (string, error) {
 
 // BuildNymRequest creates a nym request (to create an identity ont he blockchain) and returns it
 func BuildNymRequest(submitterDid string, targetDid string, targetVerkey string, alias string, role string) (string, error) {
+	return BuildNymRequestCtx(context.Background(), submitterDid, targetDid, targetVerkey, alias, role)
+}
+
+// BuildNymRequestCtx is like BuildNymRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildNymRequestCtx(ctx context.Context, submitterDid string, targetDid string, targetVerkey string, alias string, role string) (string, error) {
 
 	upSubmitterDid := unsafe.Pointer(C.CString(submitterDid))
 	defer C.free(upSubmitterDid)
@@ -116,7 +147,7 @@ func BuildNymRequest(submitterDid string, targetDid string, targetVerkey string,
 	defer C.free(upRole)
 
 	channel := ledger.BuildNymRequest(upSubmitterDid, upTargetDid, upTargetVerkey, upAlias, upRole)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -125,6 +156,11 @@ func BuildNymRequest(submitterDid string, targetDid string, targetVerkey string,
 
 // BuildAttribRequest Builds an ATTRIB request. Request to add attribute to a NYM record.
 func BuildAttribRequest(submitterDid string, targetDid, raw string, hash string, encrypted string) (string, error) {
+	return BuildAttribRequestCtx(context.Background(), submitterDid, targetDid, raw, hash, encrypted)
+}
+
+// BuildAttribRequestCtx is like BuildAttribRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildAttribRequestCtx(ctx context.Context, submitterDid string, targetDid, raw string, hash string, encrypted string) (string, error) {
 
 	upSubmitterDid := unsafe.Pointer(C.CString(submitterDid))
 	defer C.free(upSubmitterDid)
@@ -138,7 +174,7 @@ func BuildAttribRequest(submitterDid string, targetDid, raw string, hash string,
 	defer C.free(upEncData)
 
 	channel := ledger.BuildAttribRequest(upSubmitterDid, upTargetDid, upRawData, upHash, upEncData)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -147,6 +183,11 @@ func BuildAttribRequest(submitterDid string, targetDid, raw string, hash string,
 
 // BuildGetAttribRequest Builds a GET_ATTRIB request. Request to get information about an Attribute for the specified DID.
 func BuildGetAttribRequest(submitterDid string, targetDid, raw string, hash string, encrypted string) (string, error) {
+	return BuildGetAttribRequestCtx(context.Background(), submitterDid, targetDid, raw, hash, encrypted)
+}
+
+// BuildGetAttribRequestCtx is like BuildGetAttribRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildGetAttribRequestCtx(ctx context.Context, submitterDid string, targetDid, raw string, hash string, encrypted string) (string, error) {
 
 	upSubmitterDid := unsafe.Pointer(GetOptionalValue(submitterDid))
 	defer C.free(upSubmitterDid)
@@ -160,7 +201,7 @@ func BuildGetAttribRequest(submitterDid string, targetDid, raw string, hash stri
 	defer C.free(upEncData)
 
 	channel := ledger.BuildGetAttribRequest(upSubmitterDid, upTargetDid, upRawData, upHash, upEncData)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -169,6 +210,11 @@ func BuildGetAttribRequest(submitterDid string, targetDid, raw string, hash stri
 
 // BuildGetNymRequest Builds a GET_NYM request. Request to get information about a DID (NYM).
 func BuildGetNymRequest(submitterDid string, targetDid string) (string, error) {
+	return BuildGetNymRequestCtx(context.Background(), submitterDid, targetDid)
+}
+
+// BuildGetNymRequestCtx is like BuildGetNymRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildGetNymRequestCtx(ctx context.Context, submitterDid string, targetDid string) (string, error) {
 
 	upSubmitterDid := unsafe.Pointer(GetOptionalValue(submitterDid))
 	defer C.free(upSubmitterDid)
@@ -177,7 +223,7 @@ func BuildGetNymRequest(submitterDid string, targetDid string) (string, error) {
 	defer C.free(upTargetDid)
 
 	channel := ledger.BuildGetNymRequest(upSubmitterDid, upTargetDid)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -186,6 +232,11 @@ func BuildGetNymRequest(submitterDid string, targetDid string) (string, error) {
 
 // BuildNodeRequest Builds a NODE request. Request to add a new node to the pool, or updates existing in the pool.
 func BuildNodeRequest(submitterDid string, targetDid string, data string) (string, error) {
+	return BuildNodeRequestCtx(context.Background(), submitterDid, targetDid, data)
+}
+
+// BuildNodeRequestCtx is like BuildNodeRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildNodeRequestCtx(ctx context.Context, submitterDid string, targetDid string, data string) (string, error) {
 
 	upSubmitterDid := unsafe.Pointer(C.CString(submitterDid))
 	defer C.free(upSubmitterDid)
@@ -195,7 +246,7 @@ func BuildNodeRequest(submitterDid string, targetDid string, data string) (strin
 	defer C.free(upData)
 
 	channel := ledger.BuildNodeRequest(upSubmitterDid, upTargetDid, upData)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -204,10 +255,15 @@ func BuildNodeRequest(submitterDid string, targetDid string, data string) (strin
 
 // BuildGetValidatorInfoRequest Builds a GET_VALIDATOR_INFO request.
 func BuildGetValidatorInfoRequest(submitterDid string) (string, error) {
+	return BuildGetValidatorInfoRequestCtx(context.Background(), submitterDid)
+}
+
+// BuildGetValidatorInfoRequestCtx is like BuildGetValidatorInfoRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildGetValidatorInfoRequestCtx(ctx context.Context, submitterDid string) (string, error) {
 	upSubmitterDid := unsafe.Pointer(C.CString(submitterDid))
 	defer C.free(upSubmitterDid)
 	channel := ledger.BuildGetValidatorInfoRequest(upSubmitterDid)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -216,13 +272,18 @@ func BuildGetValidatorInfoRequest(submitterDid string) (string, error) {
 
 // BuildGetTxnRequest Builds a GET_TXN request. Request to get any transaction by its seq_no.
 func BuildGetTxnRequest(submitterDid string, ledgerType string, seqNo int) (string, error) {
+	return BuildGetTxnRequestCtx(context.Background(), submitterDid, ledgerType, seqNo)
+}
+
+// BuildGetTxnRequestCtx is like BuildGetTxnRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildGetTxnRequestCtx(ctx context.Context, submitterDid string, ledgerType string, seqNo int) (string, error) {
 	upSubmitterDid := unsafe.Pointer(GetOptionalValue(submitterDid))
 	defer C.free(upSubmitterDid)
 	upLedgerType := unsafe.Pointer(GetOptionalValue(ledgerType))
 	defer C.free(upLedgerType)
 
 	channel := ledger.BuildGetTxnRequest(upSubmitterDid, upLedgerType, seqNo)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -231,11 +292,16 @@ func BuildGetTxnRequest(submitterDid string, ledgerType string, seqNo int) (stri
 
 // BuildPoolConfigRequest Builds a POOL_CONFIG request. Request to change Pool's configuration.
 func BuildPoolConfigRequest(submitterDid string, writes bool, force bool) (string, error) {
+	return BuildPoolConfigRequestCtx(context.Background(), submitterDid, writes, force)
+}
+
+// BuildPoolConfigRequestCtx is like BuildPoolConfigRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildPoolConfigRequestCtx(ctx context.Context, submitterDid string, writes bool, force bool) (string, error) {
 	upSubmitterDid := unsafe.Pointer(C.CString(submitterDid))
 	defer C.free(upSubmitterDid)
 
 	channel := ledger.BuildPoolConfigRequest(upSubmitterDid, writes, force)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -244,6 +310,11 @@ func BuildPoolConfigRequest(submitterDid string, writes bool, force bool) (strin
 
 // BuildPoolRestartRequest Builds a POOL_RESTART request.
 func BuildPoolRestartRequest(submitterDid string, action string, dateTime string) (string, error) {
+	return BuildPoolRestartRequestCtx(context.Background(), submitterDid, action, dateTime)
+}
+
+// BuildPoolRestartRequestCtx is like BuildPoolRestartRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildPoolRestartRequestCtx(ctx context.Context, submitterDid string, action string, dateTime string) (string, error) {
 	upSubmitterDid := unsafe.Pointer(C.CString(submitterDid))
 	defer C.free(upSubmitterDid)
 	upAction := unsafe.Pointer(C.CString(action))
@@ -252,7 +323,7 @@ func BuildPoolRestartRequest(submitterDid string, action string, dateTime string
 	defer C.free(upDateTime)
 
 	channel := ledger.BuildPoolRestartRequest(upAction, upAction, upDateTime)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -262,6 +333,12 @@ func BuildPoolRestartRequest(submitterDid string, action string, dateTime string
 // BuildPoolUpgradeRequest Builds a POOL_UPGRADE request.
 func BuildPoolUpgradeRequest(submitterDid string, name string, version string, action string, sha256 string, timeOut int32, schedule string,
 	justification string, reinstall bool, force bool, indyPackage string) (string, error) {
+	return BuildPoolUpgradeRequestCtx(context.Background(), submitterDid, name, version, action, sha256, timeOut, schedule, justification, reinstall, force, indyPackage)
+}
+
+// BuildPoolUpgradeRequestCtx is like BuildPoolUpgradeRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildPoolUpgradeRequestCtx(ctx context.Context, submitterDid string, name string, version string, action string, sha256 string, timeOut int32, schedule string,
+	justification string, reinstall bool, force bool, indyPackage string) (string, error) {
 
 	upSubmitterDid := unsafe.Pointer(C.CString(submitterDid))
 	defer C.free(upSubmitterDid)
@@ -282,7 +359,7 @@ func BuildPoolUpgradeRequest(submitterDid string, name string, version string, a
 
 	channel := ledger.BuildPoolUpgradeRequest(upSubmitterDid, upName, upName, upAction, upSha256, timeOut,
 		upSchedule, upReason, reinstall, force, upPackage)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -291,6 +368,11 @@ func BuildPoolUpgradeRequest(submitterDid string, name string, version string, a
 
 // BuildGetSchemaRequest creates a schema request
 func BuildGetSchemaRequest(submitterDid string, schemaId string) (schemaRequest string, err error) {
+	return BuildGetSchemaRequestCtx(context.Background(), submitterDid, schemaId)
+}
+
+// BuildGetSchemaRequestCtx is like BuildGetSchemaRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildGetSchemaRequestCtx(ctx context.Context, submitterDid string, schemaId string) (schemaRequest string, err error) {
 
 	upSubmitterDid := unsafe.Pointer(GetOptionalValue(submitterDid))
 	defer C.free(upSubmitterDid)
@@ -298,7 +380,7 @@ func BuildGetSchemaRequest(submitterDid string, schemaId string) (schemaRequest
 	defer C.free(upSchemaId)
 
 	channel := ledger.BuildGetSchemaRequest(upSubmitterDid, upSchemaId)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -307,13 +389,18 @@ func BuildGetSchemaRequest(submitterDid string, schemaId string) (schemaRequest
 
 // BuildSchemaRequest creates a schema request
 func BuildSchemaRequest(submitterDid string, schema string) (request string, err error) {
+	return BuildSchemaRequestCtx(context.Background(), submitterDid, schema)
+}
+
+// BuildSchemaRequestCtx is like BuildSchemaRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildSchemaRequestCtx(ctx context.Context, submitterDid string, schema string) (request string, err error) {
 	upSubmitterDid := unsafe.Pointer(C.CString(submitterDid))
 	defer C.free(upSubmitterDid)
 	upSchemaId := unsafe.Pointer(C.CString(schema))
 	defer C.free(upSchemaId)
 
 	channel := ledger.BuildSchemaRequest(upSubmitterDid, upSchemaId)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -322,13 +409,18 @@ func BuildSchemaRequest(submitterDid string, schema string) (request string, err
 
 // BuildCredentialDefinitionRequest Builds an CRED_DEF request.
 func BuildCredentialDefinitionRequest(submitterDid string, credDefinition string) (request string, err error) {
+	return BuildCredentialDefinitionRequestCtx(context.Background(), submitterDid, credDefinition)
+}
+
+// BuildCredentialDefinitionRequestCtx is like BuildCredentialDefinitionRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildCredentialDefinitionRequestCtx(ctx context.Context, submitterDid string, credDefinition string) (request string, err error) {
 	upSubmitterDid := unsafe.Pointer(C.CString(submitterDid))
 	defer C.free(upSubmitterDid)
 	upCredDef := unsafe.Pointer(C.CString(credDefinition))
 	defer C.free(upCredDef)
 
 	channel := ledger.BuildCredentialDefinitionRequest(upSubmitterDid, upCredDef)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -337,6 +429,11 @@ func BuildCredentialDefinitionRequest(submitterDid string, credDefinition string
 
 // BuildGetRevocRegRequest Builds a GET_REVOC_REG request
 func BuildGetRevocRegRequest(submitterDid string, revRegDefId string, timeStamp int64) (request string, err error) {
+	return BuildGetRevocRegRequestCtx(context.Background(), submitterDid, revRegDefId, timeStamp)
+}
+
+// BuildGetRevocRegRequestCtx is like BuildGetRevocRegRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildGetRevocRegRequestCtx(ctx context.Context, submitterDid string, revRegDefId string, timeStamp int64) (request string, err error) {
 
 	upSubmitterDid := unsafe.Pointer(GetOptionalValue(submitterDid))
 	defer C.free(upSubmitterDid)
@@ -344,7 +441,7 @@ func BuildGetRevocRegRequest(submitterDid string, revRegDefId string, timeStamp
 	defer C.free(upRevRegDefId)
 
 	channel := ledger.BuildGetRevocRegRequest(upSubmitterDid, upRevRegDefId, timeStamp)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -353,13 +450,18 @@ func BuildGetRevocRegRequest(submitterDid string, revRegDefId string, timeStamp
 
 // BuildGetRevRegDefRequest Builds an GET_REVOC_REG_DEF request.
 func BuildGetRevRegDefRequest(submitterDid string, revRegDefId string) (request string, err error) {
+	return BuildGetRevRegDefRequestCtx(context.Background(), submitterDid, revRegDefId)
+}
+
+// BuildGetRevRegDefRequestCtx is like BuildGetRevRegDefRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildGetRevRegDefRequestCtx(ctx context.Context, submitterDid string, revRegDefId string) (request string, err error) {
 
 	upSubmitterDid := unsafe.Pointer(GetOptionalValue(submitterDid))
 	defer C.free(upSubmitterDid)
 	upRevRegDefId := unsafe.Pointer(C.CString(revRegDefId))
 	defer C.free(upRevRegDefId)
 	channel := ledger.BuildGetRevocRegDefRequest(upSubmitterDid, upRevRegDefId)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -368,6 +470,11 @@ func BuildGetRevRegDefRequest(submitterDid string, revRegDefId string) (request
 
 // BuildGetCredentialDefinitionRequest Builds an GET_CRED_DEF request.
 func BuildGetCredentialDefinitionRequest(submitterDid string, credDefinition string) (request string, err error) {
+	return BuildGetCredentialDefinitionRequestCtx(context.Background(), submitterDid, credDefinition)
+}
+
+// BuildGetCredentialDefinitionRequestCtx is like BuildGetCredentialDefinitionRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildGetCredentialDefinitionRequestCtx(ctx context.Context, submitterDid string, credDefinition string) (request string, err error) {
 
 	upSubmitterDid := unsafe.Pointer(GetOptionalValue(submitterDid))
 	defer C.free(upSubmitterDid)
@@ -375,7 +482,7 @@ func BuildGetCredentialDefinitionRequest(submitterDid string, credDefinition str
 	defer C.free(upCredDefinition)
 
 	channel := ledger.BuildGetCredDefRequest(upSubmitterDid, upCredDefinition)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -384,6 +491,11 @@ func BuildGetCredentialDefinitionRequest(submitterDid string, credDefinition str
 
 // BuildAuthRuleRequest Builds a AUTH_RULE request.
 func BuildAuthRuleRequest(submitterDid string, txnType string, action string, field string, oldValue string, newValue string, constraint string) (string, error) {
+	return BuildAuthRuleRequestCtx(context.Background(), submitterDid, txnType, action, field, oldValue, newValue, constraint)
+}
+
+// BuildAuthRuleRequestCtx is like BuildAuthRuleRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildAuthRuleRequestCtx(ctx context.Context, submitterDid string, txnType string, action string, field string, oldValue string, newValue string, constraint string) (string, error) {
 
 	upSubmitterDid := unsafe.Pointer(C.CString(submitterDid))
 	defer C.free(upSubmitterDid)
@@ -401,7 +513,7 @@ func BuildAuthRuleRequest(submitterDid string, txnType string, action string, fi
 	defer C.free(upConstraint)
 
 	channel := ledger.BuildAuthRuleRequest(upSubmitterDid, upTxnType, upAction, upField, upOldValue, upNewValue, upConstraint)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -410,12 +522,17 @@ func BuildAuthRuleRequest(submitterDid string, txnType string, action string, fi
 
 // BuildAuthRulesRequest Builds a AUTH_RULES request.
 func BuildAuthRulesRequest(submitterDid string, data string) (string, error) {
+	return BuildAuthRulesRequestCtx(context.Background(), submitterDid, data)
+}
+
+// BuildAuthRulesRequestCtx is like BuildAuthRulesRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildAuthRulesRequestCtx(ctx context.Context, submitterDid string, data string) (string, error) {
 	upSubmitterDid := unsafe.Pointer(C.CString(submitterDid))
 	defer C.free(upSubmitterDid)
 	upData := unsafe.Pointer(C.CString(data))
 	defer C.free(upSubmitterDid)
 	channel := ledger.BuildAuthRulesRequest(upSubmitterDid, upData)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -424,6 +541,11 @@ func BuildAuthRulesRequest(submitterDid string, data string) (string, error) {
 
 // BuildGetAuthRuleRequest Builds a GET_AUTH_RULE request. Request to get authentication rules for a ledger transaction.
 func BuildGetAuthRuleRequest(submitterDid string, txnType string, action string, field string, oldValue string, newValue string) (string, error) {
+	return BuildGetAuthRuleRequestCtx(context.Background(), submitterDid, txnType, action, field, oldValue, newValue)
+}
+
+// BuildGetAuthRuleRequestCtx is like BuildGetAuthRuleRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildGetAuthRuleRequestCtx(ctx context.Context, submitterDid string, txnType string, action string, field string, oldValue string, newValue string) (string, error) {
 
 	upSubmitterDid := unsafe.Pointer(GetOptionalValue(submitterDid))
 	defer C.free(upSubmitterDid)
@@ -439,7 +561,7 @@ func BuildGetAuthRuleRequest(submitterDid string, txnType string, action string,
 	defer C.free(upNewValue)
 
 	channel := ledger.BuildGetAuthRuleRequest(upSubmitterDid, upTxnType, upAction, upField, upOldValue, upNewValue)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -448,6 +570,11 @@ func BuildGetAuthRuleRequest(submitterDid string, txnType string, action string,
 
 // BuildTxnAuthorAgreementRequest Builds a TXN_AUTHR_AGRMT request. Request to add a new version of Transaction Author Agreement to the ledger.
 func BuildTxnAuthorAgreementRequest(submitterDid string, text string, version string, ratificationTs int64, retirementTs int64) (string, error) {
+	return BuildTxnAuthorAgreementRequestCtx(context.Background(), submitterDid, text, version, ratificationTs, retirementTs)
+}
+
+// BuildTxnAuthorAgreementRequestCtx is like BuildTxnAuthorAgreementRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildTxnAuthorAgreementRequestCtx(ctx context.Context, submitterDid string, text string, version string, ratificationTs int64, retirementTs int64) (string, error) {
 
 	upSubmitterDid := unsafe.Pointer(C.CString(submitterDid))
 	defer C.free(upSubmitterDid)
@@ -457,7 +584,7 @@ func BuildTxnAuthorAgreementRequest(submitterDid string, text string, version st
 	defer C.free(upVersion)
 
 	channel := ledger.BuildTxnAuthorAgreementRequest(upSubmitterDid, upText, upVersion, ratificationTs, retirementTs)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -466,11 +593,16 @@ func BuildTxnAuthorAgreementRequest(submitterDid string, text string, version st
 
 // BuildDisableAllTxnAuthorAgreementsRequest Builds a DISABLE_ALL_TXN_AUTHR_AGRMTS request. Request to disable all Transaction Author Agreement on the ledger.
 func BuildDisableAllTxnAuthorAgreementsRequest(submitterDid string) (string, error) {
+	return BuildDisableAllTxnAuthorAgreementsRequestCtx(context.Background(), submitterDid)
+}
+
+// BuildDisableAllTxnAuthorAgreementsRequestCtx is like BuildDisableAllTxnAuthorAgreementsRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildDisableAllTxnAuthorAgreementsRequestCtx(ctx context.Context, submitterDid string) (string, error) {
 	upSubmitterDid := unsafe.Pointer(C.CString(submitterDid))
 	defer C.free(upSubmitterDid)
 
 	channel := ledger.BuildDisableAllTxnAuthorAgreementsRequest(upSubmitterDid)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -479,13 +611,18 @@ func BuildDisableAllTxnAuthorAgreementsRequest(submitterDid string) (string, err
 
 // BuildGetTxnAuthorAgreementRequest  Builds a GET_TXN_AUTHR_AGRMT request. Request to get a specific Transaction Author Agreement from the ledger.
 func BuildGetTxnAuthorAgreementRequest(submitterDid string, data string) (string, error) {
+	return BuildGetTxnAuthorAgreementRequestCtx(context.Background(), submitterDid, data)
+}
+
+// BuildGetTxnAuthorAgreementRequestCtx is like BuildGetTxnAuthorAgreementRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildGetTxnAuthorAgreementRequestCtx(ctx context.Context, submitterDid string, data string) (string, error) {
 	upSubmitterDid := unsafe.Pointer(GetOptionalValue(submitterDid))
 	defer C.free(upSubmitterDid)
 	upData := unsafe.Pointer(GetOptionalValue(data))
 	defer C.free(upData)
 
 	channel := ledger.BuildGetTxnAuthorAgreementRequest(upSubmitterDid, upData)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -494,6 +631,11 @@ func BuildGetTxnAuthorAgreementRequest(submitterDid string, data string) (string
 
 // BuildAcceptanceMechanismsRequest Builds a SET_TXN_AUTHR_AGRMT_AML request. Request to add a new list of acceptance mechanisms for transaction author agreement.
 func BuildAcceptanceMechanismsRequest(submitterDid string, aml string, version string, amlContext string) (string, error) {
+	return BuildAcceptanceMechanismsRequestCtx(context.Background(), submitterDid, aml, version, amlContext)
+}
+
+// BuildAcceptanceMechanismsRequestCtx is like BuildAcceptanceMechanismsRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildAcceptanceMechanismsRequestCtx(ctx context.Context, submitterDid string, aml string, version string, amlContext string) (string, error) {
 	upSubmitterDid := unsafe.Pointer(C.CString(submitterDid))
 	defer C.free(upSubmitterDid)
 	upAml := unsafe.Pointer(C.CString(aml))
@@ -504,7 +646,7 @@ func BuildAcceptanceMechanismsRequest(submitterDid string, aml string, version s
 	defer C.free(upAmlContext)
 
 	channel := ledger.BuildAcceptanceMechanismsRequest(upSubmitterDid, upAml, upVersion, upAmlContext)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -513,13 +655,18 @@ func BuildAcceptanceMechanismsRequest(submitterDid string, aml string, version s
 
 // BuildGetAcceptanceMechanismsRequest Builds a GET_TXN_AUTHR_AGRMT_AML request.
 func BuildGetAcceptanceMechanismsRequest(submitterDid string, timestamp int64, version string) (string, error) {
+	return BuildGetAcceptanceMechanismsRequestCtx(context.Background(), submitterDid, timestamp, version)
+}
+
+// BuildGetAcceptanceMechanismsRequestCtx is like BuildGetAcceptanceMechanismsRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildGetAcceptanceMechanismsRequestCtx(ctx context.Context, submitterDid string, timestamp int64, version string) (string, error) {
 	upSubmitterDid := unsafe.Pointer(GetOptionalValue(submitterDid))
 	defer C.free(upSubmitterDid)
 	upVersion := unsafe.Pointer(GetOptionalValue(version))
 	defer C.free(upVersion)
 
 	channel := ledger.BuildGetAcceptanceMechanismsRequest(upSubmitterDid, timestamp, upVersion)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -528,12 +675,17 @@ func BuildGetAcceptanceMechanismsRequest(submitterDid string, timestamp int64, v
 
 // ParseGetRevocRegResponse Parse a GET_REVOC_REG response to get Revocation Registry in the format compatible with Anoncreds API.
 func ParseGetRevocRegResponse(getRevRegResp string) (revRegId string, revRegistryDeltaJson string, timestamp uint64, err error) {
+	return ParseGetRevocRegResponseCtx(context.Background(), getRevRegResp)
+}
+
+// ParseGetRevocRegResponseCtx is like ParseGetRevocRegResponse but returns ctx.Err() if ctx is done before libindy answers.
+func ParseGetRevocRegResponseCtx(ctx context.Context, getRevRegResp string) (revRegId string, revRegistryDeltaJson string, timestamp uint64, err error) {
 
 	upGetRevRegResp := unsafe.Pointer(C.CString(getRevRegResp))
 	defer C.free(upGetRevRegResp)
 
 	channel := ledger.ParseGetRevocRegResponse(upGetRevRegResp)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", "", 0, result.Error
 	}
@@ -542,11 +694,16 @@ func ParseGetRevocRegResponse(getRevRegResp string) (revRegId string, revRegistr
 
 // ParseGetRevocRegDeltaResponse Parse a GET_REVOC_REG_DELTA response to get Revocation Registry Delta in the format compatible with Anoncreds API.
 func ParseGetRevocRegDeltaResponse(getRevRegDeltaResp string) (revRegId string, revRegistryDeltaJson string, timestamp uint64, err error) {
+	return ParseGetRevocRegDeltaResponseCtx(context.Background(), getRevRegDeltaResp)
+}
+
+// ParseGetRevocRegDeltaResponseCtx is like ParseGetRevocRegDeltaResponse but returns ctx.Err() if ctx is done before libindy answers.
+func ParseGetRevocRegDeltaResponseCtx(ctx context.Context, getRevRegDeltaResp string) (revRegId string, revRegistryDeltaJson string, timestamp uint64, err error) {
 	upGetRevRegDeltaResp := unsafe.Pointer(C.CString(getRevRegDeltaResp))
 	defer C.free(upGetRevRegDeltaResp)
 
 	channel := ledger.ParseGetRevocRegDeltaResponse(upGetRevRegDeltaResp)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", "", 0, result.Error
 	}
@@ -555,11 +712,16 @@ func ParseGetRevocRegDeltaResponse(getRevRegDeltaResp string) (revRegId string,
 
 // ParseGetRevocRegDefResponse - parse a rev reg def response
 func ParseGetRevocRegDefResponse(getRevocRegDefResponse string) (revRegId string, revRegistryDefJson string, err error) {
+	return ParseGetRevocRegDefResponseCtx(context.Background(), getRevocRegDefResponse)
+}
+
+// ParseGetRevocRegDefResponseCtx is like ParseGetRevocRegDefResponse but returns ctx.Err() if ctx is done before libindy answers.
+func ParseGetRevocRegDefResponseCtx(ctx context.Context, getRevocRegDefResponse string) (revRegId string, revRegistryDefJson string, err error) {
 	upGetRevRegDefResp := unsafe.Pointer(C.CString(getRevocRegDefResponse))
 	defer C.free(upGetRevRegDefResp)
 
 	channel := ledger.ParseGetRevocRegDefResponse(upGetRevRegDefResp)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", "", result.Error
 	}
@@ -568,11 +730,16 @@ func ParseGetRevocRegDefResponse(getRevocRegDefResponse string) (revRegId string
 
 // ParseGetSchemaResponse - parse a schema response
 func ParseGetSchemaResponse(schemaResponse string) (schemaId string, schemaJson string, err error) {
+	return ParseGetSchemaResponseCtx(context.Background(), schemaResponse)
+}
+
+// ParseGetSchemaResponseCtx is like ParseGetSchemaResponse but returns ctx.Err() if ctx is done before libindy answers.
+func ParseGetSchemaResponseCtx(ctx context.Context, schemaResponse string) (schemaId string, schemaJson string, err error) {
 	upSchemaResp := unsafe.Pointer(C.CString(schemaResponse))
 	defer C.free(upSchemaResp)
 
 	channel := ledger.ParseGetSchemaResponse(upSchemaResp)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", "", result.Error
 	}
@@ -581,11 +748,16 @@ func ParseGetSchemaResponse(schemaResponse string) (schemaId string, schemaJson
 
 // ParseGetNymResponse Parse a GET_NYM response to get NYM data.
 func ParseGetNymResponse(nymResponse string) (nymData string, err error) {
+	return ParseGetNymResponseCtx(context.Background(), nymResponse)
+}
+
+// ParseGetNymResponseCtx is like ParseGetNymResponse but returns ctx.Err() if ctx is done before libindy answers.
+func ParseGetNymResponseCtx(ctx context.Context, nymResponse string) (nymData string, err error) {
 	upNymResp := unsafe.Pointer(C.CString(nymResponse))
 	defer C.free(upNymResp)
 
 	channel := ledger.ParseGetNymResponse(upNymResp)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -594,30 +766,48 @@ func ParseGetNymResponse(nymResponse string) (nymData string, err error) {
 
 // ParseGetCredDefResponse - parse a GET_CRED_DEF response
 func ParseGetCredDefResponse(getCredDefResp string) (credDefId string, credDefJson string, err error) {
+	return ParseGetCredDefResponseCtx(context.Background(), getCredDefResp)
+}
+
+// ParseGetCredDefResponseCtx is like ParseGetCredDefResponse but returns ctx.Err() if ctx is done before libindy answers.
+func ParseGetCredDefResponseCtx(ctx context.Context, getCredDefResp string) (credDefId string, credDefJson string, err error) {
 	upGetCredDefResp := unsafe.Pointer(C.CString(getCredDefResp))
 	defer C.free(upGetCredDefResp)
 
 	channel := ledger.ParseGetCredDefResponse(upGetCredDefResp)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", "", result.Error
 	}
 	return result.Results[0].(string), result.Results[1].(string), result.Error
 }
 
-// SignAndSubmitRequest sends a request to the blockchain and returns the result
+// SignAndSubmitRequest sends a request to the blockchain and returns the result. With SetPoolTaaAutoAppend
+// the stored transaction author agreement acceptance is appended to the request before signing.
 func SignAndSubmitRequest(ph int, wh int, did string, request string) (response string, err error) {
+	return SignAndSubmitRequestCtx(context.Background(), ph, wh, did, request)
+}
+
+// SignAndSubmitRequestCtx is like SignAndSubmitRequest but returns ctx.Err() if ctx is done before libindy answers.
+func SignAndSubmitRequestCtx(ctx context.Context, ph int, wh int, did string, request string) (response string, err error) {
+
+	request, errTaa := taaAutoAppend(ctx, ph, wh, did, request)
+	if errTaa != nil {
+		return "", errTaa
+	}
 
 	upDid := unsafe.Pointer(C.CString(did))
 	defer C.free(upDid)
 	upRequest := unsafe.Pointer(C.CString(request))
 	defer C.free(upRequest)
 
-	indyRequest.TryLockWithTimeout(60 * time.Second)
-	defer indyRequest.Unlock()
+	release, errSlot := requestScheduler.Acquire(ctx, ph)
+	if errSlot != nil {
+		return "", errSlot
+	}
 
 	channel := ledger.SignAndSubmitRequest(ph, wh, upDid, upRequest)
-	result := <-channel
+	result := indyUtils.WaitForRequest(ctx, channel, release)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -627,14 +817,61 @@ func SignAndSubmitRequest(ph int, wh int, did string, request string) (response
 
 // SubmitRequest sends a request to the blockchain and returns the result
 func SubmitRequest(ph int, request string) (response string, err error) {
+	return SubmitRequestCtx(context.Background(), ph, request)
+}
+
+// SubmitRequestCtx is like SubmitRequest but returns ctx.Err() if ctx is done before libindy answers.
+func SubmitRequestCtx(ctx context.Context, ph int, request string) (response string, err error) {
 	upRequest := unsafe.Pointer(C.CString(request))
 	defer C.free(upRequest)
 
-	indyRequest.TryLockWithTimeout(60 * time.Second)
-	defer indyRequest.Unlock()
+	release, errSlot := requestScheduler.Acquire(ctx, ph)
+	if errSlot != nil {
+		return "", errSlot
+	}
 
 	channel := ledger.SubmitRequest(ph, upRequest)
-	result := <-channel
+	result := indyUtils.WaitForRequest(ctx, channel, release)
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Results[0].(string), result.Error
+}
+
+// SubmitAction sends an action request (GET_VALIDATOR_INFO, POOL_RESTART) to the given nodes, all nodes
+// of the pool when nodes is empty. timeout is in seconds, <= 0 uses the pool timeout.
+// Returns a json object of node name to the node reply or "timeout", see types.ParseActionReplies.
+func SubmitAction(ph int, request string, nodes []string, timeout int) (response string, err error) {
+	return SubmitActionCtx(context.Background(), ph, request, nodes, timeout)
+}
+
+// SubmitActionCtx is like SubmitAction but returns ctx.Err() if ctx is done before libindy answers.
+func SubmitActionCtx(ctx context.Context, ph int, request string, nodes []string, timeout int) (response string, err error) {
+	upRequest := unsafe.Pointer(C.CString(request))
+	defer C.free(upRequest)
+
+	nodesJson := ""
+	if len(nodes) > 0 {
+		nodesB, errJ := json.Marshal(nodes)
+		if errJ != nil {
+			return "", errJ
+		}
+		nodesJson = string(nodesB)
+	}
+	upNodes := unsafe.Pointer(GetOptionalValue(nodesJson))
+	defer C.free(upNodes)
+
+	if timeout <= 0 {
+		timeout = -1
+	}
+
+	release, errSlot := requestScheduler.Acquire(ctx, ph)
+	if errSlot != nil {
+		return "", errSlot
+	}
+
+	channel := ledger.SubmitAction(ph, upRequest, upNodes, int32(timeout))
+	result := indyUtils.WaitForRequest(ctx, channel, release)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -643,13 +880,18 @@ func SubmitRequest(ph int, request string) (response string, err error) {
 
 // SignRequest signs request message
 func SignRequest(wh int, did string, request string) (response string, err error) {
+	return SignRequestCtx(context.Background(), wh, did, request)
+}
+
+// SignRequestCtx is like SignRequest but returns ctx.Err() if ctx is done before libindy answers.
+func SignRequestCtx(ctx context.Context, wh int, did string, request string) (response string, err error) {
 	upDid := unsafe.Pointer(C.CString(did))
 	defer C.free(upDid)
 	upRequest := unsafe.Pointer(C.CString(request))
 	defer C.free(upRequest)
 
 	channel := ledger.SignRequest(wh, upDid, upRequest)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", err
 	}
@@ -658,13 +900,18 @@ func SignRequest(wh int, did string, request string) (response string, err error
 
 // AppendRequestEndorser append an endorser to the request
 func AppendRequestEndorser(request, endorserDID string) (response string, err error) {
+	return AppendRequestEndorserCtx(context.Background(), request, endorserDID)
+}
+
+// AppendRequestEndorserCtx is like AppendRequestEndorser but returns ctx.Err() if ctx is done before libindy answers.
+func AppendRequestEndorserCtx(ctx context.Context, request, endorserDID string) (response string, err error) {
 	upRequest := unsafe.Pointer(C.CString(request))
 	defer C.free(upRequest)
 	upEndorserDid := unsafe.Pointer(C.CString(endorserDID))
 	defer C.free(upEndorserDid)
 
 	channel := ledger.AppendRequestEndorser(upRequest, upEndorserDid)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -673,13 +920,18 @@ func AppendRequestEndorser(request, endorserDID string) (response string, err er
 
 // MultiSignRequest signs a request
 func MultiSignRequest(wh int, did string, request string) (response string, err error) {
+	return MultiSignRequestCtx(context.Background(), wh, did, request)
+}
+
+// MultiSignRequestCtx is like MultiSignRequest but returns ctx.Err() if ctx is done before libindy answers.
+func MultiSignRequestCtx(ctx context.Context, wh int, did string, request string) (response string, err error) {
 	upRequest := unsafe.Pointer(C.CString(request))
 	defer C.free(upRequest)
 	upDid := unsafe.Pointer(C.CString(did))
 	defer C.free(upDid)
 
 	channel := ledger.MultiSignRequest(wh, upDid, upRequest)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -688,11 +940,16 @@ func MultiSignRequest(wh int, did string, request string) (response string, err
 
 // GetResponseMetadata Parse transaction response to fetch metadata.
 func GetResponseMetadata(response string) (metadataResponse string, err error) {
+	return GetResponseMetadataCtx(context.Background(), response)
+}
+
+// GetResponseMetadataCtx is like GetResponseMetadata but returns ctx.Err() if ctx is done before libindy answers.
+func GetResponseMetadataCtx(ctx context.Context, response string) (metadataResponse string, err error) {
 	upResponse := unsafe.Pointer(C.CString(response))
 	defer C.free(upResponse)
 
 	channel := ledger.GetResponseMetadata(upResponse)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -701,6 +958,11 @@ func GetResponseMetadata(response string) (metadataResponse string, err error) {
 
 // AppendTxnAuthorAgreementAcceptanceToRequest Append transaction author agreement acceptance data to a request.
 func AppendTxnAuthorAgreementAcceptanceToRequest(requestJson string, text string, version string, taaDigest string, mechanism string, time int64) (string, error) {
+	return AppendTxnAuthorAgreementAcceptanceToRequestCtx(context.Background(), requestJson, text, version, taaDigest, mechanism, time)
+}
+
+// AppendTxnAuthorAgreementAcceptanceToRequestCtx is like AppendTxnAuthorAgreementAcceptanceToRequest but returns ctx.Err() if ctx is done before libindy answers.
+func AppendTxnAuthorAgreementAcceptanceToRequestCtx(ctx context.Context, requestJson string, text string, version string, taaDigest string, mechanism string, time int64) (string, error) {
 
 	upRequest := unsafe.Pointer(C.CString(requestJson))
 	defer C.free(upRequest)
@@ -714,7 +976,7 @@ func AppendTxnAuthorAgreementAcceptanceToRequest(requestJson string, text string
 	defer C.free(upMech)
 
 	channel := ledger.AppendTxnAuthorAgreementAcceptanceToRequest(upRequest, upText, upVersion, upTaaDigest, upMech, time)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -40,7 +40,6 @@ extern void multiSignRequestCB(indy_handle_t, indy_error_t, char*);
 import "C"
 import (
 	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
-	"errors"
 	"unsafe"
 )
 
@@ -53,8 +52,8 @@ func multiSignRequestCB(commandHandle C.indy_handle_t, indyError C.indy_error_t,
 					string(C.GoString(request)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -85,8 +84,8 @@ func MultiSignRequest(wh int, did, request unsafe.Pointer) chan indyUtils.IndyRe
 		(C.cb_multiSignRequest)(unsafe.Pointer(C.multiSignRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -102,8 +101,8 @@ func appendRequestEndorserCB(commandHandle C.indy_handle_t, indyError C.indy_err
 					string(C.GoString(request)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -139,8 +138,8 @@ func AppendRequestEndorser(request, endorserDid unsafe.Pointer) chan indyUtils.I
 		(C.cb_appendRequestEndorser)(unsafe.Pointer(C.appendRequestEndorserCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -158,8 +157,8 @@ func parseGetResponseDeltaCB(commandHandle C.indy_handle_t, indyError C.indy_err
 					uint64(timestamp),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -187,8 +186,8 @@ func ParseGetRevocRegResponse(getRevRegResp unsafe.Pointer) chan indyUtils.IndyR
 		(C.cb_parseGetResponseDelta)(unsafe.Pointer(C.parseGetResponseDeltaCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -222,8 +221,8 @@ func ParseGetRevocRegDeltaResponse(getRevRegDeltaResp unsafe.Pointer) chan indyU
 		(C.cb_parseGetResponseDelta)(unsafe.Pointer(C.parseGetResponseDeltaCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -240,8 +239,8 @@ func parseGetResponseCB(commandHandle C.indy_handle_t, indyError C.indy_error_t,
 					string(C.GoString(objJSON)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -277,8 +276,8 @@ func ParseGetRevocRegDefResponse(getRevocRegDefResponse unsafe.Pointer) chan ind
 		(C.cb_parseGetResponse)(unsafe.Pointer(C.parseGetResponseCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -314,8 +313,8 @@ func ParseGetCredDefResponse(getCredDefResp unsafe.Pointer) chan indyUtils.IndyR
 		(C.cb_parseGetResponse)(unsafe.Pointer(C.parseGetResponseCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -347,8 +346,8 @@ func ParseGetSchemaResponse(schemaResponse unsafe.Pointer) chan indyUtils.IndyRe
 		(C.cb_parseGetResponse)(unsafe.Pointer(C.parseGetResponseCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -364,8 +363,8 @@ func parseGetNymResponseCB(commandHandle C.indy_handle_t, indyError C.indy_error
 					string(C.GoString(nymJson)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -399,8 +398,8 @@ func ParseGetNymResponse(getNymResponse unsafe.Pointer) chan indyUtils.IndyResul
 		(C.cb_parseGetNymResponse)(unsafe.Pointer(C.parseGetNymResponseCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -416,8 +415,8 @@ func buildRequestCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, req
 					string(C.GoString(request)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -450,8 +449,8 @@ func BuildGetRevocRegDeltaRequest(submitterDid, revocRegDefId unsafe.Pointer, fr
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -495,8 +494,8 @@ func BuildRevocRegEntryRequest(submitterDid, revocRegDefId, revDefType, value un
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -540,8 +539,8 @@ func BuildRevocRegDefRequest(submitterDid, data unsafe.Pointer) chan indyUtils.I
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -571,8 +570,8 @@ func BuildGetRevocRegRequest(submitterDid, revRegDefId unsafe.Pointer, timeStamp
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -600,8 +599,8 @@ func BuildGetRevocRegDefRequest(submitterDid, revRegDefId unsafe.Pointer) chan i
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -629,8 +628,8 @@ func BuildGetCredDefRequest(submitterDid, credDefId unsafe.Pointer) chan indyUti
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -665,8 +664,8 @@ func BuildSchemaRequest(submitterDid, schema unsafe.Pointer) chan indyUtils.Indy
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -694,8 +693,8 @@ func BuildGetSchemaRequest(submitterDid unsafe.Pointer, schemaId unsafe.Pointer)
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -735,8 +734,8 @@ func BuildCredentialDefinitionRequest(submitterDid, credDefJson unsafe.Pointer)
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -766,8 +765,8 @@ func BuildGetDdoRequest(submitterDid, targetDid unsafe.Pointer) chan indyUtils.I
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -809,8 +808,8 @@ func BuildNymRequest(submitterDid, targetDid, verkey, alias, role unsafe.Pointer
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -846,8 +845,8 @@ func BuildAttribRequest(submitterDid, targetDid, raw, hash, enc unsafe.Pointer)
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -882,8 +881,8 @@ func BuildGetAttribRequest(submitterDid, targetDid, raw, hash, enc unsafe.Pointe
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -911,8 +910,8 @@ func BuildGetNymRequest(submitterDid, targetDid unsafe.Pointer) chan indyUtils.I
 		(*C.char)(targetDid),
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -952,8 +951,8 @@ func BuildNodeRequest(submitterDid, targetDid, data unsafe.Pointer) chan indyUti
 		(*C.char)(data),
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -979,8 +978,8 @@ func BuildGetValidatorInfoRequest(submitterDid unsafe.Pointer) chan indyUtils.In
 		(*C.char)(submitterDid),
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -1013,8 +1012,8 @@ func BuildGetTxnRequest(submitterDid, ledgerType unsafe.Pointer, seqNo int) chan
 		(C.indy_i32_t)(seqNo),
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -1046,8 +1045,8 @@ func BuildPoolConfigRequest(submitterDid unsafe.Pointer, writes bool, force bool
 		(C.indy_bool_t)(force),
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -1076,8 +1075,8 @@ func BuildPoolRestartRequest(submitterDid, action, dateTime unsafe.Pointer) chan
 		(*C.char)(dateTime),
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -1134,8 +1133,8 @@ func BuildPoolUpgradeRequest(submitterDid, name, version, action, sha256 unsafe.
 		(*C.char)(package_),
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -1190,8 +1189,8 @@ func BuildAuthRuleRequest(submitterDid, txnType, action, field, oldValue, newVal
 		(*C.char)(constraint),
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -1229,8 +1228,8 @@ func BuildAuthRulesRequest(submitterDid, data unsafe.Pointer) chan indyUtils.Ind
 		(*C.char)(data),
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -1268,8 +1267,8 @@ func BuildGetAuthRuleRequest(submitterDid, txnType, action, field, oldValue, new
 		(*C.char)(newValue),
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -1334,8 +1333,8 @@ func BuildTxnAuthorAgreementRequest(submitterDid, text, version unsafe.Pointer,
 		indyRetirementTs,
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -1362,8 +1361,8 @@ func BuildDisableAllTxnAuthorAgreementsRequest(submitterDid unsafe.Pointer) chan
 		(*C.char)(submitterDid),
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -1399,8 +1398,8 @@ func BuildGetTxnAuthorAgreementRequest(submitterDid, data unsafe.Pointer) chan i
 		(*C.char)(data),
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -1439,8 +1438,8 @@ func BuildAcceptanceMechanismsRequest(submitterDid, aml, version, amlContext uns
 		(*C.char)(amlContext),
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -1473,8 +1472,8 @@ func BuildGetAcceptanceMechanismsRequest(submitterDid unsafe.Pointer, timestamp
 		(*C.char)(version),
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 	return future
@@ -1489,8 +1488,8 @@ func signAndSubmitRequestCB(commandHandle C.indy_handle_t, indyError C.indy_erro
 					string(C.GoString(response)),
 				}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -1524,8 +1523,8 @@ func SignAndSubmitRequest(poolHandle int, walletHandle int, submitterDid, reques
 		(C.cb_signAndSubmitRequest)(unsafe.Pointer(C.signAndSubmitRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -1553,8 +1552,50 @@ func SubmitRequest(poolHandle int, requestJson unsafe.Pointer) chan indyUtils.In
 		(C.cb_signAndSubmitRequest)(unsafe.Pointer(C.signAndSubmitRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
+		return future
+	}
+
+	return future
+}
+
+// SubmitAction Sends an action (GET_VALIDATOR_INFO, POOL_RESTART) to the given nodes of the pool
+func SubmitAction(poolHandle int, requestJson, nodes unsafe.Pointer, timeout int32) chan indyUtils.IndyResult {
+
+	// Prepare the call parameters
+	handle, future := indyUtils.NewFutureCommand()
+	commandHandle := (C.indy_handle_t)(handle)
+
+	/*
+	   Send action to particular nodes of validator pool.
+
+	   The list of requests can be send:
+	       POOL_RESTART
+	       GET_VALIDATOR_INFO
+
+	   The request is sent to the nodes as is. It's assumed that it's already prepared.
+
+	   :param pool_handle: pool handle (created by open_pool_ledger).
+	   :param request_json: Request data json.
+	   :param nodes: (Optional) List of node names to send the request.
+	          ["Node1", "Node2",...."NodeN"]
+	   :param timeout: (Optional) Time to wait respond from nodes (override the default timeout) (in sec).
+	                   Pass -1 to use default timeout
+	   :return: Request result as json, a map of node name to the node reply or "timeout".
+	*/
+
+	// Call to indy function
+	res := C.indy_submit_action(commandHandle,
+		(C.indy_handle_t)(poolHandle),
+		(*C.char)(requestJson),
+		(*C.char)(nodes),
+		(C.indy_i32_t)(timeout),
+		(C.cb_signAndSubmitRequest)(unsafe.Pointer(C.signAndSubmitRequestCB)))
+
+	if res != 0 {
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -1587,8 +1628,8 @@ func SignRequest(walletHandle int, submitterDid, requestJson unsafe.Pointer) cha
 		(C.cb_signAndSubmitRequest)(unsafe.Pointer(C.signAndSubmitRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -1633,8 +1674,8 @@ func GetResponseMetadata(response unsafe.Pointer) chan indyUtils.IndyResult {
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -1680,8 +1721,8 @@ func AppendTxnAuthorAgreementAcceptanceToRequest(requestJson, text, version, taa
 		(C.cb_buildRequest)(unsafe.Pointer(C.buildRequestCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -0,0 +1,91 @@
+/*
+// ******************************************************************
+// Purpose: typed models of the node replies to ledger actions
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// actionTimeout reply libindy reports for a node that didn't answer an action in time
+const actionTimeout = "timeout"
+
+// ErrNodeTimeout is returned by NodeReply.Err when the node didn't answer in time
+var ErrNodeTimeout = errors.New("node did not answer in time")
+
+// NodeReply reply of one node to an action sent with SubmitAction
+type NodeReply struct {
+	Raw      string // reply as returned by the node
+	TimedOut bool
+	Reply    *Reply // nil when TimedOut or the node answer is not a reply json
+}
+
+// Err returns ErrNodeTimeout, ErrRequestRejected or an error for an unreadable node answer
+func (n NodeReply) Err() error {
+	if n.TimedOut {
+		return ErrNodeTimeout
+	}
+	if n.Reply == nil {
+		return fmt.Errorf("unexpected node reply: %s", n.Raw)
+	}
+	return n.Reply.Err()
+}
+
+// ActionReplies replies to an action keyed by node name
+type ActionReplies map[string]NodeReply
+
+// ParseActionReplies parses the result of SubmitAction, a json object of node name to the node reply
+// (a reply json encoded as string or "timeout")
+func ParseActionReplies(resultJson string) (ActionReplies, error) {
+	var raw map[string]string
+	err := json.Unmarshal([]byte(resultJson), &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	replies := make(ActionReplies, len(raw))
+	for node, answer := range raw {
+		nodeReply := NodeReply{Raw: answer}
+		if answer == actionTimeout {
+			nodeReply.TimedOut = true
+		} else if reply, errR := ParseReply(answer); errR == nil {
+			nodeReply.Reply = reply
+		}
+		replies[node] = nodeReply
+	}
+	return replies, nil
+}
+
+// Succeeded returns the sorted names of the nodes that answered with a REPLY
+func (a ActionReplies) Succeeded() []string {
+	var nodes []string
+	for node, nodeReply := range a {
+		if nodeReply.Err() == nil {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// Failed returns the error of every node that timed out, rejected the action or answered something unreadable
+func (a ActionReplies) Failed() map[string]error {
+	failed := make(map[string]error)
+	for node, nodeReply := range a {
+		if err := nodeReply.Err(); err != nil {
+			failed[node] = err
+		}
+	}
+	return failed
+}
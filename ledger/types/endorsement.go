@@ -0,0 +1,144 @@
+/*
+// ******************************************************************
+// Purpose: portable envelope of a request sent by its author to an endorser
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Ledger roles, GET_NYM replies carry the codes
+const (
+	RoleTrustee        = "0"
+	RoleSteward        = "2"
+	RoleEndorser       = "101"
+	RoleNetworkMonitor = "201"
+)
+
+// ErrEndorsementRejected is returned when an endorser refuses to counter-sign a request
+var ErrEndorsementRejected = errors.New("endorsement rejected")
+
+// CanWriteWithoutEndorser reports whether a NYM role may send domain writes on its own,
+// DIDs without a role (or network monitors) need an endorser
+func CanWriteWithoutEndorser(role string) bool {
+	switch role {
+	case RoleTrustee, RoleSteward, RoleEndorser, "TRUSTEE", "STEWARD", "ENDORSER":
+		return true
+	}
+	return false
+}
+
+// EndorsementEnvelope request built and signed by its author, waiting for the endorser signature.
+// The request is kept verbatim so the author signature stays valid.
+type EndorsementEnvelope struct {
+	AuthorDid   string          `json:"author_did"`
+	EndorserDid string          `json:"endorser_did"`
+	Request     json.RawMessage `json:"request"`
+}
+
+// ParseEndorsementEnvelope unmarshals an envelope serialised with EndorsementEnvelope.Json
+func ParseEndorsementEnvelope(envelopeJson string) (*EndorsementEnvelope, error) {
+	var envelope EndorsementEnvelope
+	err := json.Unmarshal([]byte(envelopeJson), &envelope)
+	if err != nil {
+		return nil, err
+	}
+	if len(envelope.Request) == 0 {
+		return nil, errors.New("endorsement envelope without request")
+	}
+	return &envelope, nil
+}
+
+// Json serialises the envelope to send it to the endorser
+func (e *EndorsementEnvelope) Json() (string, error) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// VerifyAuthorSignature checks the author signature of the request with the author verkey, e.g. from KeyForDid.
+// Errors wrap ErrEndorsementRejected.
+func (e *EndorsementEnvelope) VerifyAuthorSignature(authorVerkey string) error {
+	request, err := ParseRequest(string(e.Request))
+	if err != nil {
+		return fmt.Errorf("%w: invalid request: %v", ErrEndorsementRejected, err)
+	}
+	signature := request.Signatures[e.AuthorDid]
+	if signature == "" {
+		return fmt.Errorf("%w: request is not signed by the author", ErrEndorsementRejected)
+	}
+	err = VerifyRequestSignature(string(e.Request), signature, e.AuthorDid, authorVerkey)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrEndorsementRejected, err)
+	}
+	return nil
+}
+
+// EndorsementPolicy what an endorser accepts to counter-sign
+type EndorsementPolicy struct {
+	AllowedTxnTypes []string                     // empty allows every transaction type
+	AllowedAuthors  []string                     // empty allows every author
+	Validate        func(request *Request) error // optional check of the operation contents
+}
+
+// Check validates the envelope for the endorser: the request names the endorser, carries a signature of its
+// author only and satisfies the policy. Errors wrap ErrEndorsementRejected. The author signature is only checked
+// for presence, verify it with EndorsementEnvelope.VerifyAuthorSignature before endorsing.
+func (p EndorsementPolicy) Check(envelope *EndorsementEnvelope, endorserDid string) (*Request, error) {
+	request, err := ParseRequest(string(envelope.Request))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid request: %v", ErrEndorsementRejected, err)
+	}
+
+	switch {
+	case request.Endorser != endorserDid:
+		return nil, fmt.Errorf("%w: request endorser is '%s', not '%s'", ErrEndorsementRejected, request.Endorser, endorserDid)
+	case request.Identifier != envelope.AuthorDid:
+		return nil, fmt.Errorf("%w: request identifier '%s' is not the author '%s'", ErrEndorsementRejected, request.Identifier, envelope.AuthorDid)
+	case len(request.Signature) > 0:
+		return nil, fmt.Errorf("%w: request is single signed, the author must multi sign it", ErrEndorsementRejected)
+	case len(request.Signatures[envelope.AuthorDid]) == 0:
+		return nil, fmt.Errorf("%w: request is not signed by the author", ErrEndorsementRejected)
+	case len(request.Signatures[endorserDid]) > 0:
+		return nil, fmt.Errorf("%w: request is already endorsed", ErrEndorsementRejected)
+	}
+
+	txnType, err := request.OperationType()
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid operation: %v", ErrEndorsementRejected, err)
+	}
+	if len(p.AllowedTxnTypes) > 0 && !contains(p.AllowedTxnTypes, txnType) {
+		return nil, fmt.Errorf("%w: transaction type %s not allowed", ErrEndorsementRejected, txnType)
+	}
+	if len(p.AllowedAuthors) > 0 && !contains(p.AllowedAuthors, envelope.AuthorDid) {
+		return nil, fmt.Errorf("%w: author %s not allowed", ErrEndorsementRejected, envelope.AuthorDid)
+	}
+	if p.Validate != nil {
+		errV := p.Validate(request)
+		if errV != nil {
+			return nil, fmt.Errorf("%w: %v", ErrEndorsementRejected, errV)
+		}
+	}
+	return request, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
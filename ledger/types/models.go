@@ -0,0 +1,142 @@
+/*
+// ******************************************************************
+// Purpose: typed models of the ledger objects read and written by the requests
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package types
+
+import (
+	"encoding/json"
+)
+
+// Nym identity written by NYM and returned by ParseGetNymResponse
+type Nym struct {
+	Did    string `json:"did"`
+	Verkey string `json:"verkey,omitempty"`
+	Alias  string `json:"alias,omitempty"`
+	Role   string `json:"role,omitempty"` // TRUSTEE, STEWARD, ENDORSER, NETWORK_MONITOR or empty
+}
+
+// Attrib attribute of a NYM, exactly one of Raw, Hash, Enc is set
+type Attrib struct {
+	Dest string `json:"dest"`
+	Raw  string `json:"raw,omitempty"` // json string, e.g. {"endpoint":{"ha":"127.0.0.1:5555"}}
+	Hash string `json:"hash,omitempty"`
+	Enc  string `json:"enc,omitempty"`
+}
+
+// Schema in the anoncreds format
+type Schema struct {
+	Ver       string   `json:"ver"`
+	Id        string   `json:"id"`
+	Name      string   `json:"name"`
+	Version   string   `json:"version"`
+	AttrNames []string `json:"attrNames"`
+	SeqNo     uint64   `json:"seqNo,omitempty"`
+}
+
+// CredentialDefinition in the anoncreds format, the keys are kept opaque
+type CredentialDefinition struct {
+	Ver      string          `json:"ver"`
+	Id       string          `json:"id"`
+	SchemaId string          `json:"schemaId"`
+	Type     string          `json:"type"`
+	Tag      string          `json:"tag"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// RevocRegDef revocation registry definition in the anoncreds format
+type RevocRegDef struct {
+	Ver          string           `json:"ver"`
+	Id           string           `json:"id"`
+	RevocDefType string           `json:"revocDefType"`
+	Tag          string           `json:"tag"`
+	CredDefId    string           `json:"credDefId"`
+	Value        RevocRegDefValue `json:"value"`
+}
+
+// RevocRegDefValue configuration of a revocation registry
+type RevocRegDefValue struct {
+	IssuanceType  string          `json:"issuanceType"`
+	MaxCredNum    uint32          `json:"maxCredNum"`
+	PublicKeys    json.RawMessage `json:"publicKeys"`
+	TailsHash     string          `json:"tailsHash"`
+	TailsLocation string          `json:"tailsLocation"`
+}
+
+// RevocRegDelta revocation registry delta (or full registry) in the anoncreds format
+type RevocRegDelta struct {
+	Ver   string             `json:"ver"`
+	Value RevocRegDeltaValue `json:"value"`
+}
+
+// RevocRegDeltaValue accumulators and indexes changed by the delta
+type RevocRegDeltaValue struct {
+	PrevAccum string   `json:"prevAccum,omitempty"`
+	Accum     string   `json:"accum"`
+	Issued    []uint32 `json:"issued,omitempty"`
+	Revoked   []uint32 `json:"revoked,omitempty"`
+}
+
+// TxnAuthorAgreement transaction author agreement
+type TxnAuthorAgreement struct {
+	Text           string `json:"text"`
+	Version        string `json:"version"`
+	Digest         string `json:"digest,omitempty"`
+	RatificationTs int64  `json:"ratification_ts,omitempty"`
+	RetirementTs   int64  `json:"retirement_ts,omitempty"`
+}
+
+// AcceptanceMechanisms list of acceptance mechanisms (AML) of the transaction author agreement
+type AcceptanceMechanisms struct {
+	Aml        map[string]string `json:"aml"`
+	Version    string            `json:"version"`
+	AmlContext string            `json:"amlContext,omitempty"`
+}
+
+// AuthConstraint constraint of an auth rule, either a role constraint or a combination (AND / OR) of constraints
+type AuthConstraint struct {
+	ConstraintId       string           `json:"constraint_id"` // ROLE, AND, OR, FORBIDDEN
+	Role               *string          `json:"role,omitempty"`
+	SigCount           *uint32          `json:"sig_count,omitempty"`
+	NeedToBeOwner      *bool            `json:"need_to_be_owner,omitempty"`
+	OffLedgerSignature *bool            `json:"off_ledger_signature,omitempty"`
+	Metadata           json.RawMessage  `json:"metadata,omitempty"`
+	AuthConstraints    []AuthConstraint `json:"auth_constraints,omitempty"`
+}
+
+// AuthRule rule of AUTH_RULE / AUTH_RULES / GET_AUTH_RULE
+type AuthRule struct {
+	AuthType   string         `json:"auth_type"`
+	AuthAction string         `json:"auth_action"` // ADD or EDIT
+	Field      string         `json:"field"`
+	OldValue   *string        `json:"old_value,omitempty"`
+	NewValue   *string        `json:"new_value,omitempty"`
+	Constraint AuthConstraint `json:"constraint"`
+}
+
+// Txn transaction as written on the ledger, returned by GET_TXN and by write replies
+type Txn struct {
+	Type            string          `json:"type"`
+	ProtocolVersion int             `json:"protocolVersion,omitempty"`
+	Data            json.RawMessage `json:"data"`
+	Metadata        struct {
+		From     string `json:"from,omitempty"`
+		ReqId    uint64 `json:"reqId,omitempty"`
+		Digest   string `json:"digest,omitempty"`
+		Endorser string `json:"endorser,omitempty"`
+	} `json:"metadata"`
+}
+
+// TxnMetadata ledger position of a transaction
+type TxnMetadata struct {
+	SeqNo   uint64 `json:"seqNo"`
+	TxnTime uint64 `json:"txnTime"`
+	TxnId   string `json:"txnId,omitempty"`
+}
@@ -0,0 +1,195 @@
+/*
+// ******************************************************************
+// Purpose: typed models of the ledger replies
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Reply operations
+const (
+	OpReply   = "REPLY"
+	OpReqNack = "REQNACK"
+	OpReject  = "REJECT"
+)
+
+// ErrRequestRejected is returned by Reply.Err for REQNACK and REJECT replies
+var ErrRequestRejected = errors.New("ledger rejected the request")
+
+// ErrNotFound is returned when a read reply has no data
+var ErrNotFound = errors.New("ledger object not found")
+
+// Reply reply of the pool to a submitted request
+type Reply struct {
+	Op         string  `json:"op"`
+	Reason     string  `json:"reason,omitempty"` // REQNACK / REJECT
+	Identifier string  `json:"identifier,omitempty"`
+	ReqId      uint64  `json:"reqId,omitempty"`
+	Result     *Result `json:"result,omitempty"`
+}
+
+// Result result of a REPLY. Read replies carry the object in Data with the seqNo / txnTime of
+// the transaction that wrote it, write replies carry the written Txn and its TxnMetadata.
+type Result struct {
+	Type       string          `json:"type,omitempty"`
+	Identifier string          `json:"identifier,omitempty"`
+	ReqId      uint64          `json:"reqId,omitempty"`
+	Dest       string          `json:"dest,omitempty"`
+	Data       json.RawMessage `json:"data,omitempty"`
+	SeqNo      *uint64         `json:"seqNo,omitempty"`
+	TxnTime    *uint64         `json:"txnTime,omitempty"`
+	StateProof *StateProof     `json:"state_proof,omitempty"`
+
+	Ver          string       `json:"ver,omitempty"`
+	Txn          *Txn         `json:"txn,omitempty"`
+	TxnMetadata  *TxnMetadata `json:"txnMetadata,omitempty"`
+	ReqSignature interface{}  `json:"reqSignature,omitempty"`
+	RootHash     string       `json:"rootHash,omitempty"`
+	AuditPath    []string     `json:"auditPath,omitempty"`
+}
+
+// StateProof state proof returned by the nodes for read requests
+type StateProof struct {
+	RootHash       string          `json:"root_hash"`
+	ProofNodes     string          `json:"proof_nodes"`
+	MultiSignature json.RawMessage `json:"multi_signature"`
+}
+
+// ReplyMetadata ledger position of the object in a reply
+type ReplyMetadata struct {
+	SeqNo      uint64
+	TxnTime    uint64
+	StateProof *StateProof
+}
+
+// ParseReply unmarshals a reply json returned by SubmitRequest / SignAndSubmitRequest
+func ParseReply(replyJson string) (*Reply, error) {
+	var reply Reply
+	err := json.Unmarshal([]byte(replyJson), &reply)
+	if err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+// Err returns ErrRequestRejected with the reason for REQNACK and REJECT replies
+func (r *Reply) Err() error {
+	switch r.Op {
+	case OpReply:
+		if r.Result == nil {
+			return errors.New("reply without result")
+		}
+		return nil
+	case OpReqNack, OpReject:
+		return fmt.Errorf("%w (%s): %s", ErrRequestRejected, r.Op, r.Reason)
+	default:
+		return fmt.Errorf("unexpected reply op %s", r.Op)
+	}
+}
+
+// Metadata returns the seqNo / txnTime of a read or write reply
+func (r *Reply) Metadata() ReplyMetadata {
+	var meta ReplyMetadata
+	if r.Result == nil {
+		return meta
+	}
+	if r.Result.TxnMetadata != nil {
+		meta.SeqNo = r.Result.TxnMetadata.SeqNo
+		meta.TxnTime = r.Result.TxnMetadata.TxnTime
+	}
+	if r.Result.SeqNo != nil {
+		meta.SeqNo = *r.Result.SeqNo
+	}
+	if r.Result.TxnTime != nil {
+		meta.TxnTime = *r.Result.TxnTime
+	}
+	meta.StateProof = r.Result.StateProof
+	return meta
+}
+
+// UnmarshalData unmarshals the data of a read reply, fails with ErrNotFound when the ledger returned no data.
+// Some read replies (GET_ATTR, GET_NYM) carry the data as a json string, those are decoded as well.
+func (r *Reply) UnmarshalData(v interface{}) error {
+	err := r.Err()
+	if err != nil {
+		return err
+	}
+	data := r.Result.Data
+	if len(data) == 0 || string(data) == "null" {
+		return ErrNotFound
+	}
+
+	var inner string
+	if json.Unmarshal(data, &inner) == nil {
+		if len(inner) == 0 {
+			return ErrNotFound
+		}
+		data = json.RawMessage(inner)
+	}
+	return json.Unmarshal(data, v)
+}
+
+// ParseGetTxnAuthorAgreementReply parses a GET_TXN_AUTHR_AGRMT reply
+func ParseGetTxnAuthorAgreementReply(replyJson string) (*TxnAuthorAgreement, ReplyMetadata, error) {
+	var taa TxnAuthorAgreement
+	meta, err := parseReplyData(replyJson, &taa)
+	if err != nil {
+		return nil, meta, err
+	}
+	return &taa, meta, nil
+}
+
+// ParseGetAcceptanceMechanismsReply parses a GET_TXN_AUTHR_AGRMT_AML reply
+func ParseGetAcceptanceMechanismsReply(replyJson string) (*AcceptanceMechanisms, ReplyMetadata, error) {
+	var aml AcceptanceMechanisms
+	meta, err := parseReplyData(replyJson, &aml)
+	if err != nil {
+		return nil, meta, err
+	}
+	return &aml, meta, nil
+}
+
+// ParseGetAuthRuleReply parses a GET_AUTH_RULE reply
+func ParseGetAuthRuleReply(replyJson string) ([]AuthRule, ReplyMetadata, error) {
+	var rules []AuthRule
+	meta, err := parseReplyData(replyJson, &rules)
+	if err != nil {
+		return nil, meta, err
+	}
+	return rules, meta, nil
+}
+
+// ParseGetTxnReply parses a GET_TXN reply
+func ParseGetTxnReply(replyJson string) (*Txn, ReplyMetadata, error) {
+	var txn struct {
+		Txn         Txn         `json:"txn"`
+		TxnMetadata TxnMetadata `json:"txnMetadata"`
+	}
+	meta, err := parseReplyData(replyJson, &txn)
+	if err != nil {
+		return nil, meta, err
+	}
+	meta.SeqNo = txn.TxnMetadata.SeqNo
+	meta.TxnTime = txn.TxnMetadata.TxnTime
+	return &txn.Txn, meta, nil
+}
+
+func parseReplyData(replyJson string, v interface{}) (ReplyMetadata, error) {
+	reply, err := ParseReply(replyJson)
+	if err != nil {
+		return ReplyMetadata{}, err
+	}
+	meta := reply.Metadata()
+	return meta, reply.UnmarshalData(v)
+}
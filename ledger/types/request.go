@@ -0,0 +1,184 @@
+/*
+// ******************************************************************
+// Purpose: typed models of the ledger write and read requests
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package types
+
+import (
+	"encoding/json"
+)
+
+// Transaction types of the operations
+const (
+	TxnTypeNode               = "0"
+	TxnTypeNym                = "1"
+	TxnTypeGetTxn             = "3"
+	TxnTypeTxnAuthorAgreement = "4"
+	TxnTypeAttrib             = "100"
+	TxnTypeSchema             = "101"
+	TxnTypeCredDef            = "102"
+	TxnTypeGetAttr            = "104"
+	TxnTypeGetNym             = "105"
+	TxnTypeGetSchema          = "107"
+	TxnTypeGetCredDef         = "108"
+	TxnTypeRevocRegDef        = "113"
+	TxnTypeRevocRegEntry      = "114"
+	TxnTypeGetRevocRegDef     = "115"
+	TxnTypeGetRevocReg        = "116"
+	TxnTypeGetRevocRegDelta   = "117"
+	TxnTypePoolRestart        = "118"
+	TxnTypeGetValidatorInfo   = "119"
+	TxnTypeAuthRule           = "120"
+	TxnTypeGetAuthRule        = "121"
+	TxnTypeGetTaa             = "6"
+	TxnTypeGetTaaAml          = "7"
+)
+
+// Ledger types accepted by GET_TXN
+const (
+	LedgerPool   = "POOL"
+	LedgerDomain = "DOMAIN"
+	LedgerConfig = "CONFIG"
+)
+
+// Request is a ledger request as built by libindy. The operation is kept raw,
+// unmarshal it into the matching *Operation struct with Request.UnmarshalOperation.
+type Request struct {
+	ReqId           uint64            `json:"reqId"`
+	Identifier      string            `json:"identifier,omitempty"`
+	Operation       json.RawMessage   `json:"operation"`
+	ProtocolVersion int               `json:"protocolVersion"`
+	Signature       string            `json:"signature,omitempty"`
+	Signatures      map[string]string `json:"signatures,omitempty"`
+	Endorser        string            `json:"endorser,omitempty"`
+	TaaAcceptance   *TaaAcceptance    `json:"taaAcceptance,omitempty"`
+}
+
+// TaaAcceptance transaction author agreement acceptance appended to write requests
+type TaaAcceptance struct {
+	Mechanism string `json:"mechanism"`
+	TaaDigest string `json:"taaDigest"`
+	Time      uint64 `json:"time"`
+}
+
+// ParseRequest unmarshals a request json
+func ParseRequest(requestJson string) (*Request, error) {
+	var req Request
+	err := json.Unmarshal([]byte(requestJson), &req)
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// Json marshals the request back to the json expected by libindy
+func (r *Request) Json() (string, error) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// OperationType returns the transaction type of the operation
+func (r *Request) OperationType() (string, error) {
+	var op struct {
+		Type string `json:"type"`
+	}
+	err := json.Unmarshal(r.Operation, &op)
+	return op.Type, err
+}
+
+// UnmarshalOperation unmarshals the operation into one of the *Operation structs
+func (r *Request) UnmarshalOperation(v interface{}) error {
+	return json.Unmarshal(r.Operation, v)
+}
+
+// NymOperation NYM (1)
+type NymOperation struct {
+	Type   string  `json:"type"`
+	Dest   string  `json:"dest"`
+	Verkey string  `json:"verkey,omitempty"`
+	Alias  string  `json:"alias,omitempty"`
+	Role   *string `json:"role"` // nil removes the role
+}
+
+// AttribOperation ATTRIB (100)
+type AttribOperation struct {
+	Type string `json:"type"`
+	Dest string `json:"dest"`
+	Raw  string `json:"raw,omitempty"`
+	Hash string `json:"hash,omitempty"`
+	Enc  string `json:"enc,omitempty"`
+}
+
+// SchemaOperation SCHEMA (101)
+type SchemaOperation struct {
+	Type string `json:"type"`
+	Data struct {
+		Name      string   `json:"name"`
+		Version   string   `json:"version"`
+		AttrNames []string `json:"attr_names"`
+	} `json:"data"`
+}
+
+// CredDefOperation CRED_DEF (102)
+type CredDefOperation struct {
+	Type          string          `json:"type"`
+	Ref           uint64          `json:"ref"`
+	SignatureType string          `json:"signature_type"`
+	Tag           string          `json:"tag"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// RevocRegDefOperation REVOC_REG_DEF (113)
+type RevocRegDefOperation struct {
+	Type         string           `json:"type"`
+	Id           string           `json:"id"`
+	RevocDefType string           `json:"revocDefType"`
+	Tag          string           `json:"tag"`
+	CredDefId    string           `json:"credDefId"`
+	Value        RevocRegDefValue `json:"value"`
+}
+
+// RevocRegEntryOperation REVOC_REG_ENTRY (114)
+type RevocRegEntryOperation struct {
+	Type          string          `json:"type"`
+	RevocRegDefId string          `json:"revocRegDefId"`
+	RevocDefType  string          `json:"revocDefType"`
+	Value         json.RawMessage `json:"value"`
+}
+
+// TxnAuthorAgreementOperation TXN_AUTHOR_AGREEMENT (4)
+type TxnAuthorAgreementOperation struct {
+	Type           string `json:"type"`
+	Text           string `json:"text,omitempty"`
+	Version        string `json:"version"`
+	RatificationTs int64  `json:"ratification_ts,omitempty"`
+	RetirementTs   int64  `json:"retirement_ts,omitempty"`
+}
+
+// AuthRuleOperation AUTH_RULE (120)
+type AuthRuleOperation struct {
+	Type       string         `json:"type"`
+	AuthType   string         `json:"auth_type"`
+	AuthAction string         `json:"auth_action"`
+	Field      string         `json:"field"`
+	OldValue   *string        `json:"old_value,omitempty"`
+	NewValue   *string        `json:"new_value,omitempty"`
+	Constraint AuthConstraint `json:"constraint"`
+}
+
+// GetTxnOperation GET_TXN (3)
+type GetTxnOperation struct {
+	Type     string `json:"type"`
+	Data     uint64 `json:"data"`
+	LedgerId int    `json:"ledgerId"`
+}
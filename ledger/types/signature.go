@@ -0,0 +1,163 @@
+/*
+// ******************************************************************
+// Purpose: verification of the request signatures, the payload is
+// serialised the way indy-plenum (and libindy) sign it
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package types
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidRequestSignature is returned when a request signature does not verify with the signer verkey
+var ErrInvalidRequestSignature = errors.New("invalid request signature")
+
+// SignatureInput returns the bytes a request signature covers: keys sorted, "key:value" joined with "|", arrays
+// joined with ",", the top level signature, signatures and fees left out. The raw, hash and enc values of the
+// attribute requests are replaced by their sha256.
+func SignatureInput(requestJson string) ([]byte, error) {
+	decoder := json.NewDecoder(strings.NewReader(requestJson))
+	decoder.UseNumber()
+	var request map[string]interface{}
+	err := decoder.Decode(&request)
+	if err != nil {
+		return nil, err
+	}
+
+	var txnType string
+	if operation, ok := request["operation"].(map[string]interface{}); ok {
+		txnType, _ = operation["type"].(string)
+	}
+	var b strings.Builder
+	serializeForSigning(&b, request, true, txnType == TxnTypeAttrib || txnType == TxnTypeGetAttr)
+	return []byte(b.String()), nil
+}
+
+func serializeForSigning(b *strings.Builder, value interface{}, topLevel bool, hashAttrib bool) {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			b.WriteString("True")
+		} else {
+			b.WriteString("False")
+		}
+	case json.Number:
+		b.WriteString(v.String())
+	case string:
+		b.WriteString(v)
+	case []interface{}:
+		for i, item := range v {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			serializeForSigning(b, item, false, hashAttrib)
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			if topLevel && (key == "signature" || key == "signatures" || key == "fees") {
+				continue
+			}
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for i, key := range keys {
+			if i > 0 {
+				b.WriteString("|")
+			}
+			b.WriteString(key)
+			b.WriteString(":")
+			item := v[key]
+			if s, ok := item.(string); ok && hashAttrib && (key == "raw" || key == "hash" || key == "enc") {
+				digest := sha256.Sum256([]byte(s))
+				item = hex.EncodeToString(digest[:])
+			}
+			serializeForSigning(b, item, false, hashAttrib)
+		}
+	}
+	// null is serialised as an empty string
+}
+
+// VerifyRequestSignature checks a base58 signature of the request made with the signer verkey. An abbreviated
+// verkey (~...) is expanded with the signer did.
+func VerifyRequestSignature(requestJson string, signature string, signerDid string, verkey string) error {
+	key, err := fullVerkey(signerDid, verkey)
+	if err != nil {
+		return err
+	}
+	sig, err := decodeBase58(signature)
+	if err != nil {
+		return fmt.Errorf("%w: signature is not base58", ErrInvalidRequestSignature)
+	}
+	payload, err := SignatureInput(requestJson)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(key, payload, sig) {
+		return fmt.Errorf("%w: not signed by %s", ErrInvalidRequestSignature, signerDid)
+	}
+	return nil
+}
+
+func fullVerkey(did string, verkey string) (ed25519.PublicKey, error) {
+	var key []byte
+	if strings.HasPrefix(verkey, "~") {
+		didBytes, err := decodeBase58(did)
+		if err != nil {
+			return nil, fmt.Errorf("did %s is not base58", did)
+		}
+		abbreviated, err := decodeBase58(verkey[1:])
+		if err != nil {
+			return nil, fmt.Errorf("verkey %s is not base58", verkey)
+		}
+		key = append(didBytes, abbreviated...)
+	} else {
+		decoded, err := decodeBase58(verkey)
+		if err != nil {
+			return nil, fmt.Errorf("verkey %s is not base58", verkey)
+		}
+		key = decoded
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("verkey %s of %s is not an ed25519 key", verkey, did)
+	}
+	return key, nil
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// decodeBase58 decodes the bitcoin alphabet used for the dids, verkeys and signatures
+func decodeBase58(s string) ([]byte, error) {
+	value := new(big.Int)
+	radix := big.NewInt(58)
+	for _, c := range []byte(s) {
+		digit := strings.IndexByte(base58Alphabet, c)
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid base58 character '%c'", c)
+		}
+		value.Mul(value, radix)
+		value.Add(value, big.NewInt(int64(digit)))
+	}
+	// each leading '1' is a leading zero byte
+	zeros := 0
+	for zeros < len(s) && s[zeros] == '1' {
+		zeros++
+	}
+	return append(bytes.Repeat([]byte{0}, zeros), value.Bytes()...), nil
+}
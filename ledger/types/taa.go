@@ -0,0 +1,53 @@
+/*
+// ******************************************************************
+// Purpose: transaction author agreement digest and acceptance helpers
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// TaaDigest computes the digest of a transaction author agreement the way the ledger does,
+// hex encoded sha256 of the version followed by the text
+func TaaDigest(text string, version string) string {
+	digest := sha256.Sum256([]byte(version + text))
+	return hex.EncodeToString(digest[:])
+}
+
+// RequiresTaaAcceptance reports whether a transaction type is a domain ledger write, the
+// transactions the ledger rejects without an acceptance while a TAA is active
+func RequiresTaaAcceptance(txnType string) bool {
+	switch txnType {
+	case TxnTypeNym, TxnTypeAttrib, TxnTypeSchema, TxnTypeCredDef, TxnTypeRevocRegDef, TxnTypeRevocRegEntry:
+		return true
+	}
+	return false
+}
+
+// Active reports whether the agreement must be accepted, a disabled or retired agreement has no text or is past retirement
+func (t *TxnAuthorAgreement) Active(now time.Time) bool {
+	if t == nil || (len(t.Text) == 0 && len(t.Version) == 0) {
+		return false
+	}
+	return t.RetirementTs == 0 || t.RetirementTs > now.Unix()
+}
+
+// AcceptanceTime returns the acceptance time to put in the requests. The ledger only needs the day,
+// so the time is rounded down to midnight UTC, but never before the ratification of the agreement.
+func (t *TxnAuthorAgreement) AcceptanceTime(now time.Time) uint64 {
+	day := now.UTC().Truncate(24 * time.Hour).Unix()
+	if day < t.RatificationTs {
+		day = t.RatificationTs
+	}
+	return uint64(day)
+}
@@ -0,0 +1,323 @@
+/*
+// ******************************************************************
+// Purpose: ledger request and reply models unit testing
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package types
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRequest(t *testing.T) {
+	requestJson := `{"reqId":1629277519227413000,"identifier":"V4SGRU86Z58d6TV7PBUe6f","operation":{"type":"101","data":{"name":"gvt","version":"1.0","attr_names":["age","name"]}},"protocolVersion":2}`
+
+	req, err := ParseRequest(requestJson)
+	if err != nil {
+		t.Errorf("ParseRequest() error = '%v'", err)
+		return
+	}
+	opType, _ := req.OperationType()
+	if opType != TxnTypeSchema {
+		t.Errorf("OperationType() = '%s', want = '%s'", opType, TxnTypeSchema)
+	}
+
+	var op SchemaOperation
+	err = req.UnmarshalOperation(&op)
+	if err != nil || op.Data.Name != "gvt" || len(op.Data.AttrNames) != 2 {
+		t.Errorf("UnmarshalOperation() = '%v', error = '%v'", op, err)
+	}
+
+	roundTrip, err := req.Json()
+	if err != nil || roundTrip != requestJson {
+		t.Errorf("Json() = '%s', want = '%s'", roundTrip, requestJson)
+	}
+}
+
+func TestParseReply(t *testing.T) {
+	type args struct {
+		Reply string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantSeqNo   uint64
+		wantTxnTime uint64
+		wantErr     error
+	}{
+		{"read-reply", args{`{"op":"REPLY","result":{"type":"108","seqNo":10,"txnTime":1629277520,"data":{"primary":{}},"state_proof":{"root_hash":"abc","proof_nodes":"def","multi_signature":{}}}}`}, 10, 1629277520, nil},
+		{"write-reply", args{`{"op":"REPLY","result":{"ver":"1","txn":{"type":"101","data":{}},"txnMetadata":{"seqNo":11,"txnTime":1629277521}}}`}, 11, 1629277521, nil},
+		{"reqnack", args{`{"op":"REQNACK","reason":"client request invalid","reqId":1}`}, 0, 0, ErrRequestRejected},
+		{"reject", args{`{"op":"REJECT","reason":"not authorized","reqId":1}`}, 0, 0, ErrRequestRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reply, err := ParseReply(tt.args.Reply)
+			if err != nil {
+				t.Errorf("ParseReply() error = '%v'", err)
+				return
+			}
+			if !errors.Is(reply.Err(), tt.wantErr) {
+				t.Errorf("Err() = '%v', want = '%v'", reply.Err(), tt.wantErr)
+				return
+			}
+			meta := reply.Metadata()
+			if meta.SeqNo != tt.wantSeqNo || meta.TxnTime != tt.wantTxnTime {
+				t.Errorf("Metadata() = '%v', want seqNo = '%d' txnTime = '%d'", meta, tt.wantSeqNo, tt.wantTxnTime)
+			}
+		})
+	}
+}
+
+func TestParseGetTxnAuthorAgreementReply(t *testing.T) {
+	taa, meta, err := ParseGetTxnAuthorAgreementReply(`{"op":"REPLY","result":{"type":"6","seqNo":3,"txnTime":1629277000,"data":{"text":"agreement","version":"1.0","digest":"83d9","ratification_ts":1629276000}}}`)
+	if err != nil {
+		t.Errorf("ParseGetTxnAuthorAgreementReply() error = '%v'", err)
+		return
+	}
+	if taa.Text != "agreement" || taa.Version != "1.0" || taa.Digest != "83d9" || meta.SeqNo != 3 {
+		t.Errorf("ParseGetTxnAuthorAgreementReply() = '%v', '%v'", taa, meta)
+	}
+
+	_, _, err = ParseGetTxnAuthorAgreementReply(`{"op":"REPLY","result":{"type":"6","data":null}}`)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("ParseGetTxnAuthorAgreementReply() error = '%v', want = '%v'", err, ErrNotFound)
+	}
+}
+
+func TestParseGetTxnReply(t *testing.T) {
+	txn, meta, err := ParseGetTxnReply(`{"op":"REPLY","result":{"type":"3","seqNo":5,"data":{"txn":{"type":"1","data":{"dest":"V4SGRU86Z58d6TV7PBUe6f"},"metadata":{"from":"Th7MpTaRZVRYnPiabds81Y"}},"txnMetadata":{"seqNo":5,"txnTime":1629277000}}}}`)
+	if err != nil {
+		t.Errorf("ParseGetTxnReply() error = '%v'", err)
+		return
+	}
+	if txn.Type != TxnTypeNym || txn.Metadata.From != "Th7MpTaRZVRYnPiabds81Y" || meta.TxnTime != 1629277000 {
+		t.Errorf("ParseGetTxnReply() = '%v', '%v'", txn, meta)
+	}
+}
+
+func TestParseActionReplies(t *testing.T) {
+	resultJson := `{"Node1":"{\"op\":\"REPLY\",\"result\":{\"type\":\"119\",\"identifier\":\"V4SGRU86Z58d6TV7PBUe6f\",\"reqId\":1,\"data\":{\"alias\":\"Node1\"}}}","Node2":"timeout","Node3":"{\"op\":\"REQNACK\",\"reason\":\"not a trustee\"}"}`
+
+	replies, err := ParseActionReplies(resultJson)
+	if err != nil {
+		t.Errorf("ParseActionReplies() error = '%v'", err)
+		return
+	}
+	if len(replies) != 3 {
+		t.Errorf("ParseActionReplies() got %d replies, want 3", len(replies))
+		return
+	}
+
+	var info struct {
+		Alias string `json:"alias"`
+	}
+	errData := replies["Node1"].Reply.UnmarshalData(&info)
+	if errData != nil || info.Alias != "Node1" {
+		t.Errorf("UnmarshalData() = '%v', '%v'", info, errData)
+	}
+	if !errors.Is(replies["Node2"].Err(), ErrNodeTimeout) {
+		t.Errorf("Node2 Err() = '%v', want ErrNodeTimeout", replies["Node2"].Err())
+	}
+	if !errors.Is(replies["Node3"].Err(), ErrRequestRejected) {
+		t.Errorf("Node3 Err() = '%v', want ErrRequestRejected", replies["Node3"].Err())
+	}
+
+	succeeded := replies.Succeeded()
+	if len(succeeded) != 1 || succeeded[0] != "Node1" {
+		t.Errorf("Succeeded() = '%v', want [Node1]", succeeded)
+	}
+	if len(replies.Failed()) != 2 {
+		t.Errorf("Failed() = '%v', want 2 nodes", replies.Failed())
+	}
+
+	_, err = ParseActionReplies(`["Node1"]`)
+	if err == nil {
+		t.Errorf("ParseActionReplies() expected error for a non object result")
+	}
+}
+
+func TestTaaDigest(t *testing.T) {
+	// digest of the agreement used by the libindy TAA tests
+	text := "some agreement text"
+	version := "1.0.0"
+	want := "050e52a57837fff904d3d059c8a123e3a04177042bf467db2b2c27abd8045d5e"
+
+	if got := TaaDigest(text, version); got != want {
+		t.Errorf("TaaDigest() = '%s', want = '%s'", got, want)
+	}
+}
+
+func TestTxnAuthorAgreementAcceptance(t *testing.T) {
+	now := time.Date(2021, 8, 18, 15, 4, 5, 0, time.UTC)
+	midnight := uint64(time.Date(2021, 8, 18, 0, 0, 0, 0, time.UTC).Unix())
+
+	tests := []struct {
+		name       string
+		taa        *TxnAuthorAgreement
+		wantActive bool
+		wantTime   uint64
+	}{
+		{"taa-active", &TxnAuthorAgreement{Text: "text", Version: "1.0", RatificationTs: 1600000000}, true, midnight},
+		{"taa-ratified-today", &TxnAuthorAgreement{Text: "text", Version: "1.0", RatificationTs: now.Unix() - 60}, true, uint64(now.Unix() - 60)},
+		{"taa-retired", &TxnAuthorAgreement{Text: "text", Version: "1.0", RatificationTs: 1600000000, RetirementTs: 1600000001}, false, midnight},
+		{"taa-disabled", &TxnAuthorAgreement{}, false, midnight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.taa.Active(now); got != tt.wantActive {
+				t.Errorf("Active() = '%v', want = '%v'", got, tt.wantActive)
+			}
+			if got := tt.taa.AcceptanceTime(now); got != tt.wantTime {
+				t.Errorf("AcceptanceTime() = '%v', want = '%v'", got, tt.wantTime)
+			}
+		})
+	}
+
+	if !RequiresTaaAcceptance(TxnTypeSchema) || RequiresTaaAcceptance(TxnTypeGetSchema) {
+		t.Errorf("RequiresTaaAcceptance() not correct")
+	}
+}
+
+func TestEndorsementPolicyCheck(t *testing.T) {
+	author := "V4SGRU86Z58d6TV7PBUe6f"
+	endorser := "Th7MpTaRZVRYnPiabds81Y"
+	request := func(endorserDid string, signatures string) json.RawMessage {
+		return json.RawMessage(`{"reqId":1,"identifier":"` + author + `","operation":{"type":"101","data":{"name":"gvt","version":"1.0","attr_names":["age"]}},"protocolVersion":2,"endorser":"` + endorserDid + `","signatures":` + signatures + `}`)
+	}
+	signedByAuthor := `{"` + author + `":"sig1"}`
+	errValidate := errors.New("schema name not allowed")
+
+	tests := []struct {
+		name     string
+		envelope EndorsementEnvelope
+		policy   EndorsementPolicy
+		wantErr  bool
+	}{
+		{"endorsement-works", EndorsementEnvelope{author, endorser, request(endorser, signedByAuthor)}, EndorsementPolicy{AllowedTxnTypes: []string{TxnTypeSchema}}, false},
+		{"endorsement-other-endorser", EndorsementEnvelope{author, endorser, request(author, signedByAuthor)}, EndorsementPolicy{}, true},
+		{"endorsement-other-author", EndorsementEnvelope{endorser, endorser, request(endorser, signedByAuthor)}, EndorsementPolicy{}, true},
+		{"endorsement-not-signed", EndorsementEnvelope{author, endorser, request(endorser, `{}`)}, EndorsementPolicy{}, true},
+		{"endorsement-already-endorsed", EndorsementEnvelope{author, endorser, request(endorser, `{"`+author+`":"sig1","`+endorser+`":"sig2"}`)}, EndorsementPolicy{}, true},
+		{"endorsement-txn-type-not-allowed", EndorsementEnvelope{author, endorser, request(endorser, signedByAuthor)}, EndorsementPolicy{AllowedTxnTypes: []string{TxnTypeNym}}, true},
+		{"endorsement-author-not-allowed", EndorsementEnvelope{author, endorser, request(endorser, signedByAuthor)}, EndorsementPolicy{AllowedAuthors: []string{endorser}}, true},
+		{"endorsement-validate-fails", EndorsementEnvelope{author, endorser, request(endorser, signedByAuthor)}, EndorsementPolicy{Validate: func(*Request) error { return errValidate }}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelopeJson, _ := tt.envelope.Json()
+			envelope, errParse := ParseEndorsementEnvelope(envelopeJson)
+			if errParse != nil {
+				t.Errorf("ParseEndorsementEnvelope() error = '%v'", errParse)
+				return
+			}
+			_, err := tt.policy.Check(envelope, endorser)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = '%v', wantErr = '%v'", err, tt.wantErr)
+				return
+			}
+			if err != nil && !errors.Is(err, ErrEndorsementRejected) {
+				t.Errorf("Check() error = '%v', want ErrEndorsementRejected", err)
+			}
+		})
+	}
+}
+
+func TestSignatureInput(t *testing.T) {
+	// vector of the libindy request serialisation tests
+	msg := `{"name": "John Doe", "age": 43, "operation": {"dest": 54}, "phones": ["1234567", "2345678", {"rust": 5, "age": 1}, 3], "signature": "ignored"}`
+	got, err := SignatureInput(msg)
+	want := "age:43|name:John Doe|operation:dest:54|phones:1234567,2345678,age:1|rust:5,3"
+	if err != nil || string(got) != want {
+		t.Errorf("SignatureInput() = '%s', error = '%v', want '%s'", got, err, want)
+	}
+
+	attrib := `{"reqId":1,"identifier":"V4SGRU86Z58d6TV7PBUe6f","operation":{"type":"100","dest":"V4SGRU86Z58d6TV7PBUe6f","raw":"{\"endpoint\":{\"ha\":\"127.0.0.1:5555\"}}"},"protocolVersion":2}`
+	got, _ = SignatureInput(attrib)
+	want = "identifier:V4SGRU86Z58d6TV7PBUe6f|operation:dest:V4SGRU86Z58d6TV7PBUe6f|raw:"
+	if !strings.HasPrefix(string(got), want) || strings.Contains(string(got), "endpoint") {
+		t.Errorf("SignatureInput() attrib = '%s', want the raw value hashed", got)
+	}
+}
+
+func encodeBase58(b []byte) string {
+	value := new(big.Int).SetBytes(b)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	var out []byte
+	for value.Sign() > 0 {
+		value.DivMod(value, radix, mod)
+		out = append([]byte{base58Alphabet[mod.Int64()]}, out...)
+	}
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		out = append([]byte{'1'}, out...)
+	}
+	return string(out)
+}
+
+func TestVerifyRequestSignature(t *testing.T) {
+	public, private, _ := ed25519.GenerateKey(nil)
+	_, otherPrivate, _ := ed25519.GenerateKey(nil)
+	did := encodeBase58(public[:16])
+	verkey := encodeBase58(public)
+	requestJson := `{"reqId":1,"identifier":"` + did + `","operation":{"type":"101","data":{"name":"gvt","version":"1.0","attr_names":["age"]}},"protocolVersion":2,"endorser":"Th7MpTaRZVRYnPiabds81Y"}`
+	payload, _ := SignatureInput(requestJson)
+	signature := encodeBase58(ed25519.Sign(private, payload))
+	forged := encodeBase58(ed25519.Sign(otherPrivate, payload))
+
+	tests := []struct {
+		name      string
+		signature string
+		verkey    string
+		wantErr   error
+	}{
+		{"verify-works", signature, verkey, nil},
+		{"verify-abbreviated-verkey", signature, "~" + encodeBase58(public[16:]), nil},
+		{"verify-other-key", forged, verkey, ErrInvalidRequestSignature},
+		{"verify-not-base58", "sig1", verkey, ErrInvalidRequestSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyRequestSignature(requestJson, tt.signature, did, tt.verkey)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyRequestSignature() error = '%v', want = '%v'", err, tt.wantErr)
+			}
+		})
+	}
+
+	// the endorser rejects an envelope whose author signature does not verify
+	var request map[string]interface{}
+	json.Unmarshal([]byte(requestJson), &request)
+	request["signatures"] = map[string]string{did: forged}
+	forgedJson, _ := json.Marshal(request)
+	envelope := EndorsementEnvelope{AuthorDid: did, EndorserDid: "Th7MpTaRZVRYnPiabds81Y", Request: forgedJson}
+	errEnvelope := envelope.VerifyAuthorSignature(verkey)
+	if !errors.Is(errEnvelope, ErrEndorsementRejected) {
+		t.Errorf("VerifyAuthorSignature() forged error = '%v'", errEnvelope)
+	}
+	request["signatures"] = map[string]string{did: signature}
+	signedJson, _ := json.Marshal(request)
+	envelope.Request = signedJson
+	errEnvelope = envelope.VerifyAuthorSignature(verkey)
+	if errEnvelope != nil {
+		t.Errorf("VerifyAuthorSignature() error = '%v'", errEnvelope)
+	}
+}
@@ -13,7 +13,9 @@ package indySDK
 
 import (
 	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"github.com/joyride9999/IndySdkGoBindings/ledger/types"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/Jeffail/gabs/v2"
 	"github.com/stretchr/testify/assert"
@@ -364,6 +366,78 @@ func TestSubmitRequest(t *testing.T) {
 	return
 }
 
+func TestSubmitAction(t *testing.T) {
+	poolHandle, errPool := getPoolLedger("testpool2")
+	if errPool != nil {
+		t.Errorf("getPoolLedger() error = '%v'", errPool)
+		return
+	}
+	defer ClosePoolHandle(poolHandle)
+
+	whTrustee, errCreate := createWallet(trusteeConfig(), trusteeCredentials())
+	if errCreate != nil && !errors.Is(errCreate, indyUtils.ErrWalletAlreadyExists) {
+		t.Errorf("createWallet() error = '%v'", errCreate)
+		return
+	}
+	defer walletCleanup(whTrustee, trusteeConfig(), trusteeCredentials())
+
+	trusteeDid, _, errDid := CreateAndStoreDID(whTrustee, seedTrustee1)
+	if errDid != nil {
+		t.Errorf("CreateAndStoreDid() error = '%v'", errDid)
+		return
+	}
+
+	validatorInfoRequest, errBuild := BuildGetValidatorInfoRequest(trusteeDid)
+	if errBuild != nil {
+		t.Errorf("BuildGetValidatorInfoRequest() error = '%v'", errBuild)
+		return
+	}
+	signedRequest, errSign := SignRequest(whTrustee, trusteeDid, validatorInfoRequest)
+	if errSign != nil {
+		t.Errorf("SignRequest() error = '%v'", errSign)
+		return
+	}
+
+	type args struct {
+		Request string
+		Nodes   []string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantNodes int
+		wantErr   bool
+	}{
+		{"test-submit-action-all-nodes-works", args{Request: signedRequest}, 4, false},
+		{"test-submit-action-selected-nodes-works", args{Request: signedRequest, Nodes: []string{"Node1", "Node2"}}, 2, false},
+		{"test-submit-action-invalid-request", args{Request: "invalid-request"}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, errSubmit := SubmitAction(poolHandle, tt.args.Request, tt.args.Nodes, 0)
+			hasError := errSubmit != nil
+			if hasError != tt.wantErr {
+				t.Errorf("SubmitAction() error = '%v'", errSubmit)
+				return
+			}
+			if tt.wantErr {
+				t.Log("Expected error: ", errSubmit)
+				return
+			}
+
+			replies, errParse := types.ParseActionReplies(result)
+			if errParse != nil {
+				t.Errorf("ParseActionReplies() error = '%v'", errParse)
+				return
+			}
+			if len(replies) != tt.wantNodes || len(replies.Succeeded()) != tt.wantNodes {
+				t.Errorf("Values are not correct, replies = '%v', failed = '%v'", replies, replies.Failed())
+			}
+		})
+	}
+}
+
 func TestMultiSignRequest(t *testing.T) {
 	walletHandle, errCreate := createWallet(testConfig(), testCredentials())
 	if errCreate != nil && errCreate.Error() != indyUtils.GetIndyError(203) {
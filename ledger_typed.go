@@ -0,0 +1,375 @@
+/*
+// ******************************************************************
+// Purpose: exported public functions that build and parse ledger
+// requests using the typed models of ledger/types
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package indySDK
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/joyride9999/IndySdkGoBindings/ledger/types"
+)
+
+// BuildNymRequestTyped is like BuildNymRequest but takes and returns typed models
+func BuildNymRequestTyped(submitterDid string, nym types.Nym) (*types.Request, error) {
+	return BuildNymRequestTypedCtx(context.Background(), submitterDid, nym)
+}
+
+// BuildNymRequestTypedCtx is like BuildNymRequestTyped but returns ctx.Err() if ctx is done before libindy answers.
+func BuildNymRequestTypedCtx(ctx context.Context, submitterDid string, nym types.Nym) (*types.Request, error) {
+	request, err := BuildNymRequestCtx(ctx, submitterDid, nym.Did, nym.Verkey, nym.Alias, nym.Role)
+	if err != nil {
+		return nil, err
+	}
+	return types.ParseRequest(request)
+}
+
+// BuildAttribRequestTyped is like BuildAttribRequest but takes and returns typed models
+func BuildAttribRequestTyped(submitterDid string, attrib types.Attrib) (*types.Request, error) {
+	return BuildAttribRequestTypedCtx(context.Background(), submitterDid, attrib)
+}
+
+// BuildAttribRequestTypedCtx is like BuildAttribRequestTyped but returns ctx.Err() if ctx is done before libindy answers.
+func BuildAttribRequestTypedCtx(ctx context.Context, submitterDid string, attrib types.Attrib) (*types.Request, error) {
+	request, err := BuildAttribRequestCtx(ctx, submitterDid, attrib.Dest, attrib.Raw, attrib.Hash, attrib.Enc)
+	if err != nil {
+		return nil, err
+	}
+	return types.ParseRequest(request)
+}
+
+// BuildSchemaRequestTyped is like BuildSchemaRequest but takes and returns typed models
+func BuildSchemaRequestTyped(submitterDid string, schema types.Schema) (*types.Request, error) {
+	return BuildSchemaRequestTypedCtx(context.Background(), submitterDid, schema)
+}
+
+// BuildSchemaRequestTypedCtx is like BuildSchemaRequestTyped but returns ctx.Err() if ctx is done before libindy answers.
+func BuildSchemaRequestTypedCtx(ctx context.Context, submitterDid string, schema types.Schema) (*types.Request, error) {
+	schemaJson, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	request, err := BuildSchemaRequestCtx(ctx, submitterDid, string(schemaJson))
+	if err != nil {
+		return nil, err
+	}
+	return types.ParseRequest(request)
+}
+
+// BuildCredentialDefinitionRequestTyped is like BuildCredentialDefinitionRequest but takes and returns typed models
+func BuildCredentialDefinitionRequestTyped(submitterDid string, credDef types.CredentialDefinition) (*types.Request, error) {
+	return BuildCredentialDefinitionRequestTypedCtx(context.Background(), submitterDid, credDef)
+}
+
+// BuildCredentialDefinitionRequestTypedCtx is like BuildCredentialDefinitionRequestTyped but returns ctx.Err() if ctx is done before libindy answers.
+func BuildCredentialDefinitionRequestTypedCtx(ctx context.Context, submitterDid string, credDef types.CredentialDefinition) (*types.Request, error) {
+	credDefJson, err := json.Marshal(credDef)
+	if err != nil {
+		return nil, err
+	}
+	request, err := BuildCredentialDefinitionRequestCtx(ctx, submitterDid, string(credDefJson))
+	if err != nil {
+		return nil, err
+	}
+	return types.ParseRequest(request)
+}
+
+// BuildRevocRegDefRequestTyped is like BuildRevocRegDefRequest but takes and returns typed models
+func BuildRevocRegDefRequestTyped(submitterDid string, revRegDef types.RevocRegDef) (*types.Request, error) {
+	return BuildRevocRegDefRequestTypedCtx(context.Background(), submitterDid, revRegDef)
+}
+
+// BuildRevocRegDefRequestTypedCtx is like BuildRevocRegDefRequestTyped but returns ctx.Err() if ctx is done before libindy answers.
+func BuildRevocRegDefRequestTypedCtx(ctx context.Context, submitterDid string, revRegDef types.RevocRegDef) (*types.Request, error) {
+	revRegDefJson, err := json.Marshal(revRegDef)
+	if err != nil {
+		return nil, err
+	}
+	request, err := BuildRevocRegDefRequestCtx(ctx, submitterDid, string(revRegDefJson))
+	if err != nil {
+		return nil, err
+	}
+	return types.ParseRequest(request)
+}
+
+// BuildRevocRegEntryRequestTyped is like BuildRevocRegEntryRequest but takes and returns typed models
+func BuildRevocRegEntryRequestTyped(submitterDid string, revocRegDefId string, revDefType string, delta types.RevocRegDelta) (*types.Request, error) {
+	return BuildRevocRegEntryRequestTypedCtx(context.Background(), submitterDid, revocRegDefId, revDefType, delta)
+}
+
+// BuildRevocRegEntryRequestTypedCtx is like BuildRevocRegEntryRequestTyped but returns ctx.Err() if ctx is done before libindy answers.
+func BuildRevocRegEntryRequestTypedCtx(ctx context.Context, submitterDid string, revocRegDefId string, revDefType string, delta types.RevocRegDelta) (*types.Request, error) {
+	deltaJson, err := json.Marshal(delta)
+	if err != nil {
+		return nil, err
+	}
+	request, err := BuildRevocRegEntryRequestCtx(ctx, submitterDid, revocRegDefId, revDefType, string(deltaJson))
+	if err != nil {
+		return nil, err
+	}
+	return types.ParseRequest(request)
+}
+
+// BuildTxnAuthorAgreementRequestTyped is like BuildTxnAuthorAgreementRequest but takes and returns typed models
+func BuildTxnAuthorAgreementRequestTyped(submitterDid string, taa types.TxnAuthorAgreement) (*types.Request, error) {
+	return BuildTxnAuthorAgreementRequestTypedCtx(context.Background(), submitterDid, taa)
+}
+
+// BuildTxnAuthorAgreementRequestTypedCtx is like BuildTxnAuthorAgreementRequestTyped but returns ctx.Err() if ctx is done before libindy answers.
+func BuildTxnAuthorAgreementRequestTypedCtx(ctx context.Context, submitterDid string, taa types.TxnAuthorAgreement) (*types.Request, error) {
+	request, err := BuildTxnAuthorAgreementRequestCtx(ctx, submitterDid, taa.Text, taa.Version, taa.RatificationTs, taa.RetirementTs)
+	if err != nil {
+		return nil, err
+	}
+	return types.ParseRequest(request)
+}
+
+// BuildAuthRuleRequestTyped is like BuildAuthRuleRequest but takes and returns typed models
+func BuildAuthRuleRequestTyped(submitterDid string, rule types.AuthRule) (*types.Request, error) {
+	return BuildAuthRuleRequestTypedCtx(context.Background(), submitterDid, rule)
+}
+
+// BuildAuthRuleRequestTypedCtx is like BuildAuthRuleRequestTyped but returns ctx.Err() if ctx is done before libindy answers.
+func BuildAuthRuleRequestTypedCtx(ctx context.Context, submitterDid string, rule types.AuthRule) (*types.Request, error) {
+	constraintJson, err := json.Marshal(rule.Constraint)
+	if err != nil {
+		return nil, err
+	}
+	var oldValue, newValue string
+	if rule.OldValue != nil {
+		oldValue = *rule.OldValue
+	}
+	if rule.NewValue != nil {
+		newValue = *rule.NewValue
+	}
+	request, err := BuildAuthRuleRequestCtx(ctx, submitterDid, rule.AuthType, rule.AuthAction, rule.Field, oldValue, newValue, string(constraintJson))
+	if err != nil {
+		return nil, err
+	}
+	return types.ParseRequest(request)
+}
+
+// BuildGetTxnRequestTyped is like BuildGetTxnRequest but returns a typed request
+func BuildGetTxnRequestTyped(submitterDid string, ledgerType string, seqNo int) (*types.Request, error) {
+	return BuildGetTxnRequestTypedCtx(context.Background(), submitterDid, ledgerType, seqNo)
+}
+
+// BuildGetTxnRequestTypedCtx is like BuildGetTxnRequestTyped but returns ctx.Err() if ctx is done before libindy answers.
+func BuildGetTxnRequestTypedCtx(ctx context.Context, submitterDid string, ledgerType string, seqNo int) (*types.Request, error) {
+	request, err := BuildGetTxnRequestCtx(ctx, submitterDid, ledgerType, seqNo)
+	if err != nil {
+		return nil, err
+	}
+	return types.ParseRequest(request)
+}
+
+// SubmitRequestTyped is like SubmitRequest but takes a typed request and returns the typed reply.
+// REQNACK and REJECT replies are returned together with an error wrapping types.ErrRequestRejected.
+func SubmitRequestTyped(ph int, request *types.Request) (*types.Reply, error) {
+	return SubmitRequestTypedCtx(context.Background(), ph, request)
+}
+
+// SubmitRequestTypedCtx is like SubmitRequestTyped but returns ctx.Err() if ctx is done before libindy answers.
+func SubmitRequestTypedCtx(ctx context.Context, ph int, request *types.Request) (*types.Reply, error) {
+	requestJson, err := request.Json()
+	if err != nil {
+		return nil, err
+	}
+	response, err := SubmitRequestCtx(ctx, ph, requestJson)
+	if err != nil {
+		return nil, err
+	}
+	return parseTypedReply(response)
+}
+
+// SignAndSubmitRequestTyped is like SignAndSubmitRequest but takes a typed request and returns the typed reply.
+// REQNACK and REJECT replies are returned together with an error wrapping types.ErrRequestRejected.
+func SignAndSubmitRequestTyped(ph int, wh int, did string, request *types.Request) (*types.Reply, error) {
+	return SignAndSubmitRequestTypedCtx(context.Background(), ph, wh, did, request)
+}
+
+// SignAndSubmitRequestTypedCtx is like SignAndSubmitRequestTyped but returns ctx.Err() if ctx is done before libindy answers.
+func SignAndSubmitRequestTypedCtx(ctx context.Context, ph int, wh int, did string, request *types.Request) (*types.Reply, error) {
+	requestJson, err := request.Json()
+	if err != nil {
+		return nil, err
+	}
+	response, err := SignAndSubmitRequestCtx(ctx, ph, wh, did, requestJson)
+	if err != nil {
+		return nil, err
+	}
+	return parseTypedReply(response)
+}
+
+// SubmitActionTyped is like SubmitAction but takes a typed request and returns the reply of every node
+func SubmitActionTyped(ph int, request *types.Request, nodes []string, timeout int) (types.ActionReplies, error) {
+	return SubmitActionTypedCtx(context.Background(), ph, request, nodes, timeout)
+}
+
+// SubmitActionTypedCtx is like SubmitActionTyped but returns ctx.Err() if ctx is done before libindy answers.
+func SubmitActionTypedCtx(ctx context.Context, ph int, request *types.Request, nodes []string, timeout int) (types.ActionReplies, error) {
+	requestJson, err := request.Json()
+	if err != nil {
+		return nil, err
+	}
+	response, err := SubmitActionCtx(ctx, ph, requestJson, nodes, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return types.ParseActionReplies(response)
+}
+
+func parseTypedReply(response string) (*types.Reply, error) {
+	reply, err := types.ParseReply(response)
+	if err != nil {
+		return nil, err
+	}
+	return reply, reply.Err()
+}
+
+// ParseGetSchemaResponseTyped is like ParseGetSchemaResponse but returns the typed schema and the reply metadata
+func ParseGetSchemaResponseTyped(schemaResponse string) (*types.Schema, types.ReplyMetadata, error) {
+	return ParseGetSchemaResponseTypedCtx(context.Background(), schemaResponse)
+}
+
+// ParseGetSchemaResponseTypedCtx is like ParseGetSchemaResponseTyped but returns ctx.Err() if ctx is done before libindy answers.
+func ParseGetSchemaResponseTypedCtx(ctx context.Context, schemaResponse string) (*types.Schema, types.ReplyMetadata, error) {
+	meta, err := replyMetadata(schemaResponse)
+	if err != nil {
+		return nil, meta, err
+	}
+	_, schemaJson, err := ParseGetSchemaResponseCtx(ctx, schemaResponse)
+	if err != nil {
+		return nil, meta, err
+	}
+	var schema types.Schema
+	err = json.Unmarshal([]byte(schemaJson), &schema)
+	if err != nil {
+		return nil, meta, err
+	}
+	return &schema, meta, nil
+}
+
+// ParseGetCredDefResponseTyped is like ParseGetCredDefResponse but returns the typed credential definition and the reply metadata
+func ParseGetCredDefResponseTyped(getCredDefResp string) (*types.CredentialDefinition, types.ReplyMetadata, error) {
+	return ParseGetCredDefResponseTypedCtx(context.Background(), getCredDefResp)
+}
+
+// ParseGetCredDefResponseTypedCtx is like ParseGetCredDefResponseTyped but returns ctx.Err() if ctx is done before libindy answers.
+func ParseGetCredDefResponseTypedCtx(ctx context.Context, getCredDefResp string) (*types.CredentialDefinition, types.ReplyMetadata, error) {
+	meta, err := replyMetadata(getCredDefResp)
+	if err != nil {
+		return nil, meta, err
+	}
+	_, credDefJson, err := ParseGetCredDefResponseCtx(ctx, getCredDefResp)
+	if err != nil {
+		return nil, meta, err
+	}
+	var credDef types.CredentialDefinition
+	err = json.Unmarshal([]byte(credDefJson), &credDef)
+	if err != nil {
+		return nil, meta, err
+	}
+	return &credDef, meta, nil
+}
+
+// ParseGetRevocRegDefResponseTyped is like ParseGetRevocRegDefResponse but returns the typed definition and the reply metadata
+func ParseGetRevocRegDefResponseTyped(getRevocRegDefResponse string) (*types.RevocRegDef, types.ReplyMetadata, error) {
+	return ParseGetRevocRegDefResponseTypedCtx(context.Background(), getRevocRegDefResponse)
+}
+
+// ParseGetRevocRegDefResponseTypedCtx is like ParseGetRevocRegDefResponseTyped but returns ctx.Err() if ctx is done before libindy answers.
+func ParseGetRevocRegDefResponseTypedCtx(ctx context.Context, getRevocRegDefResponse string) (*types.RevocRegDef, types.ReplyMetadata, error) {
+	meta, err := replyMetadata(getRevocRegDefResponse)
+	if err != nil {
+		return nil, meta, err
+	}
+	_, revRegDefJson, err := ParseGetRevocRegDefResponseCtx(ctx, getRevocRegDefResponse)
+	if err != nil {
+		return nil, meta, err
+	}
+	var revRegDef types.RevocRegDef
+	err = json.Unmarshal([]byte(revRegDefJson), &revRegDef)
+	if err != nil {
+		return nil, meta, err
+	}
+	return &revRegDef, meta, nil
+}
+
+// ParseGetRevocRegResponseTyped is like ParseGetRevocRegResponse but returns the typed registry
+func ParseGetRevocRegResponseTyped(getRevRegResp string) (revRegId string, revReg *types.RevocRegDelta, timestamp uint64, err error) {
+	return ParseGetRevocRegResponseTypedCtx(context.Background(), getRevRegResp)
+}
+
+// ParseGetRevocRegResponseTypedCtx is like ParseGetRevocRegResponseTyped but returns ctx.Err() if ctx is done before libindy answers.
+func ParseGetRevocRegResponseTypedCtx(ctx context.Context, getRevRegResp string) (revRegId string, revReg *types.RevocRegDelta, timestamp uint64, err error) {
+	revRegId, revRegJson, timestamp, err := ParseGetRevocRegResponseCtx(ctx, getRevRegResp)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	revReg = &types.RevocRegDelta{}
+	err = json.Unmarshal([]byte(revRegJson), revReg)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	return revRegId, revReg, timestamp, nil
+}
+
+// ParseGetRevocRegDeltaResponseTyped is like ParseGetRevocRegDeltaResponse but returns the typed delta
+func ParseGetRevocRegDeltaResponseTyped(getRevRegDeltaResp string) (revRegId string, delta *types.RevocRegDelta, timestamp uint64, err error) {
+	return ParseGetRevocRegDeltaResponseTypedCtx(context.Background(), getRevRegDeltaResp)
+}
+
+// ParseGetRevocRegDeltaResponseTypedCtx is like ParseGetRevocRegDeltaResponseTyped but returns ctx.Err() if ctx is done before libindy answers.
+func ParseGetRevocRegDeltaResponseTypedCtx(ctx context.Context, getRevRegDeltaResp string) (revRegId string, delta *types.RevocRegDelta, timestamp uint64, err error) {
+	revRegId, deltaJson, timestamp, err := ParseGetRevocRegDeltaResponseCtx(ctx, getRevRegDeltaResp)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	delta = &types.RevocRegDelta{}
+	err = json.Unmarshal([]byte(deltaJson), delta)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	return revRegId, delta, timestamp, nil
+}
+
+// ParseGetNymResponseTyped is like ParseGetNymResponse but returns the typed nym and the reply metadata
+func ParseGetNymResponseTyped(nymResponse string) (*types.Nym, types.ReplyMetadata, error) {
+	return ParseGetNymResponseTypedCtx(context.Background(), nymResponse)
+}
+
+// ParseGetNymResponseTypedCtx is like ParseGetNymResponseTyped but returns ctx.Err() if ctx is done before libindy answers.
+func ParseGetNymResponseTypedCtx(ctx context.Context, nymResponse string) (*types.Nym, types.ReplyMetadata, error) {
+	meta, err := replyMetadata(nymResponse)
+	if err != nil {
+		return nil, meta, err
+	}
+	nymJson, err := ParseGetNymResponseCtx(ctx, nymResponse)
+	if err != nil {
+		return nil, meta, err
+	}
+	var nym types.Nym
+	err = json.Unmarshal([]byte(nymJson), &nym)
+	if err != nil {
+		return nil, meta, err
+	}
+	return &nym, meta, nil
+}
+
+// replyMetadata reads seqNo, txnTime and the state proof of a reply
+func replyMetadata(response string) (types.ReplyMetadata, error) {
+	reply, err := types.ParseReply(response)
+	if err != nil {
+		return types.ReplyMetadata{}, err
+	}
+	return reply.Metadata(), reply.Err()
+}
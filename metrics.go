@@ -12,12 +12,21 @@
 
 package indySDK
 
-import "github.com/joyride9999/IndySdkGoBindings/metrics"
+import (
+	"context"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"github.com/joyride9999/IndySdkGoBindings/metrics"
+)
 
 // Collect collect metrics
 func Collect() (string, error) {
+	return CollectCtx(context.Background())
+}
+
+// CollectCtx is like Collect but returns ctx.Err() if ctx is done before libindy answers.
+func CollectCtx(ctx context.Context) (string, error) {
 	channel := metrics.Collect()
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -23,7 +23,6 @@ import "C"
 
 import (
 	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
-	"errors"
 )
 
 //export collectCB
@@ -31,8 +30,8 @@ func collectCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, js *C.ch
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{string(C.GoString(js))}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -54,8 +53,8 @@ func Collect() chan indyUtils.IndyResult {
 	//res := C.indy_collect_metrics(commandHandle,
 	//	(C.cb_collect)(unsafe.Pointer(C.collectCB)))
 	//if res != 0 {
-	//	errMsg := indyUtils.GetIndyError(int(res))
-	//	go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+	//	indyErr := indyUtils.NewIndyError(int(res))
+	//	go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 	//	return future
 	//}
 	//
@@ -16,14 +16,21 @@ package indySDK
 */
 import "C"
 import (
-	"github.com/joyride9999/IndySdkGoBindings/mod"
+	"context"
 	"encoding/json"
 	"errors"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"github.com/joyride9999/IndySdkGoBindings/mod"
 	"unsafe"
 )
 
 // SetRuntimeConfig set libindy runtime configuration
 func SetRuntimeConfig(config mod.Config) error {
+	return SetRuntimeConfigCtx(context.Background(), config)
+}
+
+// SetRuntimeConfigCtx is like SetRuntimeConfig but returns ctx.Err() if ctx is done before libindy answers.
+func SetRuntimeConfigCtx(ctx context.Context, config mod.Config) error {
 
 	jsonConfig, err := json.Marshal(config)
 	if err != nil {
@@ -34,6 +41,6 @@ func SetRuntimeConfig(config mod.Config) error {
 	defer C.free(upCfgJson)
 
 	channel := mod.SetRuntimeConfig(upCfgJson)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
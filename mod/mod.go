@@ -20,7 +20,6 @@ package mod
 import "C"
 import (
 	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
-	"errors"
 	"unsafe"
 )
 
@@ -42,8 +41,8 @@ func SetRuntimeConfig(config unsafe.Pointer) chan indyUtils.IndyResult {
 	// Call indy_set_runtime_config
 	res := C.indy_set_runtime_config((*C.char)(config))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
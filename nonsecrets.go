@@ -17,12 +17,20 @@ package indySDK
 */
 import "C"
 import (
+	"context"
+	"encoding/json"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
 	"github.com/joyride9999/IndySdkGoBindings/nonsecrets"
 	"unsafe"
 )
 
 // IndyAddWalletRecord Create a new non-secret record in the wallet.
 func IndyAddWalletRecord(wh int, recordType string, recordId string, recordValue string, tagsJson string) (err error) {
+	return IndyAddWalletRecordCtx(context.Background(), wh, recordType, recordId, recordValue, tagsJson)
+}
+
+// IndyAddWalletRecordCtx is like IndyAddWalletRecord but returns ctx.Err() if ctx is done before libindy answers.
+func IndyAddWalletRecordCtx(ctx context.Context, wh int, recordType string, recordId string, recordValue string, tagsJson string) (err error) {
 
 	upRecordType := unsafe.Pointer(C.CString(recordType))
 	defer C.free(upRecordType)
@@ -34,12 +42,17 @@ func IndyAddWalletRecord(wh int, recordType string, recordId string, recordValue
 	defer C.free(upRecordTag)
 
 	channel := nonsecrets.IndyAddWalletRecord(wh, upRecordType, upRecordId, upRecordValue, upRecordTag)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // IndyAddWalletRecordTags Add new tags to the wallet record.
 func IndyAddWalletRecordTags(wh int, recordType string, recordId string, tagsJson string) (err error) {
+	return IndyAddWalletRecordTagsCtx(context.Background(), wh, recordType, recordId, tagsJson)
+}
+
+// IndyAddWalletRecordTagsCtx is like IndyAddWalletRecordTags but returns ctx.Err() if ctx is done before libindy answers.
+func IndyAddWalletRecordTagsCtx(ctx context.Context, wh int, recordType string, recordId string, tagsJson string) (err error) {
 	upRecordType := unsafe.Pointer(C.CString(recordType))
 	defer C.free(upRecordType)
 	upRecordId := unsafe.Pointer(C.CString(recordId))
@@ -48,12 +61,17 @@ func IndyAddWalletRecordTags(wh int, recordType string, recordId string, tagsJso
 	defer C.free(upRecordTag)
 
 	channel := nonsecrets.IndyAddWalletRecordTags(wh, upRecordType, upRecordId, upRecordTag)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // IndyGetWalletRecord Create a new non-secret record in the wallet.
 func IndyGetWalletRecord(wh int, recordType string, recordId string, options string) (recordJson string, err error) {
+	return IndyGetWalletRecordCtx(context.Background(), wh, recordType, recordId, options)
+}
+
+// IndyGetWalletRecordCtx is like IndyGetWalletRecord but returns ctx.Err() if ctx is done before libindy answers.
+func IndyGetWalletRecordCtx(ctx context.Context, wh int, recordType string, recordId string, options string) (recordJson string, err error) {
 
 	upRecordType := unsafe.Pointer(C.CString(recordType))
 	defer C.free(upRecordType)
@@ -68,7 +86,7 @@ func IndyGetWalletRecord(wh int, recordType string, recordId string, options str
 	defer C.free(upOptions)
 
 	channel := nonsecrets.IndyGetWalletRecord(wh, upRecordType, upRecordId, upOptions)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -77,18 +95,28 @@ func IndyGetWalletRecord(wh int, recordType string, recordId string, options str
 
 // IndyDeleteWalletRecord Delete an existing wallet record in the wallet.
 func IndyDeleteWalletRecord(wh int, recordType string, recordId string) (err error) {
+	return IndyDeleteWalletRecordCtx(context.Background(), wh, recordType, recordId)
+}
+
+// IndyDeleteWalletRecordCtx is like IndyDeleteWalletRecord but returns ctx.Err() if ctx is done before libindy answers.
+func IndyDeleteWalletRecordCtx(ctx context.Context, wh int, recordType string, recordId string) (err error) {
 	upRecordType := unsafe.Pointer(C.CString(recordType))
 	defer C.free(upRecordType)
 	upRecordId := unsafe.Pointer(C.CString(recordId))
 	defer C.free(upRecordId)
 
 	channel := nonsecrets.IndyDeleteWalletRecord(wh, upRecordType, upRecordId)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // IndyDeleteWalletRecordTags Delete tags from the wallet record.
 func IndyDeleteWalletRecordTags(wh int, recordType string, recordId string, tagNames string) (err error) {
+	return IndyDeleteWalletRecordTagsCtx(context.Background(), wh, recordType, recordId, tagNames)
+}
+
+// IndyDeleteWalletRecordTagsCtx is like IndyDeleteWalletRecordTags but returns ctx.Err() if ctx is done before libindy answers.
+func IndyDeleteWalletRecordTagsCtx(ctx context.Context, wh int, recordType string, recordId string, tagNames string) (err error) {
 	upRecordType := unsafe.Pointer(C.CString(recordType))
 	defer C.free(upRecordType)
 	upRecordId := unsafe.Pointer(C.CString(recordId))
@@ -97,12 +125,17 @@ func IndyDeleteWalletRecordTags(wh int, recordType string, recordId string, tagN
 	defer C.free(upTagNames)
 
 	channel := nonsecrets.IndyDeleteWalletRecordTags(wh, upRecordType, upRecordId, upTagNames)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // IndyUpdateWalletRecordValue Update a non-secret wallet record value.
 func IndyUpdateWalletRecordValue(wh int, recordType string, recordId string, recordValue string) (err error) {
+	return IndyUpdateWalletRecordValueCtx(context.Background(), wh, recordType, recordId, recordValue)
+}
+
+// IndyUpdateWalletRecordValueCtx is like IndyUpdateWalletRecordValue but returns ctx.Err() if ctx is done before libindy answers.
+func IndyUpdateWalletRecordValueCtx(ctx context.Context, wh int, recordType string, recordId string, recordValue string) (err error) {
 
 	upRecordType := unsafe.Pointer(C.CString(recordType))
 	defer C.free(upRecordType)
@@ -112,12 +145,17 @@ func IndyUpdateWalletRecordValue(wh int, recordType string, recordId string, rec
 	defer C.free(upRecordValue)
 
 	channel := nonsecrets.IndyUpdateWalletRecordValue(wh, upRecordType, upRecordId, upRecordValue)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // IndyUpdateWalletRecordTags Update a non-secret wallet record value.
 func IndyUpdateWalletRecordTags(wh int, recordType string, recordId string, recordTags string) (err error) {
+	return IndyUpdateWalletRecordTagsCtx(context.Background(), wh, recordType, recordId, recordTags)
+}
+
+// IndyUpdateWalletRecordTagsCtx is like IndyUpdateWalletRecordTags but returns ctx.Err() if ctx is done before libindy answers.
+func IndyUpdateWalletRecordTagsCtx(ctx context.Context, wh int, recordType string, recordId string, recordTags string) (err error) {
 	upRecordType := unsafe.Pointer(C.CString(recordType))
 	defer C.free(upRecordType)
 	upRecordId := unsafe.Pointer(C.CString(recordId))
@@ -126,12 +164,17 @@ func IndyUpdateWalletRecordTags(wh int, recordType string, recordId string, reco
 	defer C.free(upRecordTags)
 
 	channel := nonsecrets.IndyUpdateWalletRecordTags(wh, upRecordType, upRecordId, upRecordTags)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // IndyOpenWalletSearch Search for wallet records.
 func IndyOpenWalletSearch(wh int, recordType string, query string, options string) (searchHandle int, err error) {
+	return IndyOpenWalletSearchCtx(context.Background(), wh, recordType, query, options)
+}
+
+// IndyOpenWalletSearchCtx is like IndyOpenWalletSearch but returns ctx.Err() if ctx is done before libindy answers.
+func IndyOpenWalletSearchCtx(ctx context.Context, wh int, recordType string, query string, options string) (searchHandle int, err error) {
 	upRecordType := unsafe.Pointer(C.CString(recordType))
 	defer C.free(upRecordType)
 	upQuery := unsafe.Pointer(GetOptionalValue(query))
@@ -140,7 +183,7 @@ func IndyOpenWalletSearch(wh int, recordType string, query string, options strin
 	defer C.free(upOptions)
 
 	channel := nonsecrets.IndyOpenWalletSearch(wh, upRecordType, upQuery, upOptions)
-	result := <-channel
+	result := indyUtils.WaitForHandle(ctx, channel, func(sh int) { IndyCloseWalletSearch(sh) })
 	if result.Error != nil {
 		return 0, result.Error
 	}
@@ -149,8 +192,13 @@ func IndyOpenWalletSearch(wh int, recordType string, query string, options strin
 
 // IndyFetchWalletSearchNextRecords Fetch next records for wallet search.
 func IndyFetchWalletSearchNextRecords(wh int, sh int, count int32) (recordsJson string, err error) {
+	return IndyFetchWalletSearchNextRecordsCtx(context.Background(), wh, sh, count)
+}
+
+// IndyFetchWalletSearchNextRecordsCtx is like IndyFetchWalletSearchNextRecords but returns ctx.Err() if ctx is done before libindy answers.
+func IndyFetchWalletSearchNextRecordsCtx(ctx context.Context, wh int, sh int, count int32) (recordsJson string, err error) {
 	channel := nonsecrets.IndyFetchWalletSearchNextRecords(wh, sh, count)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -159,7 +207,60 @@ func IndyFetchWalletSearchNextRecords(wh int, sh int, count int32) (recordsJson
 
 // IndyCloseWalletSearch Close wallet search (make search handle invalid).
 func IndyCloseWalletSearch(sh int) (err error) {
+	return IndyCloseWalletSearchCtx(context.Background(), sh)
+}
+
+// IndyCloseWalletSearchCtx is like IndyCloseWalletSearch but returns ctx.Err() if ctx is done before libindy answers.
+func IndyCloseWalletSearchCtx(ctx context.Context, sh int) (err error) {
 	channel := nonsecrets.IndyCloseWalletSearch(sh)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
+
+// WalletSearchPageSize records fetched per round trip by IndySearchWalletRecords
+var WalletSearchPageSize int32 = 100
+
+// IndySearchWalletRecords returns all the records of the type matching the wql query, with their value and tags
+func IndySearchWalletRecords(wh int, recordType string, query string) ([]nonsecrets.Record, error) {
+	return IndySearchWalletRecordsCtx(context.Background(), wh, recordType, query)
+}
+
+// IndySearchWalletRecordsCtx is like IndySearchWalletRecords but returns ctx.Err() if ctx is done before libindy answers.
+func IndySearchWalletRecordsCtx(ctx context.Context, wh int, recordType string, query string) ([]nonsecrets.Record, error) {
+	sh, err := IndyOpenWalletSearchCtx(ctx, wh, recordType, query, `{"retrieveTotalCount":false,"retrieveValue":true,"retrieveTags":true}`)
+	if err != nil {
+		return nil, err
+	}
+	defer IndyCloseWalletSearch(sh)
+
+	var records []nonsecrets.Record
+	for {
+		recordsJson, err := IndyFetchWalletSearchNextRecordsCtx(ctx, wh, sh, WalletSearchPageSize)
+		if err != nil {
+			return nil, err
+		}
+		var page nonsecrets.SearchRecords
+		err = json.Unmarshal([]byte(recordsJson), &page)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, page.Records...)
+		if int32(len(page.Records)) < WalletSearchPageSize {
+			return records, nil
+		}
+	}
+}
+
+// getWalletRecordCtx reads a record with its value and tags
+func getWalletRecordCtx(ctx context.Context, wh int, recordType string, recordId string) (*nonsecrets.Record, error) {
+	recordJson, err := IndyGetWalletRecordCtx(ctx, wh, recordType, recordId, `{"retrieveValue":true,"retrieveTags":true}`)
+	if err != nil {
+		return nil, err
+	}
+	var record nonsecrets.Record
+	err = json.Unmarshal([]byte(recordJson), &record)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
@@ -0,0 +1,27 @@
+/*
+// ******************************************************************
+// Purpose: non secret wallet records models
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package nonsecrets
+
+// Record non secret wallet record as returned by get record and fetch search next records,
+// the fields not requested in the options are empty
+type Record struct {
+	Id    string            `json:"id"`
+	Type  string            `json:"type,omitempty"`
+	Value string            `json:"value,omitempty"`
+	Tags  map[string]string `json:"tags,omitempty"`
+}
+
+// SearchRecords page of records returned by fetch search next records
+type SearchRecords struct {
+	TotalCount *int     `json:"totalCount,omitempty"`
+	Records    []Record `json:"records"`
+}
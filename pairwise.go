@@ -17,18 +17,25 @@ package indySDK
 */
 import "C"
 import (
+	"context"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
 	"github.com/joyride9999/IndySdkGoBindings/pairwise"
 	"unsafe"
 )
 
 // IsPairwiseExists purge credential definition cache
 func IsPairwiseExists(wh int, theirDID string) (bool, error) {
+	return IsPairwiseExistsCtx(context.Background(), wh, theirDID)
+}
+
+// IsPairwiseExistsCtx is like IsPairwiseExists but returns ctx.Err() if ctx is done before libindy answers.
+func IsPairwiseExistsCtx(ctx context.Context, wh int, theirDID string) (bool, error) {
 
 	upTheirDid := unsafe.Pointer(C.CString(theirDID))
 	defer C.free(upTheirDid)
 
 	channel := pairwise.IsPairwiseExists(wh, upTheirDid)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return false, result.Error
 	}
@@ -37,6 +44,11 @@ func IsPairwiseExists(wh int, theirDID string) (bool, error) {
 
 // CreatePairwise creates pairwise
 func CreatePairwise(wh int, theirDID, myDID, meta string) error {
+	return CreatePairwiseCtx(context.Background(), wh, theirDID, myDID, meta)
+}
+
+// CreatePairwiseCtx is like CreatePairwise but returns ctx.Err() if ctx is done before libindy answers.
+func CreatePairwiseCtx(ctx context.Context, wh int, theirDID, myDID, meta string) error {
 
 	upTheirDid := unsafe.Pointer(C.CString(theirDID))
 	defer C.free(upTheirDid)
@@ -46,14 +58,19 @@ func CreatePairwise(wh int, theirDID, myDID, meta string) error {
 	defer C.free(upMeta)
 
 	channel := pairwise.CreatePairwise(wh, upTheirDid, upMyDid, upMeta)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // ListPairwise get list of saved pairwise.
 func ListPairwise(wh int) (string, error) {
+	return ListPairwiseCtx(context.Background(), wh)
+}
+
+// ListPairwiseCtx is like ListPairwise but returns ctx.Err() if ctx is done before libindy answers.
+func ListPairwiseCtx(ctx context.Context, wh int) (string, error) {
 	channel := pairwise.ListPairwise(wh)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -62,11 +79,16 @@ func ListPairwise(wh int) (string, error) {
 
 // GetPairwise gets pairwise information for specific their_did
 func GetPairwise(wh int, theirDID string) (string, error) {
+	return GetPairwiseCtx(context.Background(), wh, theirDID)
+}
+
+// GetPairwiseCtx is like GetPairwise but returns ctx.Err() if ctx is done before libindy answers.
+func GetPairwiseCtx(ctx context.Context, wh int, theirDID string) (string, error) {
 	upTheirDid := unsafe.Pointer(C.CString(theirDID))
 	defer C.free(upTheirDid)
 
 	channel := pairwise.GetPairwise(wh, upTheirDid)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -75,12 +97,17 @@ func GetPairwise(wh int, theirDID string) (string, error) {
 
 // SetPairwiseMetadata get list of saved pairwise
 func SetPairwiseMetadata(wh int, theirDID string, meta string) error {
+	return SetPairwiseMetadataCtx(context.Background(), wh, theirDID, meta)
+}
+
+// SetPairwiseMetadataCtx is like SetPairwiseMetadata but returns ctx.Err() if ctx is done before libindy answers.
+func SetPairwiseMetadataCtx(ctx context.Context, wh int, theirDID string, meta string) error {
 	upTheirDid := unsafe.Pointer(C.CString(theirDID))
 	defer C.free(upTheirDid)
 	upMeta := unsafe.Pointer(C.CString(meta))
 	defer C.free(upMeta)
 
 	channel := pairwise.SetPairwiseMetadata(wh, upTheirDid, upMeta)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
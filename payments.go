@@ -12,12 +12,27 @@
 
 package indySDK
 
-import "github.com/joyride9999/IndySdkGoBindings/payments"
+/*
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"context"
+	"encoding/json"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"github.com/joyride9999/IndySdkGoBindings/payments"
+	"unsafe"
+)
 
 // CreatePaymentAddress creates the payment address for specified payment method
 func CreatePaymentAddress(wh int, paymentMethod string, options payments.Config) (string, error) {
+	return CreatePaymentAddressCtx(context.Background(), wh, paymentMethod, options)
+}
+
+// CreatePaymentAddressCtx is like CreatePaymentAddress but returns ctx.Err() if ctx is done before libindy answers.
+func CreatePaymentAddressCtx(ctx context.Context, wh int, paymentMethod string, options payments.Config) (string, error) {
 	channel := payments.CreatePaymentAddress(wh, paymentMethod, options)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -26,8 +41,13 @@ func CreatePaymentAddress(wh int, paymentMethod string, options payments.Config)
 
 // ListPaymentAddress lists all payment addresses that are stored in the wallet
 func ListPaymentAddress(wh int) (string, error) {
+	return ListPaymentAddressCtx(context.Background(), wh)
+}
+
+// ListPaymentAddressCtx is like ListPaymentAddress but returns ctx.Err() if ctx is done before libindy answers.
+func ListPaymentAddressCtx(ctx context.Context, wh int) (string, error) {
 	channel := payments.ListPaymentAddress(wh)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", result.Error
 	}
@@ -36,120 +56,419 @@ func ListPaymentAddress(wh int) (string, error) {
 
 // AddRequestFees lists all payment addresses that are stored in the wallet
 func AddRequestFees(wh int, submitterDID string, req string, inputs string, outputs string, extra string) (string, string, error) {
+	return AddRequestFeesCtx(context.Background(), wh, submitterDID, req, inputs, outputs, extra)
+}
+
+// AddRequestFeesCtx is like AddRequestFees but returns ctx.Err() if ctx is done before libindy answers.
+func AddRequestFeesCtx(ctx context.Context, wh int, submitterDID string, req string, inputs string, outputs string, extra string) (string, string, error) {
 	channel := payments.AddRequestFees(wh, submitterDID, req, inputs, outputs, extra)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return "", "", result.Error
+	}
+	return result.Results[0].(string), result.Results[1].(string), result.Error
+}
+
+// ParseResponseWithFees parses response for Indy request with fees
+func ParseResponseWithFees(paymentMethod string, resp string) ([]payments.Receipt, error) {
+	return ParseResponseWithFeesCtx(context.Background(), paymentMethod, resp)
+}
+
+// ParseResponseWithFeesCtx is like ParseResponseWithFees but returns ctx.Err() if ctx is done before libindy answers.
+func ParseResponseWithFeesCtx(ctx context.Context, paymentMethod string, resp string) ([]payments.Receipt, error) {
+	upPaymentMethod := unsafe.Pointer(C.CString(paymentMethod))
+	defer C.free(upPaymentMethod)
+	upResp := unsafe.Pointer(C.CString(resp))
+	defer C.free(upResp)
+
+	channel := payments.ParseResponseWithFees(upPaymentMethod, upResp)
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var receipts []payments.Receipt
+	err := json.Unmarshal([]byte(result.Results[0].(string)), &receipts)
+	if err != nil {
+		return nil, err
+	}
+	return receipts, nil
+}
+
+// BuildGetPaymentSourcesRequest builds Indy request for getting sources list for payment address, returns the request and the payment method.
+// Use BuildGetPaymentSourcesWithFromRequest for addresses with many sources.
+func BuildGetPaymentSourcesRequest(wh int, submitterDID string, paymentAddress string) (string, string, error) {
+	return BuildGetPaymentSourcesRequestCtx(context.Background(), wh, submitterDID, paymentAddress)
+}
+
+// BuildGetPaymentSourcesRequestCtx is like BuildGetPaymentSourcesRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildGetPaymentSourcesRequestCtx(ctx context.Context, wh int, submitterDID string, paymentAddress string) (string, string, error) {
+	upSubmitterDID := unsafe.Pointer(GetOptionalValue(submitterDID))
+	defer C.free(upSubmitterDID)
+	upPaymentAddress := unsafe.Pointer(C.CString(paymentAddress))
+	defer C.free(upPaymentAddress)
+
+	channel := payments.BuildGetPaymentSourcesRequest(wh, upSubmitterDID, upPaymentAddress)
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return "", "", result.Error
+	}
+	return result.Results[0].(string), result.Results[1].(string), result.Error
+}
+
+// ParseGetPaymentSourcesResponse parses response for Indy request for getting sources list
+func ParseGetPaymentSourcesResponse(paymentMethod string, resp string) ([]payments.Source, error) {
+	return ParseGetPaymentSourcesResponseCtx(context.Background(), paymentMethod, resp)
+}
+
+// ParseGetPaymentSourcesResponseCtx is like ParseGetPaymentSourcesResponse but returns ctx.Err() if ctx is done before libindy answers.
+func ParseGetPaymentSourcesResponseCtx(ctx context.Context, paymentMethod string, resp string) ([]payments.Source, error) {
+	upPaymentMethod := unsafe.Pointer(C.CString(paymentMethod))
+	defer C.free(upPaymentMethod)
+	upResp := unsafe.Pointer(C.CString(resp))
+	defer C.free(upResp)
+
+	channel := payments.ParseGetPaymentSourcesResponse(upPaymentMethod, upResp)
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var sources []payments.Source
+	err := json.Unmarshal([]byte(result.Results[0].(string)), &sources)
+	if err != nil {
+		return nil, err
+	}
+	return sources, nil
+}
+
+// BuildPaymentReq builds Indy request for doing payment according to this payment method, returns the request and the payment method.
+// inputs are the payment sources to consume.
+func BuildPaymentReq(wh int, submitterDID string, inputs []string, outputs []payments.Output, extra string) (string, string, error) {
+	return BuildPaymentReqCtx(context.Background(), wh, submitterDID, inputs, outputs, extra)
+}
+
+// BuildPaymentReqCtx is like BuildPaymentReq but returns ctx.Err() if ctx is done before libindy answers.
+func BuildPaymentReqCtx(ctx context.Context, wh int, submitterDID string, inputs []string, outputs []payments.Output, extra string) (string, string, error) {
+	inputsJson, err := json.Marshal(inputs)
+	if err != nil {
+		return "", "", err
+	}
+	outputsJson, err := json.Marshal(outputs)
+	if err != nil {
+		return "", "", err
+	}
+
+	upSubmitterDID := unsafe.Pointer(GetOptionalValue(submitterDID))
+	defer C.free(upSubmitterDID)
+	upInputs := unsafe.Pointer(C.CString(string(inputsJson)))
+	defer C.free(upInputs)
+	upOutputs := unsafe.Pointer(C.CString(string(outputsJson)))
+	defer C.free(upOutputs)
+	upExtra := unsafe.Pointer(GetOptionalValue(extra))
+	defer C.free(upExtra)
+
+	channel := payments.BuildPaymentReq(wh, upSubmitterDID, upInputs, upOutputs, upExtra)
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return "", "", result.Error
+	}
+	return result.Results[0].(string), result.Results[1].(string), result.Error
+}
+
+// ParsePaymentResponse parses response for Indy request for payment txn
+func ParsePaymentResponse(paymentMethod string, resp string) ([]payments.Receipt, error) {
+	return ParsePaymentResponseCtx(context.Background(), paymentMethod, resp)
+}
+
+// ParsePaymentResponseCtx is like ParsePaymentResponse but returns ctx.Err() if ctx is done before libindy answers.
+func ParsePaymentResponseCtx(ctx context.Context, paymentMethod string, resp string) ([]payments.Receipt, error) {
+	upPaymentMethod := unsafe.Pointer(C.CString(paymentMethod))
+	defer C.free(upPaymentMethod)
+	upResp := unsafe.Pointer(C.CString(resp))
+	defer C.free(upResp)
+
+	channel := payments.ParsePaymentResponse(upPaymentMethod, upResp)
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var receipts []payments.Receipt
+	err := json.Unmarshal([]byte(result.Results[0].(string)), &receipts)
+	if err != nil {
+		return nil, err
+	}
+	return receipts, nil
+}
+
+// PreparePaymentExtraWithAcceptanceData appends the transaction author agreement acceptance to the payment extra json.
+// Pass either text and version or the taaDigest.
+func PreparePaymentExtraWithAcceptanceData(extra string, text string, version string, taaDigest string, mechanism string, time uint64) (string, error) {
+	return PreparePaymentExtraWithAcceptanceDataCtx(context.Background(), extra, text, version, taaDigest, mechanism, time)
+}
+
+// PreparePaymentExtraWithAcceptanceDataCtx is like PreparePaymentExtraWithAcceptanceData but returns ctx.Err() if ctx is done before libindy answers.
+func PreparePaymentExtraWithAcceptanceDataCtx(ctx context.Context, extra string, text string, version string, taaDigest string, mechanism string, time uint64) (string, error) {
+	upExtra := unsafe.Pointer(GetOptionalValue(extra))
+	defer C.free(upExtra)
+	upText := unsafe.Pointer(GetOptionalValue(text))
+	defer C.free(upText)
+	upVersion := unsafe.Pointer(GetOptionalValue(version))
+	defer C.free(upVersion)
+	upTaaDigest := unsafe.Pointer(GetOptionalValue(taaDigest))
+	defer C.free(upTaaDigest)
+	upMechanism := unsafe.Pointer(C.CString(mechanism))
+	defer C.free(upMechanism)
+
+	channel := payments.PreparePaymentExtraWithAcceptanceData(upExtra, upText, upVersion, upTaaDigest, upMechanism, time)
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Results[0].(string), result.Error
+}
+
+// BuildMintReq builds Indy request for doing minting according to this payment method, returns the request and the payment method
+func BuildMintReq(wh int, submitterDID string, outputs []payments.Output, extra string) (string, string, error) {
+	return BuildMintReqCtx(context.Background(), wh, submitterDID, outputs, extra)
+}
+
+// BuildMintReqCtx is like BuildMintReq but returns ctx.Err() if ctx is done before libindy answers.
+func BuildMintReqCtx(ctx context.Context, wh int, submitterDID string, outputs []payments.Output, extra string) (string, string, error) {
+	outputsJson, err := json.Marshal(outputs)
+	if err != nil {
+		return "", "", err
+	}
+
+	upSubmitterDID := unsafe.Pointer(GetOptionalValue(submitterDID))
+	defer C.free(upSubmitterDID)
+	upOutputs := unsafe.Pointer(C.CString(string(outputsJson)))
+	defer C.free(upOutputs)
+	upExtra := unsafe.Pointer(GetOptionalValue(extra))
+	defer C.free(upExtra)
+
+	channel := payments.BuildMintReq(wh, upSubmitterDID, upOutputs, upExtra)
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return "", "", result.Error
+	}
+	return result.Results[0].(string), result.Results[1].(string), result.Error
+}
+
+// BuildSetTxnFeesReq builds Indy request for setting fees for transactions in the ledger
+func BuildSetTxnFeesReq(wh int, submitterDID string, paymentMethod string, fees payments.Fees) (string, error) {
+	return BuildSetTxnFeesReqCtx(context.Background(), wh, submitterDID, paymentMethod, fees)
+}
+
+// BuildSetTxnFeesReqCtx is like BuildSetTxnFeesReq but returns ctx.Err() if ctx is done before libindy answers.
+func BuildSetTxnFeesReqCtx(ctx context.Context, wh int, submitterDID string, paymentMethod string, fees payments.Fees) (string, error) {
+	feesJson, err := json.Marshal(fees)
+	if err != nil {
+		return "", err
+	}
+
+	upSubmitterDID := unsafe.Pointer(GetOptionalValue(submitterDID))
+	defer C.free(upSubmitterDID)
+	upPaymentMethod := unsafe.Pointer(C.CString(paymentMethod))
+	defer C.free(upPaymentMethod)
+	upFees := unsafe.Pointer(C.CString(string(feesJson)))
+	defer C.free(upFees)
+
+	channel := payments.BuildSetTxnFeesReq(wh, upSubmitterDID, upPaymentMethod, upFees)
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Results[0].(string), result.Error
+}
+
+// BuildGetTxnFeesReq builds Indy request for getting fees for transactions in the ledger
+func BuildGetTxnFeesReq(wh int, submitterDID string, paymentMethod string) (string, error) {
+	return BuildGetTxnFeesReqCtx(context.Background(), wh, submitterDID, paymentMethod)
+}
+
+// BuildGetTxnFeesReqCtx is like BuildGetTxnFeesReq but returns ctx.Err() if ctx is done before libindy answers.
+func BuildGetTxnFeesReqCtx(ctx context.Context, wh int, submitterDID string, paymentMethod string) (string, error) {
+	upSubmitterDID := unsafe.Pointer(GetOptionalValue(submitterDID))
+	defer C.free(upSubmitterDID)
+	upPaymentMethod := unsafe.Pointer(C.CString(paymentMethod))
+	defer C.free(upPaymentMethod)
+
+	channel := payments.BuildGetTxnFeesReq(wh, upSubmitterDID, upPaymentMethod)
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Results[0].(string), result.Error
+}
+
+// ParseGetTxnFeesResponse parses response for Indy request for getting fees
+func ParseGetTxnFeesResponse(paymentMethod string, resp string) (payments.Fees, error) {
+	return ParseGetTxnFeesResponseCtx(context.Background(), paymentMethod, resp)
+}
+
+// ParseGetTxnFeesResponseCtx is like ParseGetTxnFeesResponse but returns ctx.Err() if ctx is done before libindy answers.
+func ParseGetTxnFeesResponseCtx(ctx context.Context, paymentMethod string, resp string) (payments.Fees, error) {
+	upPaymentMethod := unsafe.Pointer(C.CString(paymentMethod))
+	defer C.free(upPaymentMethod)
+	upResp := unsafe.Pointer(C.CString(resp))
+	defer C.free(upResp)
+
+	channel := payments.ParseGetTxnFeesResponse(upPaymentMethod, upResp)
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var fees payments.Fees
+	err := json.Unmarshal([]byte(result.Results[0].(string)), &fees)
+	if err != nil {
+		return nil, err
+	}
+	return fees, nil
+}
+
+// BuildVerifyPaymentReq builds Indy request for information to verify the payment receipt, returns the request and the payment method
+func BuildVerifyPaymentReq(wh int, submitterDID string, receipt string) (string, string, error) {
+	return BuildVerifyPaymentReqCtx(context.Background(), wh, submitterDID, receipt)
+}
+
+// BuildVerifyPaymentReqCtx is like BuildVerifyPaymentReq but returns ctx.Err() if ctx is done before libindy answers.
+func BuildVerifyPaymentReqCtx(ctx context.Context, wh int, submitterDID string, receipt string) (string, string, error) {
+	upSubmitterDID := unsafe.Pointer(GetOptionalValue(submitterDID))
+	defer C.free(upSubmitterDID)
+	upReceipt := unsafe.Pointer(C.CString(receipt))
+	defer C.free(upReceipt)
+
+	channel := payments.BuildVerifyPaymentReq(wh, upSubmitterDID, upReceipt)
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", "", result.Error
 	}
 	return result.Results[0].(string), result.Results[1].(string), result.Error
 }
 
-//// ParseResponseWithFees parses response for Indy request with fees
-//func ParseResponseWithFees(wh int, paymentMethod string, resp string) (string, error) {
-//	channel := payments.ParseResponseWithFees(wh, paymentMethod, resp)
-//	result := <-channel
-//	if result.Error != nil {
-//		return "", result.Error
-//	}
-//	return result.Results[0].(string), result.Error
-//}
-//
-//// BuildPaymentReq builds Indy request for doing payment according to this payment method
-//func BuildPaymentReq(wh int, submitterDID string, req string, inputs string, outputs string, extra string) (string, string, error) {
-//	channel := payments.BuildPaymentReq(wh, submitterDID, req, inputs, outputs, extra)
-//	result := <-channel
-//	if result.Error != nil {
-//		return "", "", result.Error
-//	}
-//	return result.Results[0].(string), result.Results[1].(string), result.Error
-//}
-//
-//// ParsePaymentResponse parses response for Indy request for payment txn
-//func ParsePaymentResponse(wh int, paymentMethod string, resp string) (string, error) {
-//	channel := payments.ParsePaymentResponse(wh, paymentMethod, resp)
-//	result := <-channel
-//	if result.Error != nil {
-//		return "", result.Error
-//	}
-//	return result.Results[0].(string), result.Error
-//}
-//
-//// BuildMintReq builds Indy request for doing minting according to this payment method
-//func BuildMintReq(wh int, submitterDID string, outputs string, extra string) (string, string, error) {
-//	channel := payments.BuildMintReq(wh, submitterDID, outputs, extra)
-//	result := <-channel
-//	if result.Error != nil {
-//		return "", "", result.Error
-//	}
-//	return result.Results[0].(string), result.Results[1].(string), result.Error
-//}
-//
-//// BuildSetTxnFeesReq builds Indy request for setting fees for transactions in the ledger
-//func BuildSetTxnFeesReq(wh int, submitterDID string, paymentMethod string, fees string) (string, error) {
-//	channel := payments.BuildSetTxnFeesReq(wh, submitterDID, paymentMethod, fees)
-//	result := <-channel
-//	if result.Error != nil {
-//		return "", result.Error
-//	}
-//	return result.Results[0].(string), result.Error
-//}
-//
-//// BuildGetTxnFeesReq builds Indy request for getting fees for transactions in the ledger
-//func BuildGetTxnFeesReq(wh int, submitterDID string, paymentMethod string) (string, error) {
-//	channel := payments.BuildGetTxnFeesReq(wh, submitterDID, paymentMethod)
-//	result := <-channel
-//	if result.Error != nil {
-//		return "", result.Error
-//	}
-//	return result.Results[0].(string), result.Error
-//}
-//
-//// ParseGetTxnFeesResponse parses response for Indy request for getting fees
-//func ParseGetTxnFeesResponse (paymentMethod string, response string) (string, error) {
-//	channel := payments.ParseGetTxnFeesResponse(paymentMethod, response)
-//	result := <-channel
-//	if result.Error != nil {
-//		return "", result.Error
-//	}
-//	return result.Results[0].(string), result.Error
-//}
-//
-//// BuildVerifyPaymentReq builds Indy request for information to verify the payment receipt
-//func BuildVerifyPaymentReq (wh int, submitterDID string, receipt string) (string, string, error) {
-//	channel := payments.BuildVerifyPaymentReq(wh, submitterDID, receipt)
-//	result := <-channel
-//	if result.Error != nil {
-//		return "", "", result.Error
-//	}
-//	return result.Results[0].(string), result.Results[1].(string), result.Error
-//}
-//
-//// ParseVerifyPaymentResponse parses Indy response with information to verify receipt
-//func ParseVerifyPaymentResponse (submitterDID string, receipt string) (string, error) {
-//	channel := payments.ParseVerifyPaymentResponse(submitterDID, receipt)
-//	result := <-channel
-//	if result.Error != nil {
-//		return "", result.Error
-//	}
-//	return result.Results[0].(string), result.Error
-//}
-//
-//// SignWithAddress signs a message with a payment address
-//func SignWithAddress (wh int, address string, messageRaw uint8, messageLen uint32) (string, error) {
-//	channel := payments.SignWithAddress(wh, address, messageRaw, messageLen)
-//	result := <-channel
-//	if result.Error != nil {
-//		return "", result.Error
-//	}
-//	return result.Results[0].(string), result.Error
-//}
-//
-//// VerifyWithAddress verify a signature with a payment address
-//func VerifyWithAddress (address string, messageRaw uint8, messageLen uint32, signatureRaw uint8, signatureLen uint32) (bool, error) {
-//	channel := payments.VerifyWithAddress(address, messageRaw, messageLen, signatureRaw, signatureLen)
-//	result := <-channel
-//	if result.Error != nil {
-//		return false, result.Error
-//	}
-//	return result.Results[0].(bool), result.Error
-//}
+// ParseVerifyPaymentResponse parses Indy response with information to verify receipt
+func ParseVerifyPaymentResponse(paymentMethod string, resp string) (*payments.VerifyPaymentResult, error) {
+	return ParseVerifyPaymentResponseCtx(context.Background(), paymentMethod, resp)
+}
+
+// ParseVerifyPaymentResponseCtx is like ParseVerifyPaymentResponse but returns ctx.Err() if ctx is done before libindy answers.
+func ParseVerifyPaymentResponseCtx(ctx context.Context, paymentMethod string, resp string) (*payments.VerifyPaymentResult, error) {
+	upPaymentMethod := unsafe.Pointer(C.CString(paymentMethod))
+	defer C.free(upPaymentMethod)
+	upResp := unsafe.Pointer(C.CString(resp))
+	defer C.free(upResp)
+
+	channel := payments.ParseVerifyPaymentResponse(upPaymentMethod, upResp)
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var txn payments.VerifyPaymentResult
+	err := json.Unmarshal([]byte(result.Results[0].(string)), &txn)
+	if err != nil {
+		return nil, err
+	}
+	return &txn, nil
+}
+
+// GetRequestInfo gets the price and signature requirements of the action described by a GET_AUTH_RULE response,
+// fails with a TransactionNotAllowed error when the requester can't perform it
+func GetRequestInfo(getAuthRuleResponse string, requester payments.RequesterInfo, fees payments.Fees) (*payments.RequestInfo, error) {
+	return GetRequestInfoCtx(context.Background(), getAuthRuleResponse, requester, fees)
+}
+
+// GetRequestInfoCtx is like GetRequestInfo but returns ctx.Err() if ctx is done before libindy answers.
+func GetRequestInfoCtx(ctx context.Context, getAuthRuleResponse string, requester payments.RequesterInfo, fees payments.Fees) (*payments.RequestInfo, error) {
+	requesterJson, err := json.Marshal(requester)
+	if err != nil {
+		return nil, err
+	}
+	if fees == nil {
+		fees = payments.Fees{}
+	}
+	feesJson, err := json.Marshal(fees)
+	if err != nil {
+		return nil, err
+	}
+
+	upGetAuthRuleResponse := unsafe.Pointer(C.CString(getAuthRuleResponse))
+	defer C.free(upGetAuthRuleResponse)
+	upRequester := unsafe.Pointer(C.CString(string(requesterJson)))
+	defer C.free(upRequester)
+	upFees := unsafe.Pointer(C.CString(string(feesJson)))
+	defer C.free(upFees)
+
+	channel := payments.GetRequestInfo(upGetAuthRuleResponse, upRequester, upFees)
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var info payments.RequestInfo
+	err = json.Unmarshal([]byte(result.Results[0].(string)), &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// SignWithAddress signs a message with a payment address
+func SignWithAddress(wh int, address string, message []byte) ([]byte, error) {
+	return SignWithAddressCtx(context.Background(), wh, address, message)
+}
+
+// SignWithAddressCtx is like SignWithAddress but returns ctx.Err() if ctx is done before libindy answers.
+func SignWithAddressCtx(ctx context.Context, wh int, address string, message []byte) ([]byte, error) {
+	upAddress := unsafe.Pointer(C.CString(address))
+	defer C.free(upAddress)
+	upMessage := unsafe.Pointer(C.CBytes(message))
+	defer C.free(upMessage)
+
+	channel := payments.SignWithAddress(wh, upAddress, upMessage, uint32(len(message)))
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Results[0].([]byte), result.Error
+}
+
+// VerifyWithAddress verify a signature with a payment address
+func VerifyWithAddress(address string, message []byte, signature []byte) (bool, error) {
+	return VerifyWithAddressCtx(context.Background(), address, message, signature)
+}
+
+// VerifyWithAddressCtx is like VerifyWithAddress but returns ctx.Err() if ctx is done before libindy answers.
+func VerifyWithAddressCtx(ctx context.Context, address string, message []byte, signature []byte) (bool, error) {
+	upAddress := unsafe.Pointer(C.CString(address))
+	defer C.free(upAddress)
+	upMessage := unsafe.Pointer(C.CBytes(message))
+	defer C.free(upMessage)
+	upSignature := unsafe.Pointer(C.CBytes(signature))
+	defer C.free(upSignature)
+
+	channel := payments.VerifyWithAddress(upAddress, upMessage, uint32(len(message)), upSignature, uint32(len(signature)))
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.Results[0].(bool), result.Error
+}
+
+// RegisterPaymentMethod registers a payment method implemented in go, see payments.IPaymentMethod
+func RegisterPaymentMethod(paymentMethod string, method payments.IPaymentMethod) error {
+	return RegisterPaymentMethodCtx(context.Background(), paymentMethod, method)
+}
+
+// RegisterPaymentMethodCtx is like RegisterPaymentMethod but returns ctx.Err() if ctx is done before libindy answers.
+func RegisterPaymentMethodCtx(ctx context.Context, paymentMethod string, method payments.IPaymentMethod) error {
+	upPaymentMethod := unsafe.Pointer(C.CString(paymentMethod))
+	defer C.free(upPaymentMethod)
+
+	channel := payments.RegisterPaymentMethod(upPaymentMethod, method)
+	result := indyUtils.WaitForResult(ctx, channel)
+	return result.Error
+}
@@ -15,3 +15,80 @@ package payments
 type Config struct {
 	Seed	string `json:"seed"`
 }
+
+// Output output of a payment or mint request
+type Output struct {
+	Recipient string `json:"recipient"` // payment address of recipient
+	Amount    uint64 `json:"amount"`
+}
+
+// Source payment source owned by a payment address
+type Source struct {
+	Source         string `json:"source"` // source input, reference it in the inputs of a payment
+	PaymentAddress string `json:"paymentAddress"`
+	Amount         uint64 `json:"amount"`
+	Extra          string `json:"extra,omitempty"`
+}
+
+// Receipt receipt of a payment, mint or request with fees
+type Receipt struct {
+	Receipt   string `json:"receipt"` // can be used for payment referencing and verification
+	Recipient string `json:"recipient"`
+	Amount    uint64 `json:"amount"`
+	Extra     string `json:"extra,omitempty"`
+}
+
+// Fees amount to pay per transaction type (or auth rule fee alias)
+type Fees map[string]uint64
+
+// VerifyPaymentResult transaction of a verified receipt
+type VerifyPaymentResult struct {
+	Sources  []string  `json:"sources"`
+	Receipts []Receipt `json:"receipts"`
+	Extra    string    `json:"extra,omitempty"`
+}
+
+// RequesterInfo who wants to send a request, input of get request info
+type RequesterInfo struct {
+	Role                 string `json:"role,omitempty"`
+	SigCount             uint64 `json:"sig_count"`
+	IsOwner              bool   `json:"is_owner,omitempty"`
+	IsOffLedgerSignature bool   `json:"is_off_ledger_signature,omitempty"`
+}
+
+// RequestInfo price and signature requirements of a request for a requester
+type RequestInfo struct {
+	Price        uint64               `json:"price"`
+	Requirements []RequestRequirement `json:"requirements"`
+}
+
+// RequestRequirement signatures a request needs
+type RequestRequirement struct {
+	Role               string `json:"role,omitempty"`
+	SigCount           uint64 `json:"sig_count"`
+	NeedToBeOwner      bool   `json:"need_to_be_owner"`
+	OffLedgerSignature bool   `json:"off_ledger_signature,omitempty"`
+}
+
+// IPaymentMethod payment method implemented in go, register it with RegisterPaymentMethod. Up to
+// MaxPaymentMethods implementations can be registered side by side, each under its own name.
+// Every handler returns the libindy error code to report next to the go error, a zero code
+// with a non nil error is reported as CommonInvalidState. Handlers run on their own goroutine
+// so they may call back into libindy (wallet, crypto, ledger) before answering.
+type IPaymentMethod interface {
+	CreatePaymentAddress(wh int, config string) (string, int, error)
+	AddRequestFees(wh int, submitterDid string, reqJson string, inputsJson string, outputsJson string, extra string) (string, int, error)
+	ParseResponseWithFees(respJson string) (string, int, error)
+	BuildGetPaymentSourcesRequest(wh int, submitterDid string, paymentAddress string, from int64) (string, int, error)
+	ParseGetPaymentSourcesResponse(respJson string) (string, int64, int, error)
+	BuildPaymentReq(wh int, submitterDid string, inputsJson string, outputsJson string, extra string) (string, int, error)
+	ParsePaymentResponse(respJson string) (string, int, error)
+	BuildMintReq(wh int, submitterDid string, outputsJson string, extra string) (string, int, error)
+	BuildSetTxnFeesReq(wh int, submitterDid string, feesJson string) (string, int, error)
+	BuildGetTxnFeesReq(wh int, submitterDid string) (string, int, error)
+	ParseGetTxnFeesResponse(respJson string) (string, int, error)
+	BuildVerifyPaymentReq(wh int, submitterDid string, receipt string) (string, int, error)
+	ParseVerifyPaymentResponse(respJson string) (string, int, error)
+	SignWithAddress(wh int, address string, message []byte) ([]byte, int, error)
+	VerifyWithAddress(address string, message []byte, signature []byte) (bool, int, error)
+}
@@ -0,0 +1,273 @@
+/*
+// ******************************************************************
+// Purpose: Registers a payment method implemented in go, imports indy_register_payment_method from indy_payment.h
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package payments
+
+/*
+#cgo CFLAGS: -I ../include
+#cgo LDFLAGS: -L${SRCDIR}/../lib -lindy
+#include <indy_core.h>
+*/
+import "C"
+import (
+	"errors"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"sync"
+	"unsafe"
+)
+
+// ErrPaymentMethodSlots is returned when MaxPaymentMethods go payment methods are registered already
+var ErrPaymentMethodSlots = errors.New("all the go payment method slots are taken")
+
+// paymentMethodSlot go payment method registered under a name, libindy calls it through the trampolines of the slot
+type paymentMethodSlot struct {
+	name    string
+	method  IPaymentMethod
+	version int // bumped by every registration, a failed one only restores its own
+}
+
+var (
+	paymentMethodMutex sync.RWMutex
+	paymentMethodSlots [MaxPaymentMethods]paymentMethodSlot
+)
+
+func paymentMethodAt(slot C.int) IPaymentMethod {
+	paymentMethodMutex.RLock()
+	defer paymentMethodMutex.RUnlock()
+	return paymentMethodSlots[slot].method
+}
+
+// paymentMethodSlotOf returns the slot of the payment method name or the first free one, -1 if all are taken.
+// Call with the mutex held.
+func paymentMethodSlotOf(name string) int {
+	free := -1
+	for i := range paymentMethodSlots {
+		if paymentMethodSlots[i].name == name {
+			return i
+		}
+		if free < 0 && paymentMethodSlots[i].name == "" {
+			free = i
+		}
+	}
+	return free
+}
+
+//export createPaymentAddressCustomCB
+func createPaymentAddressCustomCB(slot C.int, commandHandle C.indy_handle_t, walletHandle C.indy_handle_t, config *C.char, cb unsafe.Pointer) C.indy_error_t {
+	method, sConfig := paymentMethodAt(slot), C.GoString(config)
+	answerString(cb, commandHandle, func() (string, int, error) {
+		return method.CreatePaymentAddress(int(walletHandle), sConfig)
+	})
+	return (C.indy_error_t)(0) // Success, the result is passed to cb
+}
+
+//export addRequestFeesCustomCB
+func addRequestFeesCustomCB(slot C.int, commandHandle C.indy_handle_t, walletHandle C.indy_handle_t, submitterDid *C.char, reqJson *C.char, inputsJson *C.char, outputsJson *C.char, extra *C.char, cb unsafe.Pointer) C.indy_error_t {
+	method := paymentMethodAt(slot)
+	sSubmitterDid, sReqJson, sInputsJson, sOutputsJson, sExtra := C.GoString(submitterDid), C.GoString(reqJson), C.GoString(inputsJson), C.GoString(outputsJson), C.GoString(extra)
+	answerString(cb, commandHandle, func() (string, int, error) {
+		return method.AddRequestFees(int(walletHandle), sSubmitterDid, sReqJson, sInputsJson, sOutputsJson, sExtra)
+	})
+	return (C.indy_error_t)(0) // Success, the result is passed to cb
+}
+
+//export parseResponseWithFeesCustomCB
+func parseResponseWithFeesCustomCB(slot C.int, commandHandle C.indy_handle_t, respJson *C.char, cb unsafe.Pointer) C.indy_error_t {
+	method, sRespJson := paymentMethodAt(slot), C.GoString(respJson)
+	answerString(cb, commandHandle, func() (string, int, error) {
+		return method.ParseResponseWithFees(sRespJson)
+	})
+	return (C.indy_error_t)(0) // Success, the result is passed to cb
+}
+
+//export buildGetPaymentSourcesRequestCustomCB
+func buildGetPaymentSourcesRequestCustomCB(slot C.int, commandHandle C.indy_handle_t, walletHandle C.indy_handle_t, submitterDid *C.char, paymentAddress *C.char, from C.indy_i64_t, cb unsafe.Pointer) C.indy_error_t {
+	method, sSubmitterDid, sPaymentAddress := paymentMethodAt(slot), C.GoString(submitterDid), C.GoString(paymentAddress)
+	answerString(cb, commandHandle, func() (string, int, error) {
+		return method.BuildGetPaymentSourcesRequest(int(walletHandle), sSubmitterDid, sPaymentAddress, int64(from))
+	})
+	return (C.indy_error_t)(0) // Success, the result is passed to cb
+}
+
+//export parseGetPaymentSourcesResponseCustomCB
+func parseGetPaymentSourcesResponseCustomCB(slot C.int, commandHandle C.indy_handle_t, respJson *C.char, cb unsafe.Pointer) C.indy_error_t {
+	method, sRespJson := paymentMethodAt(slot), C.GoString(respJson)
+	answerSources(cb, commandHandle, func() (string, int64, int, error) {
+		return method.ParseGetPaymentSourcesResponse(sRespJson)
+	})
+	return (C.indy_error_t)(0) // Success, the result is passed to cb
+}
+
+//export buildPaymentReqCustomCB
+func buildPaymentReqCustomCB(slot C.int, commandHandle C.indy_handle_t, walletHandle C.indy_handle_t, submitterDid *C.char, inputsJson *C.char, outputsJson *C.char, extra *C.char, cb unsafe.Pointer) C.indy_error_t {
+	method := paymentMethodAt(slot)
+	sSubmitterDid, sInputsJson, sOutputsJson, sExtra := C.GoString(submitterDid), C.GoString(inputsJson), C.GoString(outputsJson), C.GoString(extra)
+	answerString(cb, commandHandle, func() (string, int, error) {
+		return method.BuildPaymentReq(int(walletHandle), sSubmitterDid, sInputsJson, sOutputsJson, sExtra)
+	})
+	return (C.indy_error_t)(0) // Success, the result is passed to cb
+}
+
+//export parsePaymentResponseCustomCB
+func parsePaymentResponseCustomCB(slot C.int, commandHandle C.indy_handle_t, respJson *C.char, cb unsafe.Pointer) C.indy_error_t {
+	method, sRespJson := paymentMethodAt(slot), C.GoString(respJson)
+	answerString(cb, commandHandle, func() (string, int, error) {
+		return method.ParsePaymentResponse(sRespJson)
+	})
+	return (C.indy_error_t)(0) // Success, the result is passed to cb
+}
+
+//export buildMintReqCustomCB
+func buildMintReqCustomCB(slot C.int, commandHandle C.indy_handle_t, walletHandle C.indy_handle_t, submitterDid *C.char, outputsJson *C.char, extra *C.char, cb unsafe.Pointer) C.indy_error_t {
+	method := paymentMethodAt(slot)
+	sSubmitterDid, sOutputsJson, sExtra := C.GoString(submitterDid), C.GoString(outputsJson), C.GoString(extra)
+	answerString(cb, commandHandle, func() (string, int, error) {
+		return method.BuildMintReq(int(walletHandle), sSubmitterDid, sOutputsJson, sExtra)
+	})
+	return (C.indy_error_t)(0) // Success, the result is passed to cb
+}
+
+//export buildSetTxnFeesReqCustomCB
+func buildSetTxnFeesReqCustomCB(slot C.int, commandHandle C.indy_handle_t, walletHandle C.indy_handle_t, submitterDid *C.char, feesJson *C.char, cb unsafe.Pointer) C.indy_error_t {
+	method, sSubmitterDid, sFeesJson := paymentMethodAt(slot), C.GoString(submitterDid), C.GoString(feesJson)
+	answerString(cb, commandHandle, func() (string, int, error) {
+		return method.BuildSetTxnFeesReq(int(walletHandle), sSubmitterDid, sFeesJson)
+	})
+	return (C.indy_error_t)(0) // Success, the result is passed to cb
+}
+
+//export buildGetTxnFeesReqCustomCB
+func buildGetTxnFeesReqCustomCB(slot C.int, commandHandle C.indy_handle_t, walletHandle C.indy_handle_t, submitterDid *C.char, cb unsafe.Pointer) C.indy_error_t {
+	method, sSubmitterDid := paymentMethodAt(slot), C.GoString(submitterDid)
+	answerString(cb, commandHandle, func() (string, int, error) {
+		return method.BuildGetTxnFeesReq(int(walletHandle), sSubmitterDid)
+	})
+	return (C.indy_error_t)(0) // Success, the result is passed to cb
+}
+
+//export parseGetTxnFeesResponseCustomCB
+func parseGetTxnFeesResponseCustomCB(slot C.int, commandHandle C.indy_handle_t, respJson *C.char, cb unsafe.Pointer) C.indy_error_t {
+	method, sRespJson := paymentMethodAt(slot), C.GoString(respJson)
+	answerString(cb, commandHandle, func() (string, int, error) {
+		return method.ParseGetTxnFeesResponse(sRespJson)
+	})
+	return (C.indy_error_t)(0) // Success, the result is passed to cb
+}
+
+//export buildVerifyPaymentReqCustomCB
+func buildVerifyPaymentReqCustomCB(slot C.int, commandHandle C.indy_handle_t, walletHandle C.indy_handle_t, submitterDid *C.char, receipt *C.char, cb unsafe.Pointer) C.indy_error_t {
+	method, sSubmitterDid, sReceipt := paymentMethodAt(slot), C.GoString(submitterDid), C.GoString(receipt)
+	answerString(cb, commandHandle, func() (string, int, error) {
+		return method.BuildVerifyPaymentReq(int(walletHandle), sSubmitterDid, sReceipt)
+	})
+	return (C.indy_error_t)(0) // Success, the result is passed to cb
+}
+
+//export parseVerifyPaymentResponseCustomCB
+func parseVerifyPaymentResponseCustomCB(slot C.int, commandHandle C.indy_handle_t, respJson *C.char, cb unsafe.Pointer) C.indy_error_t {
+	method, sRespJson := paymentMethodAt(slot), C.GoString(respJson)
+	answerString(cb, commandHandle, func() (string, int, error) {
+		return method.ParseVerifyPaymentResponse(sRespJson)
+	})
+	return (C.indy_error_t)(0) // Success, the result is passed to cb
+}
+
+//export signWithAddressCustomCB
+func signWithAddressCustomCB(slot C.int, commandHandle C.indy_handle_t, walletHandle C.indy_handle_t, address *C.char, message *C.indy_u8_t, messageLen C.indy_u32_t, cb unsafe.Pointer) C.indy_error_t {
+	method, sAddress := paymentMethodAt(slot), C.GoString(address)
+	buffMessage := C.GoBytes(unsafe.Pointer(message), C.int(messageLen))
+	answerSignature(cb, commandHandle, func() ([]byte, int, error) {
+		return method.SignWithAddress(int(walletHandle), sAddress, buffMessage)
+	})
+	return (C.indy_error_t)(0) // Success, the result is passed to cb
+}
+
+//export verifyWithAddressCustomCB
+func verifyWithAddressCustomCB(slot C.int, commandHandle C.indy_handle_t, address *C.char, message *C.indy_u8_t, messageLen C.indy_u32_t, signature *C.indy_u8_t, signatureLen C.indy_u32_t, cb unsafe.Pointer) C.indy_error_t {
+	method, sAddress := paymentMethodAt(slot), C.GoString(address)
+	buffMessage := C.GoBytes(unsafe.Pointer(message), C.int(messageLen))
+	buffSignature := C.GoBytes(unsafe.Pointer(signature), C.int(signatureLen))
+	answerVerify(cb, commandHandle, func() (bool, int, error) {
+		return method.VerifyWithAddress(sAddress, buffMessage, buffSignature)
+	})
+	return (C.indy_error_t)(0) // Success, the result is passed to cb
+}
+
+//export registerPaymentMethodCB
+func registerPaymentMethodCB(commandHandle C.indy_handle_t, indyError C.indy_error_t) {
+	if indyError == 0 {
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
+	} else {
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
+	}
+}
+
+// RegisterPaymentMethod registers a payment method implemented in go, libindy calls its handlers for
+// every payment operation on the method. Up to MaxPaymentMethods methods can be registered under different
+// names, registering the same name again replaces the implementation. If libindy refuses the registration
+// the previous implementation is restored.
+func RegisterPaymentMethod(paymentMethod unsafe.Pointer, method IPaymentMethod) chan indyUtils.IndyResult {
+	handle, future := indyUtils.NewFutureCommand()
+
+	name := C.GoString((*C.char)(paymentMethod))
+	paymentMethodMutex.Lock()
+	slot := paymentMethodSlotOf(name)
+	if slot < 0 {
+		paymentMethodMutex.Unlock()
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: ErrPaymentMethodSlots}) }()
+		return future
+	}
+	previous := paymentMethodSlots[slot]
+	version := previous.version + 1
+	paymentMethodSlots[slot] = paymentMethodSlot{name: name, method: method, version: version}
+	paymentMethodMutex.Unlock()
+
+	// the handlers of the slot are routed to method while libindy registers them, the answer is relayed
+	// to future once the slot is settled
+	registerHandle, registered := indyUtils.NewFutureCommand()
+	commandHandle := (C.indy_handle_t)(registerHandle)
+	go func() {
+		result := <-registered
+		if result.Error != nil {
+			paymentMethodMutex.Lock()
+			if paymentMethodSlots[slot].version == version {
+				previous.version = version
+				paymentMethodSlots[slot] = previous
+			}
+			paymentMethodMutex.Unlock()
+		}
+		indyUtils.RemoveFuture((int)(handle), result)
+	}()
+
+	/*
+		Register custom payment implementation.
+
+		It allows library user to provide custom payment method implementation as set of handlers.
+
+		:param payment_method: The type of payment method also used as sub-prefix for fully resolvable payment address format ("sov" - for example)
+		:param handlers: create_payment_address, add_request_fees, parse_response_with_fees, build_get_payment_sources_request,
+		                 parse_get_payment_sources_response, build_payment_req, parse_payment_response, build_mint_req,
+		                 build_set_txn_fees_req, build_get_txn_fees_req, parse_get_txn_fees_response, build_verify_payment_req,
+		                 parse_verify_payment_response, sign_with_address, verify_with_address
+		:return: Error code
+	*/
+
+	res := registerPaymentMethodSlot(slot, commandHandle, paymentMethod)
+	if res != 0 {
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() {
+			indyUtils.RemoveFuture((int)(registerHandle), indyUtils.IndyResult{Error: indyErr})
+		}()
+		return future
+	}
+	return future
+}
@@ -0,0 +1,134 @@
+/*
+// ******************************************************************
+// Purpose: Hands the results of a go payment method back to libindy
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package payments
+
+/*
+#cgo CFLAGS: -I ../include
+#include <indy_core.h>
+#include <stdlib.h>
+
+typedef indy_error_t (*cb_answerString)(indy_handle_t, indy_error_t, const char*);
+typedef indy_error_t (*cb_answerSources)(indy_handle_t, indy_error_t, const char*, indy_i64_t);
+typedef indy_error_t (*cb_answerSignature)(indy_handle_t, indy_error_t, const indy_u8_t*, indy_u32_t);
+typedef indy_error_t (*cb_answerVerify)(indy_handle_t, indy_error_t, indy_bool_t);
+
+// go can't call a C function pointer, the callbacks libindy passes to the handlers are called from here
+static indy_error_t answerString(void* cb, indy_handle_t commandHandle, indy_error_t err, const char* result) {
+	return ((cb_answerString)cb)(commandHandle, err, result);
+}
+
+static indy_error_t answerSources(void* cb, indy_handle_t commandHandle, indy_error_t err, const char* sources, indy_i64_t next) {
+	return ((cb_answerSources)cb)(commandHandle, err, sources, next);
+}
+
+static indy_error_t answerSignature(void* cb, indy_handle_t commandHandle, indy_error_t err, const indy_u8_t* signature, indy_u32_t signatureLen) {
+	return ((cb_answerSignature)cb)(commandHandle, err, signature, signatureLen);
+}
+
+static indy_error_t answerVerify(void* cb, indy_handle_t commandHandle, indy_error_t err, indy_bool_t result) {
+	return ((cb_answerVerify)cb)(commandHandle, err, result);
+}
+*/
+import "C"
+import (
+	"fmt"
+	"unsafe"
+)
+
+// handlerErrorCode error code reported to libindy for a handler result
+func handlerErrorCode(indyCode int, err error) C.indy_error_t {
+	if err == nil {
+		return 0 // Success
+	}
+	if indyCode == 0 {
+		return 112 // CommonInvalidState
+	}
+	return C.indy_error_t(indyCode)
+}
+
+// recoverHandler reports a panicking handler as CommonInvalidState, libindy would wait for the callback forever otherwise
+func recoverHandler(indyCode *int, err *error) {
+	if r := recover(); r != nil {
+		*indyCode = 112
+		*err = fmt.Errorf("payment method handler panicked: %v", r)
+	}
+}
+
+// answerString runs a handler on its own goroutine and passes its string result to the libindy callback
+func answerString(cb unsafe.Pointer, commandHandle C.indy_handle_t, handler func() (string, int, error)) {
+	go func() {
+		result, indyCode, err := func() (result string, indyCode int, err error) {
+			defer recoverHandler(&indyCode, &err)
+			return handler()
+		}()
+		if err != nil {
+			C.answerString(cb, commandHandle, handlerErrorCode(indyCode, err), nil)
+			return
+		}
+
+		cResult := C.CString(result)
+		defer C.free(unsafe.Pointer(cResult))
+		C.answerString(cb, commandHandle, 0, cResult)
+	}()
+}
+
+// answerSources like answerString for the sources json and the next shift of a get payment sources response
+func answerSources(cb unsafe.Pointer, commandHandle C.indy_handle_t, handler func() (string, int64, int, error)) {
+	go func() {
+		sources, next, indyCode, err := func() (sources string, next int64, indyCode int, err error) {
+			defer recoverHandler(&indyCode, &err)
+			return handler()
+		}()
+		if err != nil {
+			C.answerSources(cb, commandHandle, handlerErrorCode(indyCode, err), nil, 0)
+			return
+		}
+
+		cSources := C.CString(sources)
+		defer C.free(unsafe.Pointer(cSources))
+		C.answerSources(cb, commandHandle, 0, cSources, C.indy_i64_t(next))
+	}()
+}
+
+// answerSignature like answerString for a signature
+func answerSignature(cb unsafe.Pointer, commandHandle C.indy_handle_t, handler func() ([]byte, int, error)) {
+	go func() {
+		signature, indyCode, err := func() (signature []byte, indyCode int, err error) {
+			defer recoverHandler(&indyCode, &err)
+			return handler()
+		}()
+		if err != nil {
+			C.answerSignature(cb, commandHandle, handlerErrorCode(indyCode, err), nil, 0)
+			return
+		}
+
+		cSignature := C.CBytes(signature)
+		defer C.free(cSignature)
+		C.answerSignature(cb, commandHandle, 0, (*C.indy_u8_t)(cSignature), C.indy_u32_t(len(signature)))
+	}()
+}
+
+// answerVerify like answerString for a signature check
+func answerVerify(cb unsafe.Pointer, commandHandle C.indy_handle_t, handler func() (bool, int, error)) {
+	go func() {
+		valid, indyCode, err := func() (valid bool, indyCode int, err error) {
+			defer recoverHandler(&indyCode, &err)
+			return handler()
+		}()
+		if err != nil {
+			C.answerVerify(cb, commandHandle, handlerErrorCode(indyCode, err), false)
+			return
+		}
+
+		C.answerVerify(cb, commandHandle, 0, C.indy_bool_t(valid))
+	}()
+}
@@ -0,0 +1,134 @@
+/*
+// ******************************************************************
+// Purpose: C trampolines routing the libindy payment handlers to the
+// go payment method registered in each slot
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package payments
+
+/*
+#cgo CFLAGS: -I ../include
+#include <indy_core.h>
+
+typedef indy_error_t (*slot_cb_string)(indy_handle_t, indy_error_t, const char*);
+typedef indy_error_t (*slot_cb_sources)(indy_handle_t, indy_error_t, const char*, indy_i64_t);
+typedef indy_error_t (*slot_cb_signature)(indy_handle_t, indy_error_t, const indy_u8_t*, indy_u32_t);
+typedef indy_error_t (*slot_cb_verify)(indy_handle_t, indy_error_t, indy_bool_t);
+
+// exported in paymentMethod.go, the first argument is the slot of the payment method
+extern void registerPaymentMethodCB(indy_handle_t, indy_error_t);
+extern indy_error_t createPaymentAddressCustomCB(int, indy_handle_t, indy_handle_t, char*, void*);
+extern indy_error_t addRequestFeesCustomCB(int, indy_handle_t, indy_handle_t, char*, char*, char*, char*, char*, void*);
+extern indy_error_t parseResponseWithFeesCustomCB(int, indy_handle_t, char*, void*);
+extern indy_error_t buildGetPaymentSourcesRequestCustomCB(int, indy_handle_t, indy_handle_t, char*, char*, indy_i64_t, void*);
+extern indy_error_t parseGetPaymentSourcesResponseCustomCB(int, indy_handle_t, char*, void*);
+extern indy_error_t buildPaymentReqCustomCB(int, indy_handle_t, indy_handle_t, char*, char*, char*, char*, void*);
+extern indy_error_t parsePaymentResponseCustomCB(int, indy_handle_t, char*, void*);
+extern indy_error_t buildMintReqCustomCB(int, indy_handle_t, indy_handle_t, char*, char*, char*, void*);
+extern indy_error_t buildSetTxnFeesReqCustomCB(int, indy_handle_t, indy_handle_t, char*, char*, void*);
+extern indy_error_t buildGetTxnFeesReqCustomCB(int, indy_handle_t, indy_handle_t, char*, void*);
+extern indy_error_t parseGetTxnFeesResponseCustomCB(int, indy_handle_t, char*, void*);
+extern indy_error_t buildVerifyPaymentReqCustomCB(int, indy_handle_t, indy_handle_t, char*, char*, void*);
+extern indy_error_t parseVerifyPaymentResponseCustomCB(int, indy_handle_t, char*, void*);
+extern indy_error_t signWithAddressCustomCB(int, indy_handle_t, indy_handle_t, char*, indy_u8_t*, indy_u32_t, void*);
+extern indy_error_t verifyWithAddressCustomCB(int, indy_handle_t, char*, indy_u8_t*, indy_u32_t, indy_u8_t*, indy_u32_t, void*);
+
+// libindy handlers don't carry the payment method, each slot passes its number to the go handlers
+#define PAYMENT_METHOD_SLOT(n) \
+static indy_error_t createPaymentAddress##n(indy_handle_t h, indy_handle_t wh, const char* config, slot_cb_string cb) { \
+	return createPaymentAddressCustomCB(n, h, wh, (char*)config, (void*)cb); \
+} \
+static indy_error_t addRequestFees##n(indy_handle_t h, indy_handle_t wh, const char* did, const char* req, const char* inputs, const char* outputs, const char* extra, slot_cb_string cb) { \
+	return addRequestFeesCustomCB(n, h, wh, (char*)did, (char*)req, (char*)inputs, (char*)outputs, (char*)extra, (void*)cb); \
+} \
+static indy_error_t parseResponseWithFees##n(indy_handle_t h, const char* resp, slot_cb_string cb) { \
+	return parseResponseWithFeesCustomCB(n, h, (char*)resp, (void*)cb); \
+} \
+static indy_error_t buildGetPaymentSourcesRequest##n(indy_handle_t h, indy_handle_t wh, const char* did, const char* address, indy_i64_t from, slot_cb_string cb) { \
+	return buildGetPaymentSourcesRequestCustomCB(n, h, wh, (char*)did, (char*)address, from, (void*)cb); \
+} \
+static indy_error_t parseGetPaymentSourcesResponse##n(indy_handle_t h, const char* resp, slot_cb_sources cb) { \
+	return parseGetPaymentSourcesResponseCustomCB(n, h, (char*)resp, (void*)cb); \
+} \
+static indy_error_t buildPaymentReq##n(indy_handle_t h, indy_handle_t wh, const char* did, const char* inputs, const char* outputs, const char* extra, slot_cb_string cb) { \
+	return buildPaymentReqCustomCB(n, h, wh, (char*)did, (char*)inputs, (char*)outputs, (char*)extra, (void*)cb); \
+} \
+static indy_error_t parsePaymentResponse##n(indy_handle_t h, const char* resp, slot_cb_string cb) { \
+	return parsePaymentResponseCustomCB(n, h, (char*)resp, (void*)cb); \
+} \
+static indy_error_t buildMintReq##n(indy_handle_t h, indy_handle_t wh, const char* did, const char* outputs, const char* extra, slot_cb_string cb) { \
+	return buildMintReqCustomCB(n, h, wh, (char*)did, (char*)outputs, (char*)extra, (void*)cb); \
+} \
+static indy_error_t buildSetTxnFeesReq##n(indy_handle_t h, indy_handle_t wh, const char* did, const char* fees, slot_cb_string cb) { \
+	return buildSetTxnFeesReqCustomCB(n, h, wh, (char*)did, (char*)fees, (void*)cb); \
+} \
+static indy_error_t buildGetTxnFeesReq##n(indy_handle_t h, indy_handle_t wh, const char* did, slot_cb_string cb) { \
+	return buildGetTxnFeesReqCustomCB(n, h, wh, (char*)did, (void*)cb); \
+} \
+static indy_error_t parseGetTxnFeesResponse##n(indy_handle_t h, const char* resp, slot_cb_string cb) { \
+	return parseGetTxnFeesResponseCustomCB(n, h, (char*)resp, (void*)cb); \
+} \
+static indy_error_t buildVerifyPaymentReq##n(indy_handle_t h, indy_handle_t wh, const char* did, const char* receipt, slot_cb_string cb) { \
+	return buildVerifyPaymentReqCustomCB(n, h, wh, (char*)did, (char*)receipt, (void*)cb); \
+} \
+static indy_error_t parseVerifyPaymentResponse##n(indy_handle_t h, const char* resp, slot_cb_string cb) { \
+	return parseVerifyPaymentResponseCustomCB(n, h, (char*)resp, (void*)cb); \
+} \
+static indy_error_t signWithAddress##n(indy_handle_t h, indy_handle_t wh, const char* address, const indy_u8_t* message, indy_u32_t messageLen, slot_cb_signature cb) { \
+	return signWithAddressCustomCB(n, h, wh, (char*)address, (indy_u8_t*)message, messageLen, (void*)cb); \
+} \
+static indy_error_t verifyWithAddress##n(indy_handle_t h, const char* address, const indy_u8_t* message, indy_u32_t messageLen, const indy_u8_t* signature, indy_u32_t signatureLen, slot_cb_verify cb) { \
+	return verifyWithAddressCustomCB(n, h, (char*)address, (indy_u8_t*)message, messageLen, (indy_u8_t*)signature, signatureLen, (void*)cb); \
+}
+
+#define REGISTER_PAYMENT_METHOD_SLOT(n) \
+	case n: \
+		return indy_register_payment_method(commandHandle, paymentMethod, \
+			createPaymentAddress##n, addRequestFees##n, parseResponseWithFees##n, \
+			buildGetPaymentSourcesRequest##n, parseGetPaymentSourcesResponse##n, \
+			buildPaymentReq##n, parsePaymentResponse##n, buildMintReq##n, \
+			buildSetTxnFeesReq##n, buildGetTxnFeesReq##n, parseGetTxnFeesResponse##n, \
+			buildVerifyPaymentReq##n, parseVerifyPaymentResponse##n, \
+			signWithAddress##n, verifyWithAddress##n, registerPaymentMethodCB);
+
+// keep in sync with MaxPaymentMethods
+PAYMENT_METHOD_SLOT(0)
+PAYMENT_METHOD_SLOT(1)
+PAYMENT_METHOD_SLOT(2)
+PAYMENT_METHOD_SLOT(3)
+PAYMENT_METHOD_SLOT(4)
+PAYMENT_METHOD_SLOT(5)
+PAYMENT_METHOD_SLOT(6)
+PAYMENT_METHOD_SLOT(7)
+
+static indy_error_t registerPaymentMethodSlot(int slot, indy_handle_t commandHandle, const char* paymentMethod) {
+	switch (slot) {
+	REGISTER_PAYMENT_METHOD_SLOT(0)
+	REGISTER_PAYMENT_METHOD_SLOT(1)
+	REGISTER_PAYMENT_METHOD_SLOT(2)
+	REGISTER_PAYMENT_METHOD_SLOT(3)
+	REGISTER_PAYMENT_METHOD_SLOT(4)
+	REGISTER_PAYMENT_METHOD_SLOT(5)
+	REGISTER_PAYMENT_METHOD_SLOT(6)
+	REGISTER_PAYMENT_METHOD_SLOT(7)
+	}
+	return 100; // CommonInvalidParam1
+}
+*/
+import "C"
+import "unsafe"
+
+// MaxPaymentMethods number of go payment methods that can be registered under different names,
+// each one is routed through its own set of C trampolines
+const MaxPaymentMethods = 8
+
+// registerPaymentMethodSlot registers the handlers of a slot under the payment method name
+func registerPaymentMethodSlot(slot int, commandHandle C.indy_handle_t, paymentMethod unsafe.Pointer) C.indy_error_t {
+	return C.registerPaymentMethodSlot(C.int(slot), commandHandle, (*C.char)(paymentMethod))
+}
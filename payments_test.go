@@ -0,0 +1,142 @@
+/*
+// ******************************************************************
+// Purpose: go payment method unit testing
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package indySDK
+
+import (
+	"errors"
+	"github.com/joyride9999/IndySdkGoBindings/inMemUtils"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"github.com/joyride9999/IndySdkGoBindings/payments"
+	"strings"
+	"testing"
+)
+
+func TestInMemoryPaymentMethod(t *testing.T) {
+	mock := inMemUtils.NewInMemoryPaymentMethod("mock")
+	errRegister := RegisterPaymentMethod("mock", mock)
+	if errRegister != nil {
+		t.Errorf("RegisterPaymentMethod() error = '%v'", errRegister)
+		return
+	}
+	// a second method is routed by its own name
+	errRegister = RegisterPaymentMethod("other", inMemUtils.NewInMemoryPaymentMethod("other"))
+	if errRegister != nil {
+		t.Errorf("RegisterPaymentMethod() second method error = '%v'", errRegister)
+		return
+	}
+
+	walletHandle, errCreate := createWallet(testConfig(), testCredentials())
+	if errCreate != nil && !errors.Is(errCreate, indyUtils.ErrWalletAlreadyExists) {
+		t.Errorf("createWallet() error = '%v'", errCreate)
+		return
+	}
+	defer walletCleanup(walletHandle, testConfig(), testCredentials())
+
+	alice, errAddress := CreatePaymentAddress(walletHandle, "mock", payments.Config{Seed: "000000000000000000000000000Alice"})
+	if errAddress != nil {
+		t.Errorf("CreatePaymentAddress() error = '%v'", errAddress)
+		return
+	}
+	bob, errAddress := CreatePaymentAddress(walletHandle, "mock", payments.Config{})
+	if errAddress != nil {
+		t.Errorf("CreatePaymentAddress() error = '%v'", errAddress)
+		return
+	}
+	other, errAddress := CreatePaymentAddress(walletHandle, "other", payments.Config{})
+	if errAddress != nil || !strings.HasPrefix(other, "pay:other:") {
+		t.Errorf("CreatePaymentAddress() other method address = '%v', error = '%v'", other, errAddress)
+		return
+	}
+
+	// submit runs a request built by libindy through the mock ledger
+	submit := func(request string, method string, errBuild error) string {
+		if errBuild != nil || method != "mock" {
+			t.Fatalf("build request method = '%v', error = '%v'", method, errBuild)
+		}
+		response, errSubmit := mock.Submit(request)
+		if errSubmit != nil {
+			t.Fatalf("Submit() error = '%v'", errSubmit)
+		}
+		return response
+	}
+
+	mintResponse := submit(BuildMintReq(walletHandle, "", []payments.Output{{Recipient: alice, Amount: 10}}, ""))
+	receipts, errParse := ParsePaymentResponse("mock", mintResponse)
+	if errParse != nil || len(receipts) != 1 || receipts[0].Amount != 10 {
+		t.Errorf("ParsePaymentResponse() mint receipts = '%v', error = '%v'", receipts, errParse)
+		return
+	}
+
+	sourcesResponse := submit(BuildGetPaymentSourcesRequest(walletHandle, "", alice))
+	sources, errParse := ParseGetPaymentSourcesResponse("mock", sourcesResponse)
+	if errParse != nil || len(sources) != 1 || sources[0].Source != receipts[0].Receipt {
+		t.Errorf("ParseGetPaymentSourcesResponse() sources = '%v', error = '%v'", sources, errParse)
+		return
+	}
+
+	paymentResponse := submit(BuildPaymentReq(walletHandle, "", []string{sources[0].Source}, []payments.Output{{Recipient: bob, Amount: 7}, {Recipient: alice, Amount: 3}}, ""))
+	receipts, errParse = ParsePaymentResponse("mock", paymentResponse)
+	if errParse != nil || len(receipts) != 2 || mock.Balance(alice) != 3 || mock.Balance(bob) != 7 {
+		t.Errorf("ParsePaymentResponse() receipts = '%v', error = '%v'", receipts, errParse)
+		return
+	}
+
+	overdraft, method, errBuild := BuildPaymentReq(walletHandle, "", []string{receipts[0].Receipt}, []payments.Output{{Recipient: alice, Amount: 8}}, "")
+	if errBuild != nil || method != "mock" {
+		t.Errorf("BuildPaymentReq() method = '%v', error = '%v'", method, errBuild)
+		return
+	}
+	_, errSubmit := mock.Submit(overdraft)
+	if !errors.Is(errSubmit, indyUtils.ErrPaymentInsufficientFunds) {
+		t.Errorf("Submit() overdraft error = '%v'", errSubmit)
+		return
+	}
+
+	_, _, errBuild = BuildPaymentReq(walletHandle, "", []string{"txo:mock:999"}, []payments.Output{{Recipient: alice, Amount: 1}}, "")
+	if !errors.Is(errBuild, indyUtils.ErrPaymentSourceNotFound) {
+		t.Errorf("BuildPaymentReq() unknown source error = '%v'", errBuild)
+		return
+	}
+
+	verifyRequest, method, errBuild := BuildVerifyPaymentReq(walletHandle, "", receipts[0].Receipt)
+	verifyResponse := submit(verifyRequest, method, errBuild)
+	txn, errParse := ParseVerifyPaymentResponse("mock", verifyResponse)
+	if errParse != nil || len(txn.Sources) != 1 || len(txn.Receipts) != 2 {
+		t.Errorf("ParseVerifyPaymentResponse() txn = '%v', error = '%v'", txn, errParse)
+		return
+	}
+
+	setFees, errBuild := BuildSetTxnFeesReq(walletHandle, "", "mock", payments.Fees{"1": 2})
+	submit(setFees, "mock", errBuild)
+	getFees, errBuild := BuildGetTxnFeesReq(walletHandle, "", "mock")
+	fees, errParse := ParseGetTxnFeesResponse("mock", submit(getFees, "mock", errBuild))
+	if errParse != nil || fees["1"] != 2 {
+		t.Errorf("ParseGetTxnFeesResponse() fees = '%v', error = '%v'", fees, errParse)
+		return
+	}
+
+	signature, errSign := SignWithAddress(walletHandle, alice, []byte("message"))
+	if errSign != nil {
+		t.Errorf("SignWithAddress() error = '%v'", errSign)
+		return
+	}
+	valid, errVerify := VerifyWithAddress(alice, []byte("message"), signature)
+	if errVerify != nil || !valid {
+		t.Errorf("VerifyWithAddress() valid = '%v', error = '%v'", valid, errVerify)
+		return
+	}
+	valid, errVerify = VerifyWithAddress(bob, []byte("message"), signature)
+	if errVerify != nil || valid {
+		t.Errorf("VerifyWithAddress() other address valid = '%v', error = '%v'", valid, errVerify)
+		return
+	}
+}
@@ -12,12 +12,21 @@
 
 package indySDK
 
-import "github.com/joyride9999/IndySdkGoBindings/payments_v2"
+import (
+	"context"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"github.com/joyride9999/IndySdkGoBindings/payments_v2"
+)
 
 // BuildGetPaymentSourcesWithFromRequest purge credential definition cache
 func BuildGetPaymentSourcesWithFromRequest(wh int, submitterDID string, paymentAddress string, from int64) (string, string, error) {
+	return BuildGetPaymentSourcesWithFromRequestCtx(context.Background(), wh, submitterDID, paymentAddress, from)
+}
+
+// BuildGetPaymentSourcesWithFromRequestCtx is like BuildGetPaymentSourcesWithFromRequest but returns ctx.Err() if ctx is done before libindy answers.
+func BuildGetPaymentSourcesWithFromRequestCtx(ctx context.Context, wh int, submitterDID string, paymentAddress string, from int64) (string, string, error) {
 	channel := payments_v2.BuildGetPaymentSourcesWithFromRequest(wh, submitterDID, paymentAddress, from)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return "", "", result.Error
 	}
@@ -26,10 +35,16 @@ func BuildGetPaymentSourcesWithFromRequest(wh int, submitterDID string, paymentA
 
 // ParseGetPaymentSourcesWithFromResponse parses response for Indy request for getting sources list
 func ParseGetPaymentSourcesWithFromResponse(paymentMethod string, respJs string) (int, string, error) {
+	return ParseGetPaymentSourcesWithFromResponseCtx(context.Background(), paymentMethod, respJs)
+}
+
+// ParseGetPaymentSourcesWithFromResponseCtx is like ParseGetPaymentSourcesWithFromResponse but returns ctx.Err() if ctx is done before libindy answers.
+func ParseGetPaymentSourcesWithFromResponseCtx(ctx context.Context, paymentMethod string, respJs string) (int, string, error) {
 	channel := payments_v2.ParseGetPaymentSourcesWithFromResponse(paymentMethod, respJs)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	if result.Error != nil {
 		return 0, "", result.Error
 	}
-	return result.Results[0].(int), result.Results[1].(string), result.Error
+	// the callback resolves with the sources json first and the next pointer second
+	return int(result.Results[1].(int64)), result.Results[0].(string), result.Error
 }
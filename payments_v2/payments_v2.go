@@ -25,7 +25,6 @@ extern void parseGetPaymentSourcesWithFromResponseCB(indy_handle_t, indy_error_t
 import "C"
 
 import (
-	"errors"
 	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
 	"unsafe"
 )
@@ -35,8 +34,8 @@ func buildGetPaymentSourcesWithFromRequestCB(commandHandle C.indy_handle_t, indy
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{string(C.GoString(getSourcesTxnJs)), string(C.GoString(paymentMethod))}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -66,8 +65,8 @@ func BuildGetPaymentSourcesWithFromRequest(wh int, submitterDID string, paymentA
 		C.ulonglong(from),
 		(C.cb_buildGetPaymentSourcesWithFromRequest)(unsafe.Pointer(C.buildGetPaymentSourcesWithFromRequestCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -79,8 +78,8 @@ func parseGetPaymentSourcesWithFromResponseCB(commandHandle C.indy_handle_t, ind
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{string(C.GoString(sourcesJs)), int64(next)}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -113,8 +112,8 @@ func ParseGetPaymentSourcesWithFromResponse(paymentMethod string, respJs string)
 		C.CString(respJs),
 		(C.cb_parseGetPaymentSourcesWithFromResponse)(unsafe.Pointer(C.parseGetPaymentSourcesWithFromResponseCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -17,19 +17,31 @@ package indySDK
 */
 import "C"
 import (
-	"github.com/joyride9999/IndySdkGoBindings/pool"
+	"context"
 	"encoding/json"
 	"errors"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"github.com/joyride9999/IndySdkGoBindings/pool"
 	"unsafe"
 )
 
 func SetPoolProtocolVersion(pb uint64) error {
+	return SetPoolProtocolVersionCtx(context.Background(), pb)
+}
+
+// SetPoolProtocolVersionCtx is like SetPoolProtocolVersion but returns ctx.Err() if ctx is done before libindy answers.
+func SetPoolProtocolVersionCtx(ctx context.Context, pb uint64) error {
 	channel := pool.IndySetProtocolVersion(pb)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 func CreatePoolLedgerConfig(config pool.Pool) error {
+	return CreatePoolLedgerConfigCtx(context.Background(), config)
+}
+
+// CreatePoolLedgerConfigCtx is like CreatePoolLedgerConfig but returns ctx.Err() if ctx is done before libindy answers.
+func CreatePoolLedgerConfigCtx(ctx context.Context, config pool.Pool) error {
 
 	poolName := config.Name
 	upPoolName := unsafe.Pointer(C.CString(poolName))
@@ -43,25 +55,32 @@ func CreatePoolLedgerConfig(config pool.Pool) error {
 	defer C.free(upPoolCfg)
 
 	channel := pool.IndyCreatePoolLedgerConfig(upPoolName, upPoolCfg)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 func OpenPoolLedgerConfig(config pool.Pool) (int, error) {
+	return OpenPoolLedgerConfigCtx(context.Background(), config)
+}
+
+// OpenPoolLedgerConfigCtx is like OpenPoolLedgerConfig but returns ctx.Err() if ctx is done before libindy answers.
+func OpenPoolLedgerConfigCtx(ctx context.Context, config pool.Pool) (int, error) {
+	return OpenPoolLedgerConfigWithOptionsCtx(ctx, config, pool.DefaultOpenConfig())
+}
+
+// OpenPoolLedgerConfigWithOptions opens a pool using the given runtime pool configuration
+func OpenPoolLedgerConfigWithOptions(config pool.Pool, openConfig pool.OpenConfig) (int, error) {
+	return OpenPoolLedgerConfigWithOptionsCtx(context.Background(), config, openConfig)
+}
+
+// OpenPoolLedgerConfigWithOptionsCtx is like OpenPoolLedgerConfigWithOptions but returns ctx.Err() if ctx is done before libindy answers.
+func OpenPoolLedgerConfigWithOptionsCtx(ctx context.Context, config pool.Pool, openConfig pool.OpenConfig) (int, error) {
 
 	poolName := config.Name
 	upPoolName := unsafe.Pointer(C.CString(poolName))
 	defer C.free(upPoolName)
 
-	type cfg struct {
-		Timeout int `json:"timeout"`
-	}
-
-	t := cfg{
-		Timeout: 10,
-	}
-
-	jsonConfig, err := json.Marshal(t)
+	jsonConfig, err := json.Marshal(openConfig)
 	if err != nil {
 		return 0, errors.New("cant read json")
 	}
@@ -69,14 +88,73 @@ func OpenPoolLedgerConfig(config pool.Pool) (int, error) {
 	defer C.free(upPoolCfg)
 
 	channel := pool.IndyOpenPoolLedger(upPoolName, upPoolCfg)
-	result := <-channel
+	result := indyUtils.WaitForHandle(ctx, channel, func(ph int) { ClosePoolHandle(ph) })
 	if result.Error != nil {
 		return 0, result.Error
 	}
 	return result.Results[0].(int), result.Error
 }
+
+// ClosePoolHandle closes an opened pool
 func ClosePoolHandle(ph int) error {
+	return ClosePoolHandleCtx(context.Background(), ph)
+}
+
+// ClosePoolHandleCtx is like ClosePoolHandle but returns ctx.Err() if ctx is done before libindy answers.
+func ClosePoolHandleCtx(ctx context.Context, ph int) error {
 	channel := pool.IndyClosePoolHandle(ph)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error == nil {
+		requestScheduler.Remove(ph)
+		forgetTaaPool(ph)
+	}
+	return result.Error
+}
+
+// RefreshPoolLedger refreshes the local copy of the pool ledger and updates pool nodes connections
+func RefreshPoolLedger(ph int) error {
+	return RefreshPoolLedgerCtx(context.Background(), ph)
+}
+
+// RefreshPoolLedgerCtx is like RefreshPoolLedger but returns ctx.Err() if ctx is done before libindy answers.
+func RefreshPoolLedgerCtx(ctx context.Context, ph int) error {
+	channel := pool.IndyRefreshPoolLedger(ph)
+	result := indyUtils.WaitForResult(ctx, channel)
+	return result.Error
+}
+
+// ListPools lists the created pool ledger configurations
+func ListPools() ([]pool.Entry, error) {
+	return ListPoolsCtx(context.Background())
+}
+
+// ListPoolsCtx is like ListPools but returns ctx.Err() if ctx is done before libindy answers.
+func ListPoolsCtx(ctx context.Context) ([]pool.Entry, error) {
+	channel := pool.IndyListPools()
+	result := indyUtils.WaitForResult(ctx, channel)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var pools []pool.Entry
+	err := json.Unmarshal([]byte(result.Results[0].(string)), &pools)
+	if err != nil {
+		return nil, errors.New("cant read json")
+	}
+	return pools, nil
+}
+
+// DeletePoolLedgerConfig deletes a created pool ledger configuration
+func DeletePoolLedgerConfig(poolName string) error {
+	return DeletePoolLedgerConfigCtx(context.Background(), poolName)
+}
+
+// DeletePoolLedgerConfigCtx is like DeletePoolLedgerConfig but returns ctx.Err() if ctx is done before libindy answers.
+func DeletePoolLedgerConfigCtx(ctx context.Context, poolName string) error {
+	upPoolName := unsafe.Pointer(C.CString(poolName))
+	defer C.free(upPoolName)
+
+	channel := pool.IndyDeletePoolLedgerConfig(upPoolName)
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
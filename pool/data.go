@@ -15,3 +15,26 @@ type Pool struct {
 	Name       string `json:"name"`
 	GenesisTxn string `json:"genesis_txn"`
 }
+
+// OpenConfig represents the runtime pool configuration passed to indy_open_pool_ledger.
+// Zero values are omitted so libindy applies its own defaults.
+type OpenConfig struct {
+	Timeout           int      `json:"timeout,omitempty"`             // timeout for network request (in sec)
+	ExtendedTimeout   int      `json:"extended_timeout,omitempty"`    // extended timeout for network request (in sec)
+	PreorderedNodes   []string `json:"preordered_nodes,omitempty"`    // names of nodes which will have a priority during request sending
+	NumberReadNodes   int      `json:"number_read_nodes,omitempty"`   // number of nodes to send read requests (2 by default)
+	ConnLimit         int      `json:"conn_limit,omitempty"`          // max number of requests one connection can handle
+	ConnActiveTimeout int      `json:"conn_active_timeout,omitempty"` // time (in sec) a connection stays active after the last request
+}
+
+// DefaultOpenConfig returns the configuration used by OpenPoolLedgerConfig
+func DefaultOpenConfig() OpenConfig {
+	return OpenConfig{
+		Timeout: 10,
+	}
+}
+
+// Entry represents an item of the list returned by indy_list_pools
+type Entry struct {
+	Pool string `json:"pool"`
+}
@@ -28,12 +28,20 @@ extern void openPoolLedgerCB(indy_handle_t, indy_error_t, indy_handle_t);
 typedef void (*cb_closePoolLedger)(indy_handle_t, indy_error_t);
 extern void closePoolLedgerCB(indy_handle_t, indy_error_t);
 
+typedef void (*cb_refreshPoolLedger)(indy_handle_t, indy_error_t);
+extern void refreshPoolLedgerCB(indy_handle_t, indy_error_t);
+
+typedef void (*cb_listPools)(indy_handle_t, indy_error_t, char*);
+extern void listPoolsCB(indy_handle_t, indy_error_t, char*);
+
+typedef void (*cb_deletePoolLedgerConfig)(indy_handle_t, indy_error_t);
+extern void deletePoolLedgerConfigCB(indy_handle_t, indy_error_t);
+
 */
 import "C"
 
 import (
 	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
-	"errors"
 	"unsafe"
 )
 
@@ -42,8 +50,8 @@ func closePoolLedgerCB(commandHandle C.indy_handle_t, indyError C.indy_error_t)
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -57,8 +65,8 @@ func IndyClosePoolHandle(ph int) chan indyUtils.IndyResult {
 		(C.indy_handle_t)(ph),
 		(C.cb_closePoolLedger)(unsafe.Pointer(C.closePoolLedgerCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -70,8 +78,8 @@ func setProtocolVersionCB(commandHandle C.indy_handle_t, indyError C.indy_error_
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -87,8 +95,8 @@ func IndySetProtocolVersion(pv uint64) chan indyUtils.IndyResult {
 		protocolVersion,
 		(C.cb_setProtocolVersion)(unsafe.Pointer(C.setProtocolVersionCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -100,8 +108,8 @@ func createPoolLedgerConfigCB(commandHandle C.indy_handle_t, indyError C.indy_er
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -119,8 +127,8 @@ func IndyCreatePoolLedgerConfig(poolName, PoolCfg unsafe.Pointer) chan indyUtils
 		(C.cb_setProtocolVersion)(unsafe.Pointer(C.createPoolLedgerConfigCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -132,8 +140,8 @@ func openPoolLedgerCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, r
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{int(retHandle)}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -176,8 +184,112 @@ func IndyOpenPoolLedger(poolName, poolCfg unsafe.Pointer) chan indyUtils.IndyRes
 		(C.cb_setProtocolVersion)(unsafe.Pointer(C.openPoolLedgerCB)))
 
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
+		return future
+	}
+
+	return future
+}
+
+//export refreshPoolLedgerCB
+func refreshPoolLedgerCB(commandHandle C.indy_handle_t, indyError C.indy_error_t) {
+	if indyError == 0 {
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
+	} else {
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
+	}
+}
+
+// IndyRefreshPoolLedger refreshes a local copy of a pool ledger and updates pool nodes connections
+func IndyRefreshPoolLedger(ph int) chan indyUtils.IndyResult {
+
+	handle, future := indyUtils.NewFutureCommand()
+	commandHandle := (C.indy_handle_t)(handle)
+
+	/*
+	   Refreshes a local copy of a pool ledger and updates pool nodes connections.
+
+	   :param handle: pool handle returned by indy_open_pool_ledger
+	   :return: Error code
+	*/
+	// Call indy_refresh_pool_ledger
+	res := C.indy_refresh_pool_ledger(commandHandle,
+		(C.indy_handle_t)(ph),
+		(C.cb_refreshPoolLedger)(unsafe.Pointer(C.refreshPoolLedgerCB)))
+	if res != 0 {
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
+		return future
+	}
+
+	return future
+}
+
+//export listPoolsCB
+func listPoolsCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, pools *C.char) {
+	if indyError == 0 {
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{string(C.GoString(pools))}})
+	} else {
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
+	}
+}
+
+// IndyListPools lists names of created pool ledgers
+func IndyListPools() chan indyUtils.IndyResult {
+
+	handle, future := indyUtils.NewFutureCommand()
+	commandHandle := (C.indy_handle_t)(handle)
+
+	/*
+	   Lists names of created pool ledgers
+
+	   :return: Error code
+	            pools: json array of pool ledgers: [{"pool": string}]
+	*/
+	// Call indy_list_pools
+	res := C.indy_list_pools(commandHandle,
+		(C.cb_listPools)(unsafe.Pointer(C.listPoolsCB)))
+	if res != 0 {
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
+		return future
+	}
+
+	return future
+}
+
+//export deletePoolLedgerConfigCB
+func deletePoolLedgerConfigCB(commandHandle C.indy_handle_t, indyError C.indy_error_t) {
+	if indyError == 0 {
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
+	} else {
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
+	}
+}
+
+// IndyDeletePoolLedgerConfig deletes created pool ledger configuration
+func IndyDeletePoolLedgerConfig(poolName unsafe.Pointer) chan indyUtils.IndyResult {
+
+	handle, future := indyUtils.NewFutureCommand()
+	commandHandle := (C.indy_handle_t)(handle)
+
+	/*
+	   Deletes created pool ledger configuration.
+
+	   :param config_name: Name of the pool ledger configuration to delete.
+	   :return: Error code
+	*/
+	// Call indy_delete_pool_ledger_config
+	res := C.indy_delete_pool_ledger_config(commandHandle,
+		(*C.char)(poolName),
+		(C.cb_deletePoolLedgerConfig)(unsafe.Pointer(C.deletePoolLedgerConfigCB)))
+	if res != 0 {
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -13,6 +13,7 @@
 package indySDK
 
 import (
+	"errors"
 	"github.com/joyride9999/IndySdkGoBindings/pool"
 	"fmt"
 	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
@@ -67,4 +68,97 @@ func TestOpenPoolLedgerConfig(t *testing.T) {
 
 	ClosePoolHandle(hPool)
 	return
-}
\ No newline at end of file
+}
+func TestRefreshPoolLedger(t *testing.T) {
+	var poolLedger pool.Pool
+	poolLedger.Name = "Siemens4"
+	poolLedger.GenesisTxn = "pool.txn"
+
+	errSP := SetPoolProtocolVersion(2)
+	if errSP != nil {
+		t.Errorf("SetPoolProtocolVersion() error ")
+	}
+
+	errC := CreatePoolLedgerConfig(poolLedger)
+	if errC != nil && !errors.Is(errC, indyUtils.ErrPoolConfigAlreadyExists) {
+		t.Errorf("CreatePoolLedgerConfig() error ")
+	}
+
+	hPool, errOp := OpenPoolLedgerConfig(poolLedger)
+	if errOp != nil {
+		t.Errorf("OpenPoolLedgerConfig() error ")
+		return
+	}
+	defer ClosePoolHandle(hPool)
+
+	errRefresh := RefreshPoolLedger(hPool)
+	if errRefresh != nil {
+		t.Errorf("RefreshPoolLedger() error = '%v'", errRefresh)
+	}
+	return
+}
+
+func TestListAndDeletePoolLedgerConfig(t *testing.T) {
+	var poolLedger pool.Pool
+	poolLedger.Name = "SiemensToDelete"
+	poolLedger.GenesisTxn = "pool.txn"
+
+	errC := CreatePoolLedgerConfig(poolLedger)
+	if errC != nil && !errors.Is(errC, indyUtils.ErrPoolConfigAlreadyExists) {
+		t.Errorf("CreatePoolLedgerConfig() error ")
+		return
+	}
+
+	listed := func() bool {
+		pools, errList := ListPools()
+		if errList != nil {
+			t.Errorf("ListPools() error = '%v'", errList)
+			return false
+		}
+		for _, p := range pools {
+			if p.Pool == poolLedger.Name {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !listed() {
+		t.Errorf("ListPools() pool '%s' not found", poolLedger.Name)
+	}
+
+	errDelete := DeletePoolLedgerConfig(poolLedger.Name)
+	if errDelete != nil {
+		t.Errorf("DeletePoolLedgerConfig() error = '%v'", errDelete)
+	}
+
+	if listed() {
+		t.Errorf("ListPools() pool '%s' still listed after delete", poolLedger.Name)
+	}
+	return
+}
+
+func TestOpenPoolLedgerConfigWithOptions(t *testing.T) {
+	var poolLedger pool.Pool
+	poolLedger.Name = "Siemens4"
+	poolLedger.GenesisTxn = "pool.txn"
+
+	errC := CreatePoolLedgerConfig(poolLedger)
+	if errC != nil && !errors.Is(errC, indyUtils.ErrPoolConfigAlreadyExists) {
+		t.Errorf("CreatePoolLedgerConfig() error ")
+	}
+
+	openConfig := pool.DefaultOpenConfig()
+	openConfig.ExtendedTimeout = 60
+	openConfig.NumberReadNodes = 4
+	openConfig.PreorderedNodes = []string{"Node1", "Node2"}
+
+	hPool, errOp := OpenPoolLedgerConfigWithOptions(poolLedger, openConfig)
+	if errOp != nil {
+		t.Errorf("OpenPoolLedgerConfigWithOptions() error = '%v'", errOp)
+		return
+	}
+
+	ClosePoolHandle(hPool)
+	return
+}
@@ -0,0 +1,397 @@
+/*
+// ******************************************************************
+// Purpose: issuer side revocation bookkeeping, tracks the issued revocable
+// credentials in the wallet and publishes revocations in batches
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package indySDK
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/joyride9999/IndySdkGoBindings/anoncreds"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"github.com/joyride9999/IndySdkGoBindings/ledger/types"
+	"github.com/joyride9999/IndySdkGoBindings/nonsecrets"
+	"sync"
+	"time"
+)
+
+const (
+	// IssuedCredentialRecordType non secret record type of the tracked credentials, the value is an anoncreds.CredentialInfo
+	// and the record id is <rev reg id>:<cred rev id>
+	IssuedCredentialRecordType = "issued_credential"
+	// PendingRevocDeltaRecordType non secret record type of the registry deltas not published yet, the record id is the rev reg id
+	PendingRevocDeltaRecordType = "pending_revoc_delta"
+)
+
+// status tag values of the tracked credentials
+const (
+	CredentialStatusIssued   = "issued"
+	CredentialStatusRevoking = "revoking" // set before the wallet registry is updated, until the delta is queued
+	CredentialStatusRevoked  = "revoked"
+)
+
+// ErrCredentialNotTracked is returned for a credential the revocation manager has no record of
+var ErrCredentialNotTracked = errors.New("credential is not tracked by the revocation manager")
+
+// ErrCredentialAlreadyRevoked is returned when revoking a revoked credential
+var ErrCredentialAlreadyRevoked = errors.New("credential already revoked")
+
+// RevocationManager keeps the issuer bookkeeping of revocable credentials: status records in the wallet and the
+// registry deltas waiting to be published. Revocations are merged per registry and written with one
+// REVOC_REG_ENTRY per Publish, so revoking many credentials costs one ledger write.
+type RevocationManager struct {
+	PoolHandle       int
+	WalletHandle     int
+	IssuerDid        string // submits the registry entries
+	BlobReaderHandle int    // reader of the tails files of the issuer registries
+
+	mutex sync.Mutex // serializes the updates of the pending deltas
+}
+
+// NewRevocationManager creates the revocation manager of an issuer
+func NewRevocationManager(ph int, wh int, issuerDid string, blobReaderHandle int) *RevocationManager {
+	return &RevocationManager{PoolHandle: ph, WalletHandle: wh, IssuerDid: issuerDid, BlobReaderHandle: blobReaderHandle}
+}
+
+func issuedCredentialRecordId(revRegId string, credRevId string) string {
+	return revRegId + ":" + credRevId
+}
+
+func issuedCredentialTags(info anoncreds.CredentialInfo, status string, published bool) (string, error) {
+	tags, err := json.Marshal(map[string]string{
+		"rev_reg_id":  info.RevocRegId,
+		"cred_rev_id": info.CredRevocId,
+		"cred_def_id": info.CredentialDefinitionId,
+		"subject_did": info.SubjectDid,
+		"status":      status,
+		"published":   fmt.Sprint(published),
+	})
+	return string(tags), err
+}
+
+// RecordIssued starts tracking a credential returned by IssuerCreateCredential, revRegDeltaJson is the delta
+// returned with it. On demand registries return a delta that is published with the next batch.
+func (m *RevocationManager) RecordIssued(info anoncreds.CredentialInfo, revRegDeltaJson string) error {
+	return m.RecordIssuedCtx(context.Background(), info, revRegDeltaJson)
+}
+
+// RecordIssuedCtx is like RecordIssued but returns ctx.Err() if ctx is done before libindy answers.
+func (m *RevocationManager) RecordIssuedCtx(ctx context.Context, info anoncreds.CredentialInfo, revRegDeltaJson string) error {
+	if info.RevocRegId == "" || info.CredRevocId == "" {
+		return errors.New("credential has no revocation registry")
+	}
+	if info.IssuerDid == "" {
+		info.IssuerDid = m.IssuerDid
+	}
+	if info.CreationDate.IsZero() {
+		info.CreationDate = time.Now().UTC()
+	}
+	info.Valid, info.RevocationDate = true, nil
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	value, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	tags, err := issuedCredentialTags(info, CredentialStatusIssued, revRegDeltaJson == "")
+	if err != nil {
+		return err
+	}
+	err = IndyAddWalletRecordCtx(ctx, m.WalletHandle, IssuedCredentialRecordType, issuedCredentialRecordId(info.RevocRegId, info.CredRevocId), string(value), tags)
+	if err != nil {
+		return err
+	}
+	if revRegDeltaJson == "" {
+		return nil
+	}
+	return m.queueDeltaCtx(ctx, info.RevocRegId, revRegDeltaJson)
+}
+
+// Credential returns the tracked credential, fails with ErrCredentialNotTracked if there is none
+func (m *RevocationManager) Credential(revRegId string, credRevId string) (*anoncreds.CredentialInfo, error) {
+	return m.CredentialCtx(context.Background(), revRegId, credRevId)
+}
+
+// CredentialCtx is like Credential but returns ctx.Err() if ctx is done before libindy answers.
+func (m *RevocationManager) CredentialCtx(ctx context.Context, revRegId string, credRevId string) (*anoncreds.CredentialInfo, error) {
+	record, err := m.issuedCredentialRecordCtx(ctx, revRegId, credRevId)
+	if err != nil {
+		return nil, err
+	}
+	return credentialInfoOf(record)
+}
+
+func (m *RevocationManager) issuedCredentialRecordCtx(ctx context.Context, revRegId string, credRevId string) (*nonsecrets.Record, error) {
+	record, err := getWalletRecordCtx(ctx, m.WalletHandle, IssuedCredentialRecordType, issuedCredentialRecordId(revRegId, credRevId))
+	if errors.Is(err, indyUtils.ErrWalletItemNotFound) {
+		return nil, fmt.Errorf("%w: %s in %s", ErrCredentialNotTracked, credRevId, revRegId)
+	}
+	return record, err
+}
+
+// Credentials returns the tracked credentials of a registry with the given status, empty arguments match all
+func (m *RevocationManager) Credentials(revRegId string, status string) ([]anoncreds.CredentialInfo, error) {
+	return m.CredentialsCtx(context.Background(), revRegId, status)
+}
+
+// CredentialsCtx is like Credentials but returns ctx.Err() if ctx is done before libindy answers.
+func (m *RevocationManager) CredentialsCtx(ctx context.Context, revRegId string, status string) ([]anoncreds.CredentialInfo, error) {
+	query := make(map[string]string)
+	if revRegId != "" {
+		query["rev_reg_id"] = revRegId
+	}
+	if status != "" {
+		query["status"] = status
+	}
+	queryJson, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := IndySearchWalletRecordsCtx(ctx, m.WalletHandle, IssuedCredentialRecordType, string(queryJson))
+	if err != nil {
+		return nil, err
+	}
+	credentials := make([]anoncreds.CredentialInfo, 0, len(records))
+	for i := range records {
+		info, err := credentialInfoOf(&records[i])
+		if err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, *info)
+	}
+	return credentials, nil
+}
+
+func credentialInfoOf(record *nonsecrets.Record) (*anoncreds.CredentialInfo, error) {
+	var info anoncreds.CredentialInfo
+	err := json.Unmarshal([]byte(record.Value), &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// Revoke revokes a tracked credential in the wallet registry and queues its delta, the ledger is updated by Publish.
+// The credential is marked revoking before the wallet registry changes, calling Revoke again after a failure
+// completes the bookkeeping instead of revoking twice.
+func (m *RevocationManager) Revoke(revRegId string, credRevId string) error {
+	return m.RevokeCtx(context.Background(), revRegId, credRevId)
+}
+
+// RevokeCtx is like Revoke but returns ctx.Err() if ctx is done before libindy answers.
+func (m *RevocationManager) RevokeCtx(ctx context.Context, revRegId string, credRevId string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	record, err := m.issuedCredentialRecordCtx(ctx, revRegId, credRevId)
+	if err != nil {
+		return err
+	}
+	info, err := credentialInfoOf(record)
+	if err != nil {
+		return err
+	}
+
+	queued := false
+	revoking := record.Tags["status"] == CredentialStatusRevoking
+	if revoking {
+		// a previous Revoke failed part way, its delta may already be queued
+		pending, err := m.PendingDeltaCtx(ctx, revRegId)
+		if err != nil {
+			return err
+		}
+		queued = !info.Valid || deltaRevokes(pending, credRevId)
+	} else {
+		if !info.Valid {
+			return fmt.Errorf("%w: %s in %s", ErrCredentialAlreadyRevoked, credRevId, revRegId)
+		}
+		tags, err := issuedCredentialTags(*info, CredentialStatusRevoking, false)
+		if err != nil {
+			return err
+		}
+		err = IndyUpdateWalletRecordTagsCtx(ctx, m.WalletHandle, IssuedCredentialRecordType, record.Id, tags)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !queued {
+		revRegDeltaJson, err := IssuerRevokeCredentialCtx(ctx, m.WalletHandle, m.BlobReaderHandle, revRegId, credRevId)
+		if revoking && errors.Is(err, indyUtils.ErrInvalidUserRevocId) {
+			// the wallet registry was updated but the delta was lost before it was queued
+			return fmt.Errorf("%w: %s is revoked in the wallet registry of %s but its delta was not queued", err, credRevId, revRegId)
+		}
+		if err != nil {
+			return err
+		}
+		err = m.queueDeltaCtx(ctx, revRegId, revRegDeltaJson)
+		if err != nil {
+			return err
+		}
+	}
+	return m.markRevokedCtx(ctx, record.Id, info, false)
+}
+
+// markRevokedCtx saves a credential whose revocation is queued or published as revoked
+func (m *RevocationManager) markRevokedCtx(ctx context.Context, recordId string, info *anoncreds.CredentialInfo, published bool) error {
+	if info.Valid {
+		revoked := time.Now().UTC()
+		info.Valid, info.RevocationDate = false, &revoked
+		value, err := json.Marshal(info)
+		if err != nil {
+			return err
+		}
+		err = IndyUpdateWalletRecordValueCtx(ctx, m.WalletHandle, IssuedCredentialRecordType, recordId, string(value))
+		if err != nil {
+			return err
+		}
+	}
+	tags, err := issuedCredentialTags(*info, CredentialStatusRevoked, published)
+	if err != nil {
+		return err
+	}
+	return IndyUpdateWalletRecordTagsCtx(ctx, m.WalletHandle, IssuedCredentialRecordType, recordId, tags)
+}
+
+// deltaRevokes tells whether a registry delta revokes the credential
+func deltaRevokes(revRegDeltaJson string, credRevId string) bool {
+	var delta types.RevocRegDelta
+	if revRegDeltaJson == "" || json.Unmarshal([]byte(revRegDeltaJson), &delta) != nil {
+		return false
+	}
+	for _, revoked := range delta.Value.Revoked {
+		if fmt.Sprint(revoked) == credRevId {
+			return true
+		}
+	}
+	return false
+}
+
+// queueDeltaCtx merges the delta into the pending delta of the registry, call with the mutex held
+func (m *RevocationManager) queueDeltaCtx(ctx context.Context, revRegId string, revRegDeltaJson string) error {
+	pending, err := getWalletRecordCtx(ctx, m.WalletHandle, PendingRevocDeltaRecordType, revRegId)
+	if errors.Is(err, indyUtils.ErrWalletItemNotFound) {
+		return IndyAddWalletRecordCtx(ctx, m.WalletHandle, PendingRevocDeltaRecordType, revRegId, revRegDeltaJson, "")
+	}
+	if err != nil {
+		return err
+	}
+
+	merged, err := IssuerMergeRevocationRegistryDeltasCtx(ctx, pending.Value, revRegDeltaJson)
+	if err != nil {
+		return err
+	}
+	return IndyUpdateWalletRecordValueCtx(ctx, m.WalletHandle, PendingRevocDeltaRecordType, revRegId, merged)
+}
+
+// PendingDelta returns the merged delta of a registry waiting to be published, empty if there is none
+func (m *RevocationManager) PendingDelta(revRegId string) (string, error) {
+	return m.PendingDeltaCtx(context.Background(), revRegId)
+}
+
+// PendingDeltaCtx is like PendingDelta but returns ctx.Err() if ctx is done before libindy answers.
+func (m *RevocationManager) PendingDeltaCtx(ctx context.Context, revRegId string) (string, error) {
+	pending, err := getWalletRecordCtx(ctx, m.WalletHandle, PendingRevocDeltaRecordType, revRegId)
+	if errors.Is(err, indyUtils.ErrWalletItemNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return pending.Value, nil
+}
+
+// Publish writes the pending delta of a registry with one REVOC_REG_ENTRY and marks its credentials published.
+// Returns a nil reply when nothing is pending.
+func (m *RevocationManager) Publish(revRegId string) (*types.Reply, error) {
+	return m.PublishCtx(context.Background(), revRegId)
+}
+
+// PublishCtx is like Publish but returns ctx.Err() if ctx is done before libindy answers.
+func (m *RevocationManager) PublishCtx(ctx context.Context, revRegId string) (*types.Reply, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	revRegDeltaJson, err := m.PendingDeltaCtx(ctx, revRegId)
+	if err != nil || revRegDeltaJson == "" {
+		return nil, err
+	}
+
+	request, err := BuildRevocRegEntryRequestCtx(ctx, m.IssuerDid, revRegId, "CL_ACCUM", revRegDeltaJson)
+	if err != nil {
+		return nil, err
+	}
+	response, err := SignAndSubmitRequestCtx(ctx, m.PoolHandle, m.WalletHandle, m.IssuerDid, request)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := parseTypedReply(response)
+	if err != nil {
+		return reply, err
+	}
+
+	err = IndyDeleteWalletRecordCtx(ctx, m.WalletHandle, PendingRevocDeltaRecordType, revRegId)
+	if err != nil {
+		return reply, err
+	}
+	unpublished, err := IndySearchWalletRecordsCtx(ctx, m.WalletHandle, IssuedCredentialRecordType, fmt.Sprintf(`{"rev_reg_id": %q, "published": "false"}`, revRegId))
+	if err != nil {
+		return reply, err
+	}
+	for i := range unpublished {
+		info, err := credentialInfoOf(&unpublished[i])
+		if err != nil {
+			return reply, err
+		}
+		status := unpublished[i].Tags["status"]
+		if status == CredentialStatusRevoking {
+			// the revocation of a failed Revoke went out with this delta, else it is left to the next Revoke
+			if deltaRevokes(revRegDeltaJson, info.CredRevocId) {
+				err = m.markRevokedCtx(ctx, unpublished[i].Id, info, true)
+				if err != nil {
+					return reply, err
+				}
+			}
+			continue
+		}
+		tags, err := issuedCredentialTags(*info, status, true)
+		if err != nil {
+			return reply, err
+		}
+		err = IndyUpdateWalletRecordTagsCtx(ctx, m.WalletHandle, IssuedCredentialRecordType, unpublished[i].Id, tags)
+		if err != nil {
+			return reply, err
+		}
+	}
+	return reply, nil
+}
+
+// RevokeBatch revokes the credentials of a registry and publishes them with one ledger write.
+// Credentials revoked before a failure stay queued for the next Publish.
+func (m *RevocationManager) RevokeBatch(revRegId string, credRevIds []string) (*types.Reply, error) {
+	return m.RevokeBatchCtx(context.Background(), revRegId, credRevIds)
+}
+
+// RevokeBatchCtx is like RevokeBatch but returns ctx.Err() if ctx is done before libindy answers.
+func (m *RevocationManager) RevokeBatchCtx(ctx context.Context, revRegId string, credRevIds []string) (*types.Reply, error) {
+	for _, credRevId := range credRevIds {
+		err := m.RevokeCtx(ctx, revRegId, credRevId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m.PublishCtx(ctx, revRegId)
+}
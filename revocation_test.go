@@ -0,0 +1,171 @@
+/*
+// ******************************************************************
+// Purpose: issuer revocation bookkeeping unit testing
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package indySDK
+
+import (
+	"context"
+	"errors"
+	"github.com/Jeffail/gabs/v2"
+	"github.com/joyride9999/IndySdkGoBindings/anoncreds"
+	"github.com/joyride9999/IndySdkGoBindings/blobstorage"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"testing"
+)
+
+func TestRevocationManager(t *testing.T) {
+	whIssuer, errCreate := createWallet(issuerConfig(), issuerCredentials())
+	if errCreate != nil && !errors.Is(errCreate, indyUtils.ErrWalletAlreadyExists) {
+		t.Errorf("CreateWallet() error = '%v'", errCreate)
+		return
+	}
+	defer walletCleanup(whIssuer, issuerConfig(), issuerCredentials())
+	didIssuer, _, _ := CreateAndStoreDID(whIssuer, "")
+
+	whHolder, errCreate := createWallet(holderConfig(), holderCredentials())
+	if errCreate != nil && !errors.Is(errCreate, indyUtils.ErrWalletAlreadyExists) {
+		t.Errorf("CreateWallet() error = '%v'", errCreate)
+		return
+	}
+	defer walletCleanup(whHolder, holderConfig(), holderCredentials())
+	didHolder, _, _ := CreateAndStoreDID(whHolder, "")
+	masterSecret, _ := ProverCreateMasterSecret(whHolder, "")
+
+	tailsDir := t.TempDir()
+	info, revRegDefJson, _, errCredDef := createRevocableCredDef(whIssuer, didIssuer, tailsDir, 5)
+	if errCredDef != nil {
+		t.Errorf("createRevocableCredDef() error = '%v'", errCredDef)
+		return
+	}
+	blobReaderHandle, errReader := IndyOpenBlobStorageReader("default", jsonObjectToString(blobstorage.ConfigBlobStorage{BaseDir: tailsDir}))
+	if errReader != nil {
+		t.Errorf("IndyOpenBlobStorageReader() error = '%v'", errReader)
+		return
+	}
+
+	manager := NewRevocationManager(0, whIssuer, didIssuer, blobReaderHandle)
+	var credRevIds []string
+	for i := 0; i < 3; i++ {
+		credentialId, credRevId, issueDelta, errIssue := issueRevocableCredential(whIssuer, info, revRegDefJson, blobReaderHandle, whHolder, didHolder, masterSecret)
+		if errIssue != nil {
+			t.Errorf("issueRevocableCredential() error = '%v'", errIssue)
+			return
+		}
+		errRecord := manager.RecordIssued(anoncreds.CredentialInfo{
+			SubjectDid:             didHolder,
+			CredentialDefinitionId: info.CredentialDefinitionId,
+			CredentialId:           credentialId,
+			RevocRegId:             info.RevocationRegistryId,
+			CredRevocId:            credRevId,
+		}, issueDelta)
+		if errRecord != nil {
+			t.Errorf("RecordIssued() error = '%v'", errRecord)
+			return
+		}
+		credRevIds = append(credRevIds, credRevId)
+	}
+
+	// the issuance deltas of the on demand registry wait in one merged delta
+	pending, errPending := manager.PendingDelta(info.RevocationRegistryId)
+	delta, _ := gabs.ParseJSON([]byte(pending))
+	if errPending != nil || len(delta.Path("value.issued").Children()) != 3 {
+		t.Errorf("PendingDelta() delta = '%v', error = '%v'", pending, errPending)
+		return
+	}
+
+	for _, credRevId := range credRevIds[:2] {
+		errRevoke := manager.Revoke(info.RevocationRegistryId, credRevId)
+		if errRevoke != nil {
+			t.Errorf("Revoke() error = '%v'", errRevoke)
+			return
+		}
+	}
+	errRevoke := manager.Revoke(info.RevocationRegistryId, credRevIds[0])
+	if !errors.Is(errRevoke, ErrCredentialAlreadyRevoked) {
+		t.Errorf("Revoke() revoked credential error = '%v'", errRevoke)
+		return
+	}
+	errRevoke = manager.Revoke(info.RevocationRegistryId, "99")
+	if !errors.Is(errRevoke, ErrCredentialNotTracked) {
+		t.Errorf("Revoke() unknown credential error = '%v'", errRevoke)
+		return
+	}
+
+	pending, _ = manager.PendingDelta(info.RevocationRegistryId)
+	delta, _ = gabs.ParseJSON([]byte(pending))
+	if len(delta.Path("value.revoked").Children()) != 2 {
+		t.Errorf("PendingDelta() revocations not merged = '%v'", pending)
+		return
+	}
+
+	revoked, errList := manager.Credentials(info.RevocationRegistryId, CredentialStatusRevoked)
+	if errList != nil || len(revoked) != 2 || revoked[0].Valid || revoked[0].RevocationDate == nil {
+		t.Errorf("Credentials() revoked = '%v', error = '%v'", revoked, errList)
+		return
+	}
+	issued, errList := manager.Credentials(info.RevocationRegistryId, CredentialStatusIssued)
+	if errList != nil || len(issued) != 1 || issued[0].CredRevocId != credRevIds[2] {
+		t.Errorf("Credentials() issued = '%v', error = '%v'", issued, errList)
+		return
+	}
+
+	// Revoke failed after the wallet registry was updated and the delta queued, the retry completes the record
+	retryId := credRevIds[2]
+	markRevoking := func(credRevId string) error {
+		retryInfo, _ := manager.Credential(info.RevocationRegistryId, credRevId)
+		tags, _ := issuedCredentialTags(*retryInfo, CredentialStatusRevoking, false)
+		return IndyUpdateWalletRecordTags(whIssuer, IssuedCredentialRecordType, issuedCredentialRecordId(info.RevocationRegistryId, credRevId), tags)
+	}
+	errMark := markRevoking(retryId)
+	revokeDelta, errLibindy := IssuerRevokeCredential(whIssuer, blobReaderHandle, info.RevocationRegistryId, retryId)
+	if errMark != nil || errLibindy != nil {
+		t.Errorf("failed Revoke() setup error = '%v', '%v'", errMark, errLibindy)
+		return
+	}
+	manager.queueDeltaCtx(context.Background(), info.RevocationRegistryId, revokeDelta)
+	errRevoke = manager.Revoke(info.RevocationRegistryId, retryId)
+	retried, _ := manager.Credential(info.RevocationRegistryId, retryId)
+	if errRevoke != nil || retried.Valid {
+		t.Errorf("Revoke() retry after queued delta error = '%v', credential = '%v'", errRevoke, retried)
+		return
+	}
+
+	// Revoke failed before the wallet registry was updated, the retry revokes it
+	credentialId, lateId, issueDelta, errIssue := issueRevocableCredential(whIssuer, info, revRegDefJson, blobReaderHandle, whHolder, didHolder, masterSecret)
+	if errIssue != nil {
+		t.Errorf("issueRevocableCredential() error = '%v'", errIssue)
+		return
+	}
+	manager.RecordIssued(anoncreds.CredentialInfo{CredentialId: credentialId, RevocRegId: info.RevocationRegistryId, CredRevocId: lateId}, issueDelta)
+	markRevoking(lateId)
+	errRevoke = manager.Revoke(info.RevocationRegistryId, lateId)
+	if errRevoke != nil {
+		t.Errorf("Revoke() retry before the registry update error = '%v'", errRevoke)
+		return
+	}
+	pending, _ = manager.PendingDelta(info.RevocationRegistryId)
+	if !deltaRevokes(pending, retryId) || !deltaRevokes(pending, lateId) {
+		t.Errorf("PendingDelta() retried revocations not queued = '%v'", pending)
+		return
+	}
+	revoked, _ = manager.Credentials(info.RevocationRegistryId, CredentialStatusRevoked)
+	revoking, _ := manager.Credentials(info.RevocationRegistryId, CredentialStatusRevoking)
+	if len(revoked) != 4 || len(revoking) != 0 {
+		t.Errorf("Credentials() revoked = '%v', revoking = '%v'", revoked, revoking)
+		return
+	}
+
+	reply, errPublish := manager.Publish("unknown-registry")
+	if errPublish != nil || reply != nil {
+		t.Errorf("Publish() nothing pending reply = '%v', error = '%v'", reply, errPublish)
+		return
+	}
+}
@@ -0,0 +1,383 @@
+/*
+// ******************************************************************
+// Purpose: issuer revocation registry lifecycle, creates, publishes and
+// rolls over the registries of the issuer credential definitions
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package indySDK
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/joyride9999/IndySdkGoBindings/anoncreds"
+	"github.com/joyride9999/IndySdkGoBindings/blobstorage"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"github.com/joyride9999/IndySdkGoBindings/ledger/types"
+	"sync"
+	"time"
+)
+
+// RevocRegistryRecordType non secret record type of the managed registries, the value is a RevocRegistry
+// and the record id is the rev reg id
+const RevocRegistryRecordType = "revoc_registry"
+
+// state tag values of the managed registries
+const (
+	RevocRegistryStateReady  = "ready"  // created ahead, becomes active when the active registry is full
+	RevocRegistryStateActive = "active" // receives the new credentials of its cred def
+	RevocRegistryStateFull   = "full"
+)
+
+// RevocRegistry wallet record of a registry managed by the RevocRegistryManager
+type RevocRegistry struct {
+	RevRegId      string `json:"rev_reg_id"`
+	CredDefId     string `json:"cred_def_id"`
+	Tag           string `json:"tag"`
+	RevRegDefJson string `json:"rev_reg_def"`
+	// initial REVOC_REG_ENTRY of the registry, kept until it is published
+	RevRegEntryJson string `json:"rev_reg_entry,omitempty"`
+	MaxCredNum      int    `json:"max_cred_num"`
+	Issued          int    `json:"issued"`
+	State           string `json:"state"`
+	DefPublished    bool   `json:"def_published,omitempty"` // REVOC_REG_DEF written, the initial entry may still be missing
+	Published       bool   `json:"published"`
+}
+
+// RevocRegistryManager keeps one active revocation registry per credential definition. The next registry is created
+// and published ahead when the active one crosses RolloverThreshold, so IssueCredential switches registries without
+// the issuer ever seeing AnoncredsRevocationRegistryFullError.
+type RevocRegistryManager struct {
+	PoolHandle        int // ledger the registries are published to, they stay unpublished when PoolHandle is 0
+	WalletHandle      int
+	IssuerDid         string
+	TailsDir          string  // directory of the tails files
+	BlobReaderHandle  int     // reader opened on TailsDir
+	MaxCredNum        int     // capacity of the created registries
+	IssuanceType      string  // ISSUANCE_BY_DEFAULT or ISSUANCE_ON_DEMAND
+	RolloverThreshold float64 // fraction of MaxCredNum after which the next registry is created
+
+	mutex sync.Mutex // serializes the registry state changes
+
+	// ledger calls, SignAndSubmitRequestCtx and SubmitRequestCtx unless replaced by the unit tests
+	signAndSubmit func(ctx context.Context, ph int, wh int, did string, request string) (string, error)
+	submit        func(ctx context.Context, ph int, request string) (string, error)
+}
+
+// NewRevocRegistryManager creates the registry manager of an issuer, ISSUANCE_BY_DEFAULT registries are created
+// and the next one is prepared when the active registry is 80% used
+func NewRevocRegistryManager(ph int, wh int, issuerDid string, tailsDir string, blobReaderHandle int, maxCredNum int) *RevocRegistryManager {
+	return &RevocRegistryManager{
+		PoolHandle:        ph,
+		WalletHandle:      wh,
+		IssuerDid:         issuerDid,
+		TailsDir:          tailsDir,
+		BlobReaderHandle:  blobReaderHandle,
+		MaxCredNum:        maxCredNum,
+		IssuanceType:      "ISSUANCE_BY_DEFAULT",
+		RolloverThreshold: 0.8,
+	}
+}
+
+// Registries returns the managed registries of a cred def with the given state, an empty state matches all
+func (m *RevocRegistryManager) Registries(credDefId string, state string) ([]RevocRegistry, error) {
+	return m.RegistriesCtx(context.Background(), credDefId, state)
+}
+
+// RegistriesCtx is like Registries but returns ctx.Err() if ctx is done before libindy answers.
+func (m *RevocRegistryManager) RegistriesCtx(ctx context.Context, credDefId string, state string) ([]RevocRegistry, error) {
+	query := map[string]string{"cred_def_id": credDefId}
+	if state != "" {
+		query["state"] = state
+	}
+	queryJson, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := IndySearchWalletRecordsCtx(ctx, m.WalletHandle, RevocRegistryRecordType, string(queryJson))
+	if err != nil {
+		return nil, err
+	}
+	registries := make([]RevocRegistry, len(records))
+	for i := range records {
+		err = json.Unmarshal([]byte(records[i].Value), &registries[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return registries, nil
+}
+
+// Active returns the active registry of a cred def, a ready registry is promoted or a new one is created if there is none
+func (m *RevocRegistryManager) Active(credDefId string) (*RevocRegistry, error) {
+	return m.ActiveCtx(context.Background(), credDefId)
+}
+
+// ActiveCtx is like Active but returns ctx.Err() if ctx is done before libindy answers.
+func (m *RevocRegistryManager) ActiveCtx(ctx context.Context, credDefId string) (*RevocRegistry, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.activeCtx(ctx, credDefId)
+}
+
+// IssueCredential creates a credential in the active registry of the offered cred def, switching to the next registry
+// when the active one is full. Returns the registry used, the delta of an on demand registry is meant for
+// RevocationManager.RecordIssued. A failure preparing the next registry is returned along with the issued credential.
+func (m *RevocRegistryManager) IssueCredential(credOfferJson, credRequestJson, credValuesJson string) (credentialJson string, revRegId string, credRevId string, revRegDeltaJson string, err error) {
+	return m.IssueCredentialCtx(context.Background(), credOfferJson, credRequestJson, credValuesJson)
+}
+
+// IssueCredentialCtx is like IssueCredential but returns ctx.Err() if ctx is done before libindy answers.
+func (m *RevocRegistryManager) IssueCredentialCtx(ctx context.Context, credOfferJson, credRequestJson, credValuesJson string) (credentialJson string, revRegId string, credRevId string, revRegDeltaJson string, err error) {
+	var offer struct {
+		CredDefId string `json:"cred_def_id"`
+	}
+	err = json.Unmarshal([]byte(credOfferJson), &offer)
+	if err != nil {
+		return "", "", "", "", err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	active, err := m.activeCtx(ctx, offer.CredDefId)
+	if err != nil {
+		return "", "", "", "", err
+	}
+	credentialJson, credRevId, revRegDeltaJson, err = IssuerCreateCredentialCtx(ctx, m.WalletHandle, credOfferJson, credRequestJson, credValuesJson, active.RevRegId, m.BlobReaderHandle)
+	if errors.Is(err, indyUtils.ErrRevocationRegistryFull) {
+		// the count is behind libindy (credentials issued around the manager), retire the registry and retry once
+		active.State = RevocRegistryStateFull
+		err = m.saveCtx(ctx, active)
+		if err != nil {
+			return "", "", "", "", err
+		}
+		active, err = m.activeCtx(ctx, offer.CredDefId)
+		if err != nil {
+			return "", "", "", "", err
+		}
+		credentialJson, credRevId, revRegDeltaJson, err = IssuerCreateCredentialCtx(ctx, m.WalletHandle, credOfferJson, credRequestJson, credValuesJson, active.RevRegId, m.BlobReaderHandle)
+	}
+	if err != nil {
+		return "", "", "", "", err
+	}
+
+	active.Issued++
+	if active.Issued >= active.MaxCredNum {
+		active.State = RevocRegistryStateFull
+	}
+	err = m.saveCtx(ctx, active)
+	if err != nil {
+		return credentialJson, active.RevRegId, credRevId, revRegDeltaJson, err
+	}
+	err = m.prepareNextCtx(ctx, active)
+	return credentialJson, active.RevRegId, credRevId, revRegDeltaJson, err
+}
+
+// activeCtx returns the active registry, call with the mutex held
+func (m *RevocRegistryManager) activeCtx(ctx context.Context, credDefId string) (*RevocRegistry, error) {
+	active, err := m.RegistriesCtx(ctx, credDefId, RevocRegistryStateActive)
+	if err != nil {
+		return nil, err
+	}
+	if len(active) > 0 {
+		return &active[0], m.publishCtx(ctx, &active[0])
+	}
+
+	ready, err := m.RegistriesCtx(ctx, credDefId, RevocRegistryStateReady)
+	if err != nil {
+		return nil, err
+	}
+	if len(ready) > 0 {
+		next := &ready[0]
+		next.State = RevocRegistryStateActive
+		err = m.saveCtx(ctx, next)
+		if err != nil {
+			return nil, err
+		}
+		return next, m.publishCtx(ctx, next)
+	}
+
+	return m.createCtx(ctx, credDefId, RevocRegistryStateActive)
+}
+
+// prepareNextCtx creates the next registry once the active one crossed the threshold, call with the mutex held
+func (m *RevocRegistryManager) prepareNextCtx(ctx context.Context, active *RevocRegistry) error {
+	if float64(active.Issued) < m.RolloverThreshold*float64(active.MaxCredNum) {
+		return nil
+	}
+	ready, err := m.RegistriesCtx(ctx, active.CredDefId, RevocRegistryStateReady)
+	if err != nil || len(ready) > 0 {
+		return err
+	}
+	_, err = m.createCtx(ctx, active.CredDefId, RevocRegistryStateReady)
+	return err
+}
+
+// createCtx creates a registry in the wallet, writes its tails file and publishes it, call with the mutex held
+func (m *RevocRegistryManager) createCtx(ctx context.Context, credDefId string, state string) (*RevocRegistry, error) {
+	registries, err := m.RegistriesCtx(ctx, credDefId, "")
+	if err != nil {
+		return nil, err
+	}
+	tag := fmt.Sprint(len(registries) + 1)
+
+	configJson, err := json.Marshal(blobstorage.ConfigBlobStorage{BaseDir: m.TailsDir})
+	if err != nil {
+		return nil, err
+	}
+	blobWriterHandle, err := IndyOpenBlobStorageWriterCtx(ctx, "default", string(configJson))
+	if err != nil {
+		return nil, err
+	}
+	revRegConfig, err := json.Marshal(anoncreds.RevocRegConfig{MaxCredNumber: m.MaxCredNum, IssuanceType: m.IssuanceType})
+	if err != nil {
+		return nil, err
+	}
+	revRegId, revRegDefJson, revRegEntryJson, err := IssuerCreateAndStoreRevocRegCtx(ctx, m.WalletHandle, m.IssuerDid, "CL_ACCUM", tag, credDefId, string(revRegConfig), blobWriterHandle)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := &RevocRegistry{
+		RevRegId:        revRegId,
+		CredDefId:       credDefId,
+		Tag:             tag,
+		RevRegDefJson:   revRegDefJson,
+		RevRegEntryJson: revRegEntryJson,
+		MaxCredNum:      m.MaxCredNum,
+		State:           state,
+	}
+	value, err := json.Marshal(registry)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := revocRegistryTags(registry)
+	if err != nil {
+		return nil, err
+	}
+	err = IndyAddWalletRecordCtx(ctx, m.WalletHandle, RevocRegistryRecordType, revRegId, string(value), tags)
+	if err != nil {
+		return nil, err
+	}
+
+	// a registry failing to publish is published again when it becomes active
+	return registry, m.publishCtx(ctx, registry)
+}
+
+// publishCtx writes the REVOC_REG_DEF and the initial REVOC_REG_ENTRY of a registry not published yet, call with the mutex held.
+// Each step is saved once written, a retry resumes after the last one and does not write the entry again if the
+// ledger has it already (e.g. the reply of the previous attempt was lost).
+func (m *RevocRegistryManager) publishCtx(ctx context.Context, registry *RevocRegistry) error {
+	if registry.Published || m.PoolHandle == 0 {
+		return nil
+	}
+
+	if !registry.DefPublished {
+		request, err := BuildRevocRegDefRequestCtx(ctx, m.IssuerDid, registry.RevRegDefJson)
+		if err != nil {
+			return err
+		}
+		err = m.signAndSubmitCtx(ctx, request)
+		if err != nil {
+			return err
+		}
+		registry.DefPublished = true
+		err = m.saveCtx(ctx, registry)
+		if err != nil {
+			return err
+		}
+	}
+
+	written, err := m.entryPublishedCtx(ctx, registry)
+	if err != nil {
+		return err
+	}
+	if !written {
+		request, err := BuildRevocRegEntryRequestCtx(ctx, m.IssuerDid, registry.RevRegId, "CL_ACCUM", registry.RevRegEntryJson)
+		if err != nil {
+			return err
+		}
+		err = m.signAndSubmitCtx(ctx, request)
+		if err != nil {
+			return err
+		}
+	}
+
+	registry.Published, registry.RevRegEntryJson = true, ""
+	return m.saveCtx(ctx, registry)
+}
+
+// entryPublishedCtx tells whether the ledger has an entry of the registry, read with a GET_REVOC_REG_DELTA
+func (m *RevocRegistryManager) entryPublishedCtx(ctx context.Context, registry *RevocRegistry) (bool, error) {
+	request, err := BuildGetRevocRegDeltaRequestCtx(ctx, m.IssuerDid, registry.RevRegId, -1, time.Now().Unix())
+	if err != nil {
+		return false, err
+	}
+	submit := m.submit
+	if submit == nil {
+		submit = SubmitRequestCtx
+	}
+	response, err := submit(ctx, m.PoolHandle, request)
+	if err != nil {
+		return false, err
+	}
+	reply, err := types.ParseReply(response)
+	if err != nil {
+		return false, err
+	}
+	var delta json.RawMessage
+	err = reply.UnmarshalData(&delta)
+	if errors.Is(err, types.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// signAndSubmitCtx writes a request signed by the issuer, fails with the rejection of the ledger
+func (m *RevocRegistryManager) signAndSubmitCtx(ctx context.Context, request string) error {
+	signAndSubmit := m.signAndSubmit
+	if signAndSubmit == nil {
+		signAndSubmit = SignAndSubmitRequestCtx
+	}
+	response, err := signAndSubmit(ctx, m.PoolHandle, m.WalletHandle, m.IssuerDid, request)
+	if err != nil {
+		return err
+	}
+	_, err = parseTypedReply(response)
+	return err
+}
+
+// saveCtx updates the wallet record of a registry
+func (m *RevocRegistryManager) saveCtx(ctx context.Context, registry *RevocRegistry) error {
+	value, err := json.Marshal(registry)
+	if err != nil {
+		return err
+	}
+	tags, err := revocRegistryTags(registry)
+	if err != nil {
+		return err
+	}
+	err = IndyUpdateWalletRecordValueCtx(ctx, m.WalletHandle, RevocRegistryRecordType, registry.RevRegId, string(value))
+	if err != nil {
+		return err
+	}
+	return IndyUpdateWalletRecordTagsCtx(ctx, m.WalletHandle, RevocRegistryRecordType, registry.RevRegId, tags)
+}
+
+func revocRegistryTags(registry *RevocRegistry) (string, error) {
+	tags, err := json.Marshal(map[string]string{
+		"cred_def_id": registry.CredDefId,
+		"state":       registry.State,
+		"published":   fmt.Sprint(registry.Published),
+	})
+	return string(tags), err
+}
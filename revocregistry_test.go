@@ -0,0 +1,188 @@
+/*
+// ******************************************************************
+// Purpose: revocation registry lifecycle unit testing
+// Author:  adrian.toader@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package indySDK
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/joyride9999/IndySdkGoBindings/blobstorage"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"github.com/joyride9999/IndySdkGoBindings/ledger/types"
+	"testing"
+)
+
+func TestRevocRegistryManager(t *testing.T) {
+	whIssuer, errCreate := createWallet(issuerConfig(), issuerCredentials())
+	if errCreate != nil && !errors.Is(errCreate, indyUtils.ErrWalletAlreadyExists) {
+		t.Errorf("CreateWallet() error = '%v'", errCreate)
+		return
+	}
+	defer walletCleanup(whIssuer, issuerConfig(), issuerCredentials())
+	didIssuer, _, _ := CreateAndStoreDID(whIssuer, "")
+
+	whHolder, errCreate := createWallet(holderConfig(), holderCredentials())
+	if errCreate != nil && !errors.Is(errCreate, indyUtils.ErrWalletAlreadyExists) {
+		t.Errorf("CreateWallet() error = '%v'", errCreate)
+		return
+	}
+	defer walletCleanup(whHolder, holderConfig(), holderCredentials())
+	didHolder, _, _ := CreateAndStoreDID(whHolder, "")
+	masterSecret, _ := ProverCreateMasterSecret(whHolder, "")
+
+	tailsDir := t.TempDir()
+	info, _, _, errCredDef := createRevocableCredDef(whIssuer, didIssuer, tailsDir, 5)
+	if errCredDef != nil {
+		t.Errorf("createRevocableCredDef() error = '%v'", errCredDef)
+		return
+	}
+	blobReaderHandle, errReader := IndyOpenBlobStorageReader("default", jsonObjectToString(blobstorage.ConfigBlobStorage{BaseDir: tailsDir}))
+	if errReader != nil {
+		t.Errorf("IndyOpenBlobStorageReader() error = '%v'", errReader)
+		return
+	}
+
+	// no pool, the registries are created but not published
+	manager := NewRevocRegistryManager(0, whIssuer, didIssuer, tailsDir, blobReaderHandle, 2)
+	manager.RolloverThreshold = 0.5
+
+	var revRegIds []string
+	for i := 0; i < 3; i++ {
+		credOffer, _ := IssuerCreateCredentialOffer(whIssuer, info.CredentialDefinitionId)
+		credentialRequest, _, errRequest := ProverCreateCredentialRequest(whHolder, didHolder, credOffer, info.CredentialDefinitionJson, masterSecret)
+		if errRequest != nil {
+			t.Errorf("ProverCreateCredentialRequest() error = '%v'", errRequest)
+			return
+		}
+		_, revRegId, credRevId, _, errIssue := manager.IssueCredential(credOffer, credentialRequest, credValuesJson)
+		if errIssue != nil || credRevId == "" {
+			t.Errorf("IssueCredential() credRevId = '%v', error = '%v'", credRevId, errIssue)
+			return
+		}
+		revRegIds = append(revRegIds, revRegId)
+
+		// the next registry is ready as soon as the first one is half used
+		ready, errReady := manager.Registries(info.CredentialDefinitionId, RevocRegistryStateReady)
+		if i == 0 && (errReady != nil || len(ready) != 1) {
+			t.Errorf("Registries() ready = '%v', error = '%v'", ready, errReady)
+			return
+		}
+	}
+	if revRegIds[0] != revRegIds[1] || revRegIds[1] == revRegIds[2] {
+		t.Errorf("IssueCredential() registries = '%v'", revRegIds)
+		return
+	}
+
+	full, errFull := manager.Registries(info.CredentialDefinitionId, RevocRegistryStateFull)
+	if errFull != nil || len(full) != 1 || full[0].RevRegId != revRegIds[0] || full[0].Issued != 2 {
+		t.Errorf("Registries() full = '%v', error = '%v'", full, errFull)
+		return
+	}
+	active, errActive := manager.Active(info.CredentialDefinitionId)
+	if errActive != nil || active.RevRegId != revRegIds[2] || active.Issued != 1 || active.Published {
+		t.Errorf("Active() registry = '%v', error = '%v'", active, errActive)
+		return
+	}
+}
+
+// fakeRevocLedger ledger of the registry manager unit tests, the first entry write fails and landed tells
+// whether it reached the ledger before failing
+type fakeRevocLedger struct {
+	defs, entries int
+	failed        bool
+	landed        bool
+}
+
+func (l *fakeRevocLedger) signAndSubmit(ctx context.Context, ph int, wh int, did string, request string) (string, error) {
+	var parsed struct {
+		Operation struct {
+			Type string `json:"type"`
+		} `json:"operation"`
+	}
+	json.Unmarshal([]byte(request), &parsed)
+	switch parsed.Operation.Type {
+	case types.TxnTypeRevocRegDef:
+		l.defs++
+	case types.TxnTypeRevocRegEntry:
+		if !l.failed {
+			l.failed = true
+			if l.landed {
+				l.entries++
+			}
+			return "", indyUtils.ErrPoolLedgerTimeout
+		}
+		l.entries++
+	}
+	return `{"op":"REPLY","result":{"txnMetadata":{"seqNo":1}}}`, nil
+}
+
+func (l *fakeRevocLedger) submit(ctx context.Context, ph int, request string) (string, error) {
+	if l.entries == 0 {
+		return `{"op":"REPLY","result":{"type":"117","data":null}}`, nil
+	}
+	return `{"op":"REPLY","result":{"type":"117","seqNo":2,"data":{"value":{}}}}`, nil
+}
+
+func TestRevocRegistryManagerPublishRetry(t *testing.T) {
+	whIssuer, errCreate := createWallet(issuerConfig(), issuerCredentials())
+	if errCreate != nil && !errors.Is(errCreate, indyUtils.ErrWalletAlreadyExists) {
+		t.Errorf("CreateWallet() error = '%v'", errCreate)
+		return
+	}
+	defer walletCleanup(whIssuer, issuerConfig(), issuerCredentials())
+	tailsDir := t.TempDir()
+
+	tests := []struct {
+		name   string
+		landed bool
+	}{
+		{"retry-entry-not-written", false},
+		{"retry-entry-reply-lost", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// an issuer and cred def per case, the registry of the previous case stays active
+			didIssuer, _, _ := CreateAndStoreDID(whIssuer, "")
+			info, _, _, errCredDef := createRevocableCredDef(whIssuer, didIssuer, tailsDir, 5)
+			if errCredDef != nil {
+				t.Errorf("createRevocableCredDef() error = '%v'", errCredDef)
+				return
+			}
+			credDefId := info.CredentialDefinitionId
+
+			// the ledger is faked, the pool handle only has to be set for the registries to be published
+			ledger := &fakeRevocLedger{landed: tt.landed}
+			manager := NewRevocRegistryManager(1, whIssuer, didIssuer, tailsDir, 0, 2)
+			manager.signAndSubmit, manager.submit = ledger.signAndSubmit, ledger.submit
+
+			_, errActive := manager.Active(credDefId)
+			if !errors.Is(errActive, indyUtils.ErrPoolLedgerTimeout) {
+				t.Errorf("Active() entry write error = '%v'", errActive)
+				return
+			}
+			registries, _ := manager.Registries(credDefId, RevocRegistryStateActive)
+			if len(registries) != 1 || !registries[0].DefPublished || registries[0].Published {
+				t.Errorf("Registries() after the failed entry = '%v'", registries)
+				return
+			}
+
+			active, errActive := manager.Active(credDefId)
+			if errActive != nil || !active.Published || active.RevRegEntryJson != "" {
+				t.Errorf("Active() retry registry = '%v', error = '%v'", active, errActive)
+				return
+			}
+			if ledger.defs != 1 || ledger.entries != 1 {
+				t.Errorf("ledger writes defs = %d, entries = %d, want = 1, 1", ledger.defs, ledger.entries)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+/*
+// ******************************************************************
+// Purpose: transaction author agreement workflow, fetches the agreement
+// of a pool, stores the acceptance and appends it to write requests
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package indySDK
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"github.com/joyride9999/IndySdkGoBindings/ledger/types"
+	"github.com/joyride9999/IndySdkGoBindings/nonsecrets"
+	"sync"
+	"time"
+)
+
+// TaaAcceptanceRecordType non secret record type of the stored acceptances, the record id is the agreement digest
+const TaaAcceptanceRecordType = "taa_acceptance"
+
+// TaaCacheTimeout how long the agreement and acceptance mechanisms fetched from a pool are reused
+var TaaCacheTimeout = 10 * time.Minute
+
+// ErrTaaNotAccepted is returned when a write request needs an acceptance of the active agreement and the wallet has none
+var ErrTaaNotAccepted = errors.New("transaction author agreement not accepted")
+
+// ErrUnknownAcceptanceMechanism is returned when accepting with a mechanism missing from the acceptance mechanisms list
+var ErrUnknownAcceptanceMechanism = errors.New("unknown acceptance mechanism")
+
+// TaaInfo active transaction author agreement of a pool and its acceptance mechanisms
+type TaaInfo struct {
+	Required bool                        // false when the pool has no active agreement
+	Taa      *types.TxnAuthorAgreement   // nil when not Required
+	Aml      *types.AcceptanceMechanisms // nil when not Required
+	Digest   string
+	Fetched  time.Time
+}
+
+// TaaAcceptanceRecord acceptance of an agreement stored in the wallet
+type TaaAcceptanceRecord struct {
+	Version   string `json:"version"`
+	Digest    string `json:"digest"`
+	Mechanism string `json:"mechanism"`
+	Time      uint64 `json:"time"`
+}
+
+// taaPools agreements cached and auto append settings per pool handle
+type taaPools struct {
+	mutex      sync.Mutex
+	info       map[int]*TaaInfo
+	autoAppend map[int]bool
+}
+
+var taaState = taaPools{info: make(map[int]*TaaInfo), autoAppend: make(map[int]bool)}
+
+// SetPoolTaaAutoAppend makes SignAndSubmitRequest append the stored acceptance to the domain write requests
+// sent on the pool handle while the pool has an active agreement
+func SetPoolTaaAutoAppend(ph int, enabled bool) {
+	taaState.mutex.Lock()
+	defer taaState.mutex.Unlock()
+
+	if enabled {
+		taaState.autoAppend[ph] = true
+	} else {
+		delete(taaState.autoAppend, ph)
+	}
+}
+
+// InvalidateTaaInfo drops the cached agreement of a pool handle, the next call fetches it again
+func InvalidateTaaInfo(ph int) {
+	taaState.mutex.Lock()
+	defer taaState.mutex.Unlock()
+
+	delete(taaState.info, ph)
+}
+
+// forgetTaaPool drops everything known about a closed pool handle
+func forgetTaaPool(ph int) {
+	taaState.mutex.Lock()
+	defer taaState.mutex.Unlock()
+
+	delete(taaState.info, ph)
+	delete(taaState.autoAppend, ph)
+}
+
+// GetTaaInfo returns the active agreement and acceptance mechanisms of a pool, cached for TaaCacheTimeout
+func GetTaaInfo(ph int, submitterDid string) (*TaaInfo, error) {
+	return GetTaaInfoCtx(context.Background(), ph, submitterDid)
+}
+
+// GetTaaInfoCtx is like GetTaaInfo but returns ctx.Err() if ctx is done before libindy answers.
+func GetTaaInfoCtx(ctx context.Context, ph int, submitterDid string) (*TaaInfo, error) {
+	taaState.mutex.Lock()
+	info, ok := taaState.info[ph]
+	taaState.mutex.Unlock()
+	if ok && time.Since(info.Fetched) < TaaCacheTimeout {
+		return info, nil
+	}
+
+	info, err := fetchTaaInfo(ctx, ph, submitterDid)
+	if err != nil {
+		return nil, err
+	}
+
+	taaState.mutex.Lock()
+	taaState.info[ph] = info
+	taaState.mutex.Unlock()
+	return info, nil
+}
+
+func fetchTaaInfo(ctx context.Context, ph int, submitterDid string) (*TaaInfo, error) {
+	info := &TaaInfo{Fetched: time.Now()}
+
+	taaRequest, err := BuildGetTxnAuthorAgreementRequestCtx(ctx, submitterDid, "")
+	if err != nil {
+		return nil, err
+	}
+	taaResponse, err := SubmitRequestCtx(ctx, ph, taaRequest)
+	if err != nil {
+		return nil, err
+	}
+	taa, _, err := types.ParseGetTxnAuthorAgreementReply(taaResponse)
+	if errors.Is(err, types.ErrNotFound) {
+		return info, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !taa.Active(info.Fetched) {
+		return info, nil
+	}
+
+	amlRequest, err := BuildGetAcceptanceMechanismsRequestCtx(ctx, submitterDid, -1, "")
+	if err != nil {
+		return nil, err
+	}
+	amlResponse, err := SubmitRequestCtx(ctx, ph, amlRequest)
+	if err != nil {
+		return nil, err
+	}
+	aml, _, err := types.ParseGetAcceptanceMechanismsReply(amlResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	info.Required = true
+	info.Taa = taa
+	info.Aml = aml
+	info.Digest = taa.Digest
+	if len(info.Digest) == 0 {
+		info.Digest = types.TaaDigest(taa.Text, taa.Version)
+	}
+	return info, nil
+}
+
+// AcceptTaa stores in the wallet the acceptance of the active agreement of the pool with the given mechanism,
+// replacing a previous acceptance of the same agreement. Returns nil when the pool has no active agreement.
+func AcceptTaa(ph int, wh int, submitterDid string, mechanism string) (*TaaAcceptanceRecord, error) {
+	return AcceptTaaCtx(context.Background(), ph, wh, submitterDid, mechanism)
+}
+
+// AcceptTaaCtx is like AcceptTaa but returns ctx.Err() if ctx is done before libindy answers.
+func AcceptTaaCtx(ctx context.Context, ph int, wh int, submitterDid string, mechanism string) (*TaaAcceptanceRecord, error) {
+	info, err := GetTaaInfoCtx(ctx, ph, submitterDid)
+	if err != nil {
+		return nil, err
+	}
+	if !info.Required {
+		return nil, nil
+	}
+	if _, ok := info.Aml.Aml[mechanism]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAcceptanceMechanism, mechanism)
+	}
+
+	acceptance := &TaaAcceptanceRecord{
+		Version:   info.Taa.Version,
+		Digest:    info.Digest,
+		Mechanism: mechanism,
+		Time:      info.Taa.AcceptanceTime(time.Now()),
+	}
+	value, err := json.Marshal(acceptance)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := json.Marshal(map[string]string{"version": acceptance.Version, "mechanism": mechanism})
+	if err != nil {
+		return nil, err
+	}
+
+	err = IndyAddWalletRecordCtx(ctx, wh, TaaAcceptanceRecordType, acceptance.Digest, string(value), string(tags))
+	if errors.Is(err, indyUtils.ErrWalletItemAlreadyExists) {
+		err = IndyUpdateWalletRecordValueCtx(ctx, wh, TaaAcceptanceRecordType, acceptance.Digest, string(value))
+		if err == nil {
+			err = IndyUpdateWalletRecordTagsCtx(ctx, wh, TaaAcceptanceRecordType, acceptance.Digest, string(tags))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return acceptance, nil
+}
+
+// GetTaaAcceptance returns the acceptance stored for the agreement digest, fails with ErrTaaNotAccepted if there is none
+func GetTaaAcceptance(wh int, digest string) (*TaaAcceptanceRecord, error) {
+	return GetTaaAcceptanceCtx(context.Background(), wh, digest)
+}
+
+// GetTaaAcceptanceCtx is like GetTaaAcceptance but returns ctx.Err() if ctx is done before libindy answers.
+func GetTaaAcceptanceCtx(ctx context.Context, wh int, digest string) (*TaaAcceptanceRecord, error) {
+	recordJson, err := IndyGetWalletRecordCtx(ctx, wh, TaaAcceptanceRecordType, digest, `{"retrieveValue":true}`)
+	if errors.Is(err, indyUtils.ErrWalletItemNotFound) {
+		return nil, fmt.Errorf("%w: digest %s", ErrTaaNotAccepted, digest)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var record nonsecrets.Record
+	err = json.Unmarshal([]byte(recordJson), &record)
+	if err != nil {
+		return nil, err
+	}
+	var acceptance TaaAcceptanceRecord
+	err = json.Unmarshal([]byte(record.Value), &acceptance)
+	if err != nil {
+		return nil, err
+	}
+	return &acceptance, nil
+}
+
+// AppendTaaAcceptance appends the stored acceptance of the active agreement to a domain write request.
+// Requests that don't need it, or sent to pools without an active agreement, are returned unchanged.
+// Fails with ErrTaaNotAccepted when the wallet has no acceptance of the active agreement.
+func AppendTaaAcceptance(ph int, wh int, submitterDid string, requestJson string) (string, error) {
+	return AppendTaaAcceptanceCtx(context.Background(), ph, wh, submitterDid, requestJson)
+}
+
+// AppendTaaAcceptanceCtx is like AppendTaaAcceptance but returns ctx.Err() if ctx is done before libindy answers.
+func AppendTaaAcceptanceCtx(ctx context.Context, ph int, wh int, submitterDid string, requestJson string) (string, error) {
+	request, err := types.ParseRequest(requestJson)
+	if err != nil {
+		return "", err
+	}
+	txnType, err := request.OperationType()
+	if err != nil {
+		return "", err
+	}
+	if request.TaaAcceptance != nil || !types.RequiresTaaAcceptance(txnType) {
+		return requestJson, nil
+	}
+
+	info, err := GetTaaInfoCtx(ctx, ph, submitterDid)
+	if err != nil {
+		return "", err
+	}
+	if !info.Required {
+		return requestJson, nil
+	}
+
+	acceptance, err := GetTaaAcceptanceCtx(ctx, wh, info.Digest)
+	if err != nil {
+		return "", err
+	}
+	return AppendTxnAuthorAgreementAcceptanceToRequestCtx(ctx, requestJson, "", "", acceptance.Digest, acceptance.Mechanism, int64(acceptance.Time))
+}
+
+// taaAutoAppend appends the acceptance when SetPoolTaaAutoAppend is enabled on the pool handle
+func taaAutoAppend(ctx context.Context, ph int, wh int, submitterDid string, requestJson string) (string, error) {
+	taaState.mutex.Lock()
+	enabled := taaState.autoAppend[ph]
+	taaState.mutex.Unlock()
+	if !enabled {
+		return requestJson, nil
+	}
+	return AppendTaaAcceptanceCtx(ctx, ph, wh, submitterDid, requestJson)
+}
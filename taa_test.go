@@ -0,0 +1,129 @@
+/*
+// ******************************************************************
+// Purpose: transaction author agreement workflow unit testing
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package indySDK
+
+import (
+	"errors"
+	"fmt"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"github.com/joyride9999/IndySdkGoBindings/ledger/types"
+	"testing"
+	"time"
+)
+
+func TestTaaWorkflow(t *testing.T) {
+	poolHandle, errPool := getPoolLedger("testpool2")
+	if errPool != nil {
+		t.Errorf("getPoolLedger() error = '%v'", errPool)
+		return
+	}
+	defer ClosePoolHandle(poolHandle)
+
+	whTrustee, errCreate := createWallet(trusteeConfig(), trusteeCredentials())
+	if errCreate != nil && !errors.Is(errCreate, indyUtils.ErrWalletAlreadyExists) {
+		t.Errorf("createWallet() error = '%v'", errCreate)
+		return
+	}
+	defer walletCleanup(whTrustee, trusteeConfig(), trusteeCredentials())
+
+	trusteeDid, _, errDid := CreateAndStoreDID(whTrustee, seedTrustee1)
+	if errDid != nil {
+		t.Errorf("CreateAndStoreDid() error = '%v'", errDid)
+		return
+	}
+
+	// the ledger refuses to reuse versions, so every run publishes new ones
+	version := fmt.Sprintf("%d", time.Now().UnixNano())
+	text := "indy agreement " + version
+
+	amlRequest, _ := BuildAcceptanceMechanismsRequest(trusteeDid, `{"on_file":"on file","click_agreement":"click"}`, version, "")
+	_, errAml := SignAndSubmitRequestTyped(poolHandle, whTrustee, trusteeDid, mustParseRequest(amlRequest))
+	if errAml != nil {
+		t.Errorf("SignAndSubmitRequest() aml error = '%v'", errAml)
+		return
+	}
+	taaRequest, _ := BuildTxnAuthorAgreementRequest(trusteeDid, text, version, time.Now().Unix(), 0)
+	_, errTaa := SignAndSubmitRequestTyped(poolHandle, whTrustee, trusteeDid, mustParseRequest(taaRequest))
+	if errTaa != nil {
+		t.Errorf("SignAndSubmitRequest() taa error = '%v'", errTaa)
+		return
+	}
+	defer func() {
+		disableRequest, _ := BuildDisableAllTxnAuthorAgreementsRequest(trusteeDid)
+		request, _ := AppendTaaAcceptance(poolHandle, whTrustee, trusteeDid, disableRequest)
+		SignAndSubmitRequest(poolHandle, whTrustee, trusteeDid, request)
+	}()
+	InvalidateTaaInfo(poolHandle)
+
+	info, errInfo := GetTaaInfo(poolHandle, trusteeDid)
+	if errInfo != nil {
+		t.Errorf("GetTaaInfo() error = '%v'", errInfo)
+		return
+	}
+	if !info.Required || info.Taa.Version != version || info.Digest != types.TaaDigest(text, version) {
+		t.Errorf("GetTaaInfo() values are not correct = '%+v'", info)
+		return
+	}
+
+	did, verKey, _ := CreateAndStoreDID(whTrustee, "")
+	nymRequest, _ := BuildNymRequest(trusteeDid, did, verKey, "", "")
+
+	type args struct {
+		Mechanism  string
+		AutoAppend bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{"test-taa-not-accepted", args{Mechanism: "", AutoAppend: true}, ErrTaaNotAccepted},
+		{"test-taa-unknown-mechanism", args{Mechanism: "unknown"}, ErrUnknownAcceptanceMechanism},
+		{"test-taa-without-auto-append-rejected", args{Mechanism: "click_agreement"}, types.ErrRequestRejected},
+		{"test-taa-auto-append-works", args{Mechanism: "click_agreement", AutoAppend: true}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetPoolTaaAutoAppend(poolHandle, tt.args.AutoAppend)
+			defer SetPoolTaaAutoAppend(poolHandle, false)
+
+			if len(tt.args.Mechanism) > 0 {
+				acceptance, errAccept := AcceptTaa(poolHandle, whTrustee, trusteeDid, tt.args.Mechanism)
+				if errAccept != nil {
+					if !errors.Is(errAccept, tt.wantErr) {
+						t.Errorf("AcceptTaa() error = '%v', want = '%v'", errAccept, tt.wantErr)
+					}
+					return
+				}
+				stored, errGet := GetTaaAcceptance(whTrustee, info.Digest)
+				if errGet != nil || *stored != *acceptance {
+					t.Errorf("GetTaaAcceptance() = '%v', error = '%v'", stored, errGet)
+					return
+				}
+			}
+
+			_, errSubmit := SignAndSubmitRequestTyped(poolHandle, whTrustee, trusteeDid, mustParseRequest(nymRequest))
+			if !errors.Is(errSubmit, tt.wantErr) {
+				t.Errorf("SignAndSubmitRequest() error = '%v', want = '%v'", errSubmit, tt.wantErr)
+			}
+		})
+	}
+}
+
+func mustParseRequest(requestJson string) *types.Request {
+	request, err := types.ParseRequest(requestJson)
+	if err != nil {
+		panic(err)
+	}
+	return request
+}
@@ -12,11 +12,14 @@
 package indySDK
 
 import (
+	"errors"
+	"fmt"
+	"github.com/Jeffail/gabs/v2"
+	"github.com/joyride9999/IndySdkGoBindings/anoncreds"
+	"github.com/joyride9999/IndySdkGoBindings/blobstorage"
 	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
 	"github.com/joyride9999/IndySdkGoBindings/pool"
 	"github.com/joyride9999/IndySdkGoBindings/wallet"
-	"fmt"
-	"github.com/Jeffail/gabs/v2"
 )
 
 const tag = "tag0"
@@ -118,6 +121,64 @@ func searchAndFetchCredForProofReq(whHolder int, proofRequest string) (string, s
 	return attrCreds, predCreds, nil
 }
 
+// createRevocableCredDef creates a credential definition supporting revocation and an on demand revocation registry
+// for it, the tails file is written to tailsDir. Returns the definitions and the initial registry entry.
+func createRevocableCredDef(whIssuer int, didIssuer string, tailsDir string, maxCredNum int) (anoncreds.CredentialDefinitionInfo, string, string, error) {
+	var info anoncreds.CredentialDefinitionInfo
+	_, schemaJson, errSchema := IssuerCreateSchema(didIssuer, "gvt", "1.0", schemaAttributes)
+	if errSchema != nil {
+		return info, "", "", errSchema
+	}
+	info.SchemaJson = schemaJson
+
+	credDefConfig := jsonObjectToString(anoncreds.CredentialConfig{SupportsRevocation: true})
+	credDefId, credDefJson, errCredDef := IssuerCreateAndStoreCredentialDefinition(whIssuer, didIssuer, schemaJson, tag, "CL", credDefConfig)
+	if errCredDef != nil {
+		return info, "", "", errCredDef
+	}
+	info.CredentialDefinitionId, info.CredentialDefinitionJson = credDefId, credDefJson
+
+	blobWriterHandle, errWriter := IndyOpenBlobStorageWriter("default", jsonObjectToString(blobstorage.ConfigBlobStorage{BaseDir: tailsDir}))
+	if errWriter != nil {
+		return info, "", "", errWriter
+	}
+
+	revRegConfig := jsonObjectToString(anoncreds.RevocRegConfig{MaxCredNumber: maxCredNum, IssuanceType: "ISSUANCE_ON_DEMAND"})
+	revRegId, revRegDefJson, revRegEntryJson, errRevReg := IssuerCreateAndStoreRevocReg(whIssuer, didIssuer, "CL_ACCUM", tag, credDefId, revRegConfig, blobWriterHandle)
+	if errRevReg != nil {
+		return info, "", "", errRevReg
+	}
+	info.RevocationRegistryId = revRegId
+
+	return info, revRegDefJson, revRegEntryJson, nil
+}
+
+// issueRevocableCredential issues a credential of the revocable cred def to the holder and stores it in the holder wallet,
+// returns the credential id, its revocation id and the registry delta of the issuance
+func issueRevocableCredential(whIssuer int, info anoncreds.CredentialDefinitionInfo, revRegDefJson string, blobReaderHandle int, whHolder int, didHolder string, masterSecret string) (string, string, string, error) {
+	credOffer, errOffer := IssuerCreateCredentialOffer(whIssuer, info.CredentialDefinitionId)
+	if errOffer != nil {
+		return "", "", "", errOffer
+	}
+
+	credentialRequest, credentialRequestMetadata, errRequest := ProverCreateCredentialRequest(whHolder, didHolder, credOffer, info.CredentialDefinitionJson, masterSecret)
+	if errRequest != nil {
+		return "", "", "", errRequest
+	}
+
+	credentialJson, credRevId, revRegDeltaJson, errCreateCred := IssuerCreateCredential(whIssuer, credOffer, credentialRequest, credValuesJson, info.RevocationRegistryId, blobReaderHandle)
+	if errCreateCred != nil {
+		return "", "", "", errCreateCred
+	}
+
+	credentialId, errStore := ProverStoreCredential(whHolder, "", credentialRequestMetadata, credentialJson, info.CredentialDefinitionJson, revRegDefJson)
+	if errStore != nil {
+		return "", "", "", errStore
+	}
+
+	return credentialId, credRevId, revRegDeltaJson, nil
+}
+
 /*
 	did_test.go
 */
@@ -164,7 +225,7 @@ func testConfig() wallet.Config {
 
 func createWallet(config wallet.Config, credentials wallet.Credential) (int, error) {
 	errCreate := CreateWallet(config, credentials)
-	if errCreate != nil && errCreate.Error() != indyUtils.GetIndyError(203) {
+	if errCreate != nil && !errors.Is(errCreate, indyUtils.ErrWalletAlreadyExists) {
 		return 0, errCreate
 	}
 
@@ -213,7 +274,7 @@ func getPoolLedger(poolName string) (int, error) {
 	}
 
 	errCreatePool := CreatePoolLedgerConfig(poolLedger)
-	if errCreatePool != nil && errCreatePool.Error() != indyUtils.GetIndyError(306) {
+	if errCreatePool != nil && !errors.Is(errCreatePool, indyUtils.ErrPoolConfigAlreadyExists) {
 		return 0, errCreatePool
 	}
 
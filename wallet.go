@@ -17,14 +17,21 @@ package indySDK
 */
 import "C"
 import (
-	"github.com/joyride9999/IndySdkGoBindings/wallet"
+	"context"
 	"encoding/json"
 	"errors"
+	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
+	"github.com/joyride9999/IndySdkGoBindings/wallet"
 	"unsafe"
 )
 
 // CreateWallet creates a new secure wallet with the given unique name
 func CreateWallet(config wallet.Config, credential wallet.Credential) error {
+	return CreateWalletCtx(context.Background(), config, credential)
+}
+
+// CreateWalletCtx is like CreateWallet but returns ctx.Err() if ctx is done before libindy answers.
+func CreateWalletCtx(ctx context.Context, config wallet.Config, credential wallet.Credential) error {
 
 	jsonConfig, err := json.Marshal(config)
 	if err != nil {
@@ -41,12 +48,17 @@ func CreateWallet(config wallet.Config, credential wallet.Credential) error {
 	defer C.free(upWalletCredential)
 
 	channel := wallet.CreateWallet(upWalletCfg, upWalletCredential)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // OpenWallet opens an existing wallet
 func OpenWallet(config wallet.Config, credential wallet.Credential) (int, error) {
+	return OpenWalletCtx(context.Background(), config, credential)
+}
+
+// OpenWalletCtx is like OpenWallet but returns ctx.Err() if ctx is done before libindy answers.
+func OpenWalletCtx(ctx context.Context, config wallet.Config, credential wallet.Credential) (int, error) {
 	jsonConfig, err := json.Marshal(config)
 	if err != nil {
 		return 0, errors.New("cant read json")
@@ -62,7 +74,7 @@ func OpenWallet(config wallet.Config, credential wallet.Credential) (int, error)
 	defer C.free(upWalletCredential)
 
 	channel := wallet.OpenWallet(upWalletCfg, upWalletCredential)
-	result := <-channel
+	result := indyUtils.WaitForHandle(ctx, channel, func(wh int) { CloseWallet(wh) })
 	if result.Error != nil {
 		return 0, result.Error
 	}
@@ -71,13 +83,23 @@ func OpenWallet(config wallet.Config, credential wallet.Credential) (int, error)
 
 // CloseWallet creates a new secure wallet with the given unique name
 func CloseWallet(wh int) error {
+	return CloseWalletCtx(context.Background(), wh)
+}
+
+// CloseWalletCtx is like CloseWallet but returns ctx.Err() if ctx is done before libindy answers.
+func CloseWalletCtx(ctx context.Context, wh int) error {
 	channel := wallet.CloseWallet(wh)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // DeleteWallet deletes a secure wallet
 func DeleteWallet(config wallet.Config, credentials wallet.Credential) error {
+	return DeleteWalletCtx(context.Background(), config, credentials)
+}
+
+// DeleteWalletCtx is like DeleteWallet but returns ctx.Err() if ctx is done before libindy answers.
+func DeleteWalletCtx(ctx context.Context, config wallet.Config, credentials wallet.Credential) error {
 
 	jsonConfig, err := json.Marshal(config)
 	if err != nil {
@@ -94,12 +116,17 @@ func DeleteWallet(config wallet.Config, credentials wallet.Credential) error {
 	defer C.free(upWalletCredential)
 
 	channel := wallet.DeleteWallet(upWalletCfg, upWalletCredential)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // GenerateWalletKey generate wallet master key
 func GenerateWalletKey(config wallet.Config) error {
+	return GenerateWalletKeyCtx(context.Background(), config)
+}
+
+// GenerateWalletKeyCtx is like GenerateWalletKey but returns ctx.Err() if ctx is done before libindy answers.
+func GenerateWalletKeyCtx(ctx context.Context, config wallet.Config) error {
 	jsonConfig, err := json.Marshal(config)
 	if err != nil {
 		return errors.New("cant read json")
@@ -108,12 +135,17 @@ func GenerateWalletKey(config wallet.Config) error {
 	defer C.free(upWalletCfg)
 
 	channel := wallet.GenerateWalletKey(upWalletCfg)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // ExportWallet exports opened wallet
 func ExportWallet(wh int, config wallet.ExportConfig) error {
+	return ExportWalletCtx(context.Background(), wh, config)
+}
+
+// ExportWalletCtx is like ExportWallet but returns ctx.Err() if ctx is done before libindy answers.
+func ExportWalletCtx(ctx context.Context, wh int, config wallet.ExportConfig) error {
 	jsonConfig, err := json.Marshal(config)
 	if err != nil {
 		return errors.New("cant read json")
@@ -122,12 +154,17 @@ func ExportWallet(wh int, config wallet.ExportConfig) error {
 	defer C.free(upWalletCfg)
 
 	channel := wallet.ExportWallet(wh, upWalletCfg)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // ImportWallet creates new secure wallet and imports its content
 func ImportWallet(config wallet.Config, credentials wallet.Credential, importConfig wallet.ImportConfig) error {
+	return ImportWalletCtx(context.Background(), config, credentials, importConfig)
+}
+
+// ImportWalletCtx is like ImportWallet but returns ctx.Err() if ctx is done before libindy answers.
+func ImportWalletCtx(ctx context.Context, config wallet.Config, credentials wallet.Credential, importConfig wallet.ImportConfig) error {
 
 	jsonConfig, err := json.Marshal(config)
 	if err != nil {
@@ -150,17 +187,22 @@ func ImportWallet(config wallet.Config, credentials wallet.Credential, importCon
 	defer C.free(upWalletImportCfg)
 
 	channel := wallet.ImportWallet(upWalletCfg, upWalletCredential, upWalletImportCfg)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
 
 // RegisterWalletStorage registers new wallet type
 func RegisterWalletStorage(storageType string, storage wallet.IWalletStorage) error {
+	return RegisterWalletStorageCtx(context.Background(), storageType, storage)
+}
+
+// RegisterWalletStorageCtx is like RegisterWalletStorage but returns ctx.Err() if ctx is done before libindy answers.
+func RegisterWalletStorageCtx(ctx context.Context, storageType string, storage wallet.IWalletStorage) error {
 
 	upStorageType := unsafe.Pointer(C.CString(storageType))
 	defer C.free(upStorageType)
 
 	channel := wallet.RegisterWalletStorage(upStorageType, storage)
-	result := <-channel
+	result := indyUtils.WaitForResult(ctx, channel)
 	return result.Error
 }
@@ -19,13 +19,22 @@ package wallet
 #include <stdlib.h>
 */
 import "C"
-import "unsafe"
+import (
+	"encoding/json"
+	"strings"
+	"unsafe"
+)
 
 // StorageConfig represents Indy wallet storage config
 type StorageConfig struct {
 	Path   string `json:"path"`
 	Dsn    string `json:"dsn"`    // Used with custom pg storage
 	LogSql int    `json:"logsql"` // Used with custom pg storage
+
+	// Connection pool shared by all the wallets with the same dsn, used with custom pg storage
+	MaxOpenConns    int `json:"max_open_conns,omitempty"`    // 0 means unlimited
+	MaxIdleConns    int `json:"max_idle_conns,omitempty"`    // 0 keeps the database/sql default
+	ConnMaxLifetime int `json:"conn_max_lifetime,omitempty"` // seconds, 0 means connections are reused forever
 }
 
 // Config represents Indy wallet config
@@ -70,6 +79,48 @@ type RecordValue struct {
 	Value unsafe.Pointer
 }
 
+// RecordOptions options passed by libindy to custom storages when fetching a single record
+type RecordOptions struct {
+	RetrieveType  bool `json:"retrieveType"`
+	RetrieveValue bool `json:"retrieveValue"`
+	RetrieveTags  bool `json:"retrieveTags"`
+}
+
+// SearchOptions options passed by libindy to custom storages when opening a search
+type SearchOptions struct {
+	RetrieveRecords    bool `json:"retrieveRecords"`
+	RetrieveTotalCount bool `json:"retrieveTotalCount"`
+	RetrieveType       bool `json:"retrieveType"`
+	RetrieveValue      bool `json:"retrieveValue"`
+	RetrieveTags       bool `json:"retrieveTags"`
+}
+
+// ParseRecordOptions parses record options, missing fields get the libindy defaults
+func ParseRecordOptions(optionsJson string) (RecordOptions, error) {
+	options := RecordOptions{RetrieveType: false, RetrieveValue: true, RetrieveTags: false}
+	if len(strings.TrimSpace(optionsJson)) == 0 {
+		return options, nil
+	}
+	err := json.Unmarshal([]byte(optionsJson), &options)
+	if err != nil {
+		return RecordOptions{}, err
+	}
+	return options, nil
+}
+
+// ParseSearchOptions parses search options, missing fields get the libindy defaults
+func ParseSearchOptions(optionsJson string) (SearchOptions, error) {
+	options := SearchOptions{RetrieveRecords: true, RetrieveTotalCount: false, RetrieveType: false, RetrieveValue: true, RetrieveTags: false}
+	if len(strings.TrimSpace(optionsJson)) == 0 {
+		return options, nil
+	}
+	err := json.Unmarshal([]byte(optionsJson), &options)
+	if err != nil {
+		return SearchOptions{}, err
+	}
+	return options, nil
+}
+
 type IWalletStorage interface {
 	Create(name string, config string, credentialsJson string, metadata string) (int, error)
 	Open(name string, config string, credentials string) (int, int, error)
@@ -118,7 +118,6 @@ import "C"
 
 import (
 	"github.com/joyride9999/IndySdkGoBindings/indyUtils"
-	"errors"
 	"unsafe"
 )
 
@@ -127,8 +126,8 @@ func createWalletCB(commandHandle C.indy_handle_t, indyError C.indy_error_t) {
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -178,8 +177,8 @@ func CreateWallet(config, credential unsafe.Pointer) chan indyUtils.IndyResult {
 		(*C.char)(credential),
 		(C.cb_createWallet)(unsafe.Pointer(C.createWalletCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -191,8 +190,8 @@ func closeWalletCB(commandHandle C.indy_handle_t, indyError C.indy_error_t) {
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -212,8 +211,8 @@ func CloseWallet(wh int) chan indyUtils.IndyResult {
 		(C.indy_handle_t)(wh),
 		(C.cb_closeWallet)(unsafe.Pointer(C.closeWalletCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
-		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)}) }()
+		indyErr := indyUtils.NewIndyError(int(res))
+		go func() { indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr}) }()
 		return future
 	}
 
@@ -225,8 +224,8 @@ func openWalletCB(commandHandle C.indy_handle_t, indyError C.indy_error_t, retHa
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil, Results: []interface{}{int(retHandle)}})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -285,9 +284,9 @@ func OpenWallet(config, credential unsafe.Pointer) chan indyUtils.IndyResult {
 		(*C.char)(credential),
 		(C.cb_openWallet)(unsafe.Pointer(C.openWalletCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
+		indyErr := indyUtils.NewIndyError(int(res))
 		go func() {
-			indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+			indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr})
 		}()
 		return future
 	}
@@ -300,8 +299,8 @@ func deleteWalletCB(commandHandle C.indy_handle_t, indyError C.indy_error_t) {
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -352,9 +351,9 @@ func DeleteWallet(config, credentials unsafe.Pointer) chan indyUtils.IndyResult
 		(*C.char)(credentials),
 		(C.cb_deleteWallet)(unsafe.Pointer(C.deleteWalletCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
+		indyErr := indyUtils.NewIndyError(int(res))
 		go func() {
-			indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+			indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr})
 		}()
 		return future
 	}
@@ -367,8 +366,8 @@ func generateWalletKeyCB(commandHandle C.indy_handle_t, indyError C.indy_error_t
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -396,9 +395,9 @@ func GenerateWalletKey(config unsafe.Pointer) chan indyUtils.IndyResult {
 		(*C.char)(config),
 		(C.cb_generateWalletKey)(unsafe.Pointer(C.generateWalletKeyCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
+		indyErr := indyUtils.NewIndyError(int(res))
 		go func() {
-			indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+			indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr})
 		}()
 		return future
 	}
@@ -411,8 +410,8 @@ func exportWalletCB(commandHandle C.indy_handle_t, indyError C.indy_error_t) {
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -444,9 +443,9 @@ func ExportWallet(wh int, config unsafe.Pointer) chan indyUtils.IndyResult {
 		(*C.char)(config),
 		(C.cb_exportWallet)(unsafe.Pointer(C.exportWalletCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
+		indyErr := indyUtils.NewIndyError(int(res))
 		go func() {
-			indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+			indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr})
 		}()
 		return future
 	}
@@ -459,8 +458,8 @@ func importWalletCB(commandHandle C.indy_handle_t, indyError C.indy_error_t) {
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -519,9 +518,9 @@ func ImportWallet(config, credentials, importConfig unsafe.Pointer) chan indyUti
 		(*C.char)(importConfig),
 		(C.cb_importWallet)(unsafe.Pointer(C.importWalletCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
+		indyErr := indyUtils.NewIndyError(int(res))
 		go func() {
-			indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+			indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr})
 		}()
 		return future
 	}
@@ -950,8 +949,8 @@ func registerWalletStorageCB(commandHandle C.indy_handle_t, indyError C.indy_err
 	if indyError == 0 {
 		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: nil})
 	} else {
-		errMsg := indyUtils.GetIndyError(int(indyError))
-		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+		indyErr := indyUtils.CurrentIndyError(int(indyError))
+		indyUtils.RemoveFuture((int)(commandHandle), indyUtils.IndyResult{Error: indyErr})
 	}
 }
 
@@ -990,9 +989,9 @@ func RegisterWalletStorage(storageType unsafe.Pointer, storage IWalletStorage) c
 		(C.cb_freeSearchWallet)(unsafe.Pointer(C.freeSearchWalletCB)),
 		(C.cb_registerWalletStorage)(unsafe.Pointer(C.registerWalletStorageCB)))
 	if res != 0 {
-		errMsg := indyUtils.GetIndyError(int(res))
+		indyErr := indyUtils.NewIndyError(int(res))
 		go func() {
-			indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: errors.New(errMsg)})
+			indyUtils.RemoveFuture((int)(handle), indyUtils.IndyResult{Error: indyErr})
 		}()
 		return future
 	}
@@ -386,3 +386,121 @@ func TestMemStorageCredentialSearchProof(t *testing.T) {
 
 	return
 }
+
+func TestMemStorageWqlQueries(t *testing.T) {
+	tags := map[string]string{"name": "alice", "~age": "30", "~city": "Bucharest"}
+
+	type args struct {
+		Query string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantMatch bool
+		wantErr   bool
+	}{
+		{"empty-query", args{`{}`}, true, false},
+		{"eq", args{`{"name": "alice"}`}, true, false},
+		{"eq-missing-tag", args{`{"other": "alice"}`}, false, false},
+		{"implicit-and", args{`{"name": "alice", "~age": "31"}`}, false, false},
+		{"neq", args{`{"name": {"$neq": "bob"}}`}, true, false},
+		{"neq-missing-tag", args{`{"other": {"$neq": "bob"}}`}, false, false},
+		{"gt", args{`{"~age": {"$gt": "29"}}`}, true, false},
+		{"gte", args{`{"~age": {"$gte": "30"}}`}, true, false},
+		{"lt", args{`{"~age": {"$lt": "30"}}`}, false, false},
+		{"lte", args{`{"~age": {"$lte": "30"}}`}, true, false},
+		{"like", args{`{"~city": {"$like": "Buc%"}}`}, true, false},
+		{"like-single-char", args{`{"~city": {"$like": "B_charest"}}`}, true, false},
+		{"like-no-match", args{`{"~city": {"$like": "B_rest"}}`}, false, false},
+		{"in", args{`{"name": {"$in": ["bob", "alice"]}}`}, true, false},
+		{"not-in", args{`{"name": {"$in": ["bob"]}}`}, false, false},
+		{"and", args{`{"$and": [{"name": "alice"}, {"~age": "30"}]}`}, true, false},
+		{"or", args{`{"$or": [{"name": "bob"}, {"~age": "30"}]}`}, true, false},
+		{"or-none", args{`{"$or": [{"name": "bob"}, {"~age": "31"}]}`}, false, false},
+		{"not", args{`{"$not": {"name": "bob"}}`}, true, false},
+		{"not-missing-tag", args{`{"$not": {"other": "x"}}`}, true, false},
+		{"nested", args{`{"$or": [{"$and": [{"name": "alice"}, {"$not": {"~age": "30"}}]}, {"~city": {"$like": "%rest"}}]}`}, true, false},
+		{"unknown-operator", args{`{"name": {"$regex": "a.*"}}`}, false, true},
+		{"non-string-value", args{`{"~age": 30}`}, false, true},
+		{"and-not-array", args{`{"$and": {"name": "alice"}}`}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := inMemUtils.MatchWql(tt.args.Query, tags)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MatchWql() error = '%v', wantErr = '%v'", err, tt.wantErr)
+				return
+			}
+			if match != tt.wantMatch {
+				t.Errorf("MatchWql() match = '%v', want = '%v'", match, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestMemStorageSearchOptions(t *testing.T) {
+	customStorage := inMemUtils.NewInMemoryStorage()
+	storageHandle, _, _ := customStorage.Open("search-options", "", "")
+	_, errAdd := customStorage.AddRecord(storageHandle, "type", "id1", []byte("value1"), `{"name":"alice"}`)
+	if errAdd != nil {
+		t.Errorf("AddRecord() error = '%v'", errAdd)
+		return
+	}
+
+	type args struct {
+		Options string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantValue bool
+		wantTags  bool
+		wantFound bool
+	}{
+		{"test-default-options", args{Options: "{}"}, true, false, true},
+		{"test-ids-only", args{Options: `{"retrieveValue": false}`}, false, false, true},
+		{"test-with-tags", args{Options: `{"retrieveTags": true}`}, true, true, true},
+		{"test-count-only", args{Options: `{"retrieveRecords": false, "retrieveTotalCount": true}`}, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh, _, errSearch := customStorage.OpenSearch(storageHandle, "type", `{"name": "alice"}`, tt.args.Options)
+			if errSearch != nil {
+				t.Errorf("OpenSearch() error = '%v'", errSearch)
+				return
+			}
+			defer customStorage.FreeSearch(storageHandle, sh)
+
+			count, _, _ := customStorage.GetSearchTotalCount(storageHandle, sh)
+			if count != 1 {
+				t.Errorf("GetSearchTotalCount() = '%d', want = '1'", count)
+			}
+
+			rh, _, errNext := customStorage.FetchSearchNext(storageHandle, sh)
+			if (errNext == nil) != tt.wantFound {
+				t.Errorf("FetchSearchNext() error = '%v'", errNext)
+				return
+			}
+			if !tt.wantFound {
+				return
+			}
+			defer customStorage.FreeRecord(storageHandle, rh)
+
+			_, _, errValue := customStorage.GetRecordValue(storageHandle, rh)
+			if (errValue == nil) != tt.wantValue {
+				t.Errorf("GetRecordValue() error = '%v', want value = '%v'", errValue, tt.wantValue)
+			}
+			_, _, errTags := customStorage.GetRecordTags(storageHandle, rh)
+			if (errTags == nil) != tt.wantTags {
+				t.Errorf("GetRecordTags() error = '%v', want tags = '%v'", errTags, tt.wantTags)
+			}
+		})
+	}
+
+	_, _, errOptions := customStorage.OpenSearch(storageHandle, "type", "{}", "{")
+	if errOptions == nil {
+		t.Errorf("OpenSearch() with invalid options should fail")
+	}
+}
@@ -0,0 +1,83 @@
+/*
+// ******************************************************************
+// Purpose: typed representation of wallet query language (WQL) queries
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package wql
+
+// Operator is a WQL comparison operator
+type Operator string
+
+const (
+	OpEq   Operator = "$eq" // implicit: {"tag": "value"}
+	OpNeq  Operator = "$neq"
+	OpGt   Operator = "$gt"
+	OpGte  Operator = "$gte"
+	OpLt   Operator = "$lt"
+	OpLte  Operator = "$lte"
+	OpLike Operator = "$like"
+	OpIn   Operator = "$in"
+)
+
+// operators that may be written explicitly in a query
+var tagOperators = map[string]Operator{
+	string(OpNeq):  OpNeq,
+	string(OpGt):   OpGt,
+	string(OpGte):  OpGte,
+	string(OpLt):   OpLt,
+	string(OpLte):  OpLte,
+	string(OpLike): OpLike,
+	string(OpIn):   OpIn,
+}
+
+// Query is a node of a parsed WQL query: *And, *Or, *Not or *Condition
+type Query interface {
+	isQuery()
+}
+
+// And is true when all the sub queries are true. An empty And matches everything.
+type And struct {
+	Queries []Query
+}
+
+// Or is true when at least one of the sub queries is true. An empty Or matches nothing.
+type Or struct {
+	Queries []Query
+}
+
+// Not negates its sub query
+type Not struct {
+	Query Query
+}
+
+// Condition compares a tag with one value, or with a list of values for $in.
+// A condition is false for records that do not have the tag.
+type Condition struct {
+	Tag    string
+	Op     Operator
+	Values []string
+}
+
+// Value returns the operand of single value operators
+func (c *Condition) Value() string {
+	if len(c.Values) == 0 {
+		return ""
+	}
+	return c.Values[0]
+}
+
+// IsPlaintext reports whether the condition targets an unencrypted tag (name prefixed by ~)
+func (c *Condition) IsPlaintext() bool {
+	return len(c.Tag) > 0 && c.Tag[0] == '~'
+}
+
+func (*And) isQuery()       {}
+func (*Or) isQuery()        {}
+func (*Not) isQuery()       {}
+func (*Condition) isQuery() {}
@@ -0,0 +1,251 @@
+/*
+// ******************************************************************
+// Purpose: compiles parsed WQL queries for the wallet storage backends
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package wql
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Compiler translates a query into a backend specific representation.
+// Compile walks the query bottom-up, so And/Or/Not receive the already compiled operands.
+type Compiler interface {
+	And(operands []interface{}) (interface{}, error)
+	Or(operands []interface{}) (interface{}, error)
+	Not(operand interface{}) (interface{}, error)
+	Condition(c *Condition) (interface{}, error)
+}
+
+// Compile compiles the query with the given compiler
+func Compile(q Query, c Compiler) (interface{}, error) {
+	switch n := q.(type) {
+	case *And:
+		operands, err := compileAll(n.Queries, c)
+		if err != nil {
+			return nil, err
+		}
+		return c.And(operands)
+	case *Or:
+		operands, err := compileAll(n.Queries, c)
+		if err != nil {
+			return nil, err
+		}
+		return c.Or(operands)
+	case *Not:
+		operand, err := Compile(n.Query, c)
+		if err != nil {
+			return nil, err
+		}
+		return c.Not(operand)
+	case *Condition:
+		return c.Condition(n)
+	default:
+		return nil, fmt.Errorf("wql: unsupported query node %T", q)
+	}
+}
+
+func compileAll(queries []Query, c Compiler) ([]interface{}, error) {
+	operands := make([]interface{}, 0, len(queries))
+	for _, q := range queries {
+		operand, err := Compile(q, c)
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+	return operands, nil
+}
+
+// SqlFragment is a where clause with ? placeholders and its arguments
+type SqlFragment struct {
+	Clause string
+	Args   []interface{}
+}
+
+// SqlCompiler compiles a query to a where clause on the items table.
+// Every condition becomes a sub select on the tags table returned by TagsTable.
+type SqlCompiler struct {
+	ItemIdColumn string                      // column of the items table matched against the tags item_id, defaults to "id"
+	TagsTable    func(plaintext bool) string // (schema qualified) tags table for plaintext / encrypted tags
+}
+
+// CompileSql compiles an already parsed query to a where clause
+func (s *SqlCompiler) CompileSql(q Query) (SqlFragment, error) {
+	res, err := Compile(q, s)
+	if err != nil {
+		return SqlFragment{}, err
+	}
+	return res.(SqlFragment), nil
+}
+
+func (s *SqlCompiler) join(operands []interface{}, sep string, empty string) (interface{}, error) {
+	if len(operands) == 0 {
+		return SqlFragment{Clause: empty}, nil
+	}
+	clauses := make([]string, 0, len(operands))
+	var args []interface{}
+	for _, o := range operands {
+		f := o.(SqlFragment)
+		clauses = append(clauses, f.Clause)
+		args = append(args, f.Args...)
+	}
+	return SqlFragment{Clause: "(" + strings.Join(clauses, sep) + ")", Args: args}, nil
+}
+
+func (s *SqlCompiler) And(operands []interface{}) (interface{}, error) {
+	return s.join(operands, " AND ", "TRUE")
+}
+
+func (s *SqlCompiler) Or(operands []interface{}) (interface{}, error) {
+	return s.join(operands, " OR ", "FALSE")
+}
+
+func (s *SqlCompiler) Not(operand interface{}) (interface{}, error) {
+	f := operand.(SqlFragment)
+	return SqlFragment{Clause: "(NOT " + f.Clause + ")", Args: f.Args}, nil
+}
+
+func (s *SqlCompiler) Condition(c *Condition) (interface{}, error) {
+	column := s.ItemIdColumn
+	if len(column) == 0 {
+		column = "id"
+	}
+
+	var valueClause string
+	args := []interface{}{c.Tag}
+	switch c.Op {
+	case OpEq:
+		valueClause = "value = ?"
+	case OpNeq:
+		valueClause = "value != ?"
+	case OpGt:
+		valueClause = "value > ?"
+	case OpGte:
+		valueClause = "value >= ?"
+	case OpLt:
+		valueClause = "value < ?"
+	case OpLte:
+		valueClause = "value <= ?"
+	case OpLike:
+		valueClause = "value LIKE ?"
+	case OpIn:
+		if len(c.Values) == 0 {
+			return SqlFragment{Clause: "FALSE"}, nil
+		}
+		valueClause = "value IN (" + strings.TrimSuffix(strings.Repeat("?, ", len(c.Values)), ", ") + ")"
+	default:
+		return nil, fmt.Errorf("wql: unsupported operator %s", c.Op)
+	}
+
+	if c.Op == OpIn {
+		for _, v := range c.Values {
+			args = append(args, v)
+		}
+	} else {
+		args = append(args, c.Value())
+	}
+
+	clause := fmt.Sprintf("(%s IN (SELECT item_id FROM %s WHERE name = ? AND %s))", column, s.TagsTable(c.IsPlaintext()), valueClause)
+	return SqlFragment{Clause: clause, Args: args}, nil
+}
+
+// TagsEvaluator evaluates a query against the tags of one record
+type TagsEvaluator struct {
+	Tags map[string]string
+}
+
+// Match reports whether the tags satisfy the query
+func Match(q Query, tags map[string]string) (bool, error) {
+	res, err := Compile(q, &TagsEvaluator{Tags: tags})
+	if err != nil {
+		return false, err
+	}
+	return res.(bool), nil
+}
+
+func (t *TagsEvaluator) And(operands []interface{}) (interface{}, error) {
+	for _, o := range operands {
+		if !o.(bool) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (t *TagsEvaluator) Or(operands []interface{}) (interface{}, error) {
+	for _, o := range operands {
+		if o.(bool) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (t *TagsEvaluator) Not(operand interface{}) (interface{}, error) {
+	return !operand.(bool), nil
+}
+
+func (t *TagsEvaluator) Condition(c *Condition) (interface{}, error) {
+	tagValue, found := t.Tags[c.Tag]
+	if !found {
+		return false, nil
+	}
+
+	switch c.Op {
+	case OpEq:
+		return tagValue == c.Value(), nil
+	case OpNeq:
+		return tagValue != c.Value(), nil
+	case OpGt:
+		return tagValue > c.Value(), nil
+	case OpGte:
+		return tagValue >= c.Value(), nil
+	case OpLt:
+		return tagValue < c.Value(), nil
+	case OpLte:
+		return tagValue <= c.Value(), nil
+	case OpLike:
+		re, err := LikeToRegexp(c.Value())
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString(tagValue), nil
+	case OpIn:
+		for _, v := range c.Values {
+			if tagValue == v {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return nil, fmt.Errorf("wql: unsupported operator %s", c.Op)
+	}
+}
+
+// LikeToRegexp converts an sql LIKE pattern (% and _ wildcards) to an anchored regexp
+func LikeToRegexp(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			sb.WriteString("(?s:.*)")
+		case '_':
+			sb.WriteString("(?s:.)")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
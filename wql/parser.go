@@ -0,0 +1,208 @@
+/*
+// ******************************************************************
+// Purpose: parses and validates wallet query language (WQL) queries
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package wql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrInvalidJson     = errors.New("invalid json")
+	ErrInvalidQuery    = errors.New("invalid query")
+	ErrUnknownOperator = errors.New("unknown operator")
+	ErrInvalidValue    = errors.New("invalid value")
+)
+
+// Error describes where and why a query was rejected
+type Error struct {
+	Path string // location in the query, e.g. $.$or[1].name
+	Err  error  // one of ErrInvalidJson, ErrInvalidQuery, ErrUnknownOperator, ErrInvalidValue
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("wql: %s at %s: %s", e.Err.Error(), e.Path, e.Msg)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Parse parses a WQL query json. An empty string or {} yields an empty And that matches every record.
+func Parse(queryJson string) (Query, error) {
+	if len(strings.TrimSpace(queryJson)) == 0 {
+		return &And{}, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(queryJson)))
+	decoder.UseNumber()
+
+	var raw interface{}
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, &Error{Path: "$", Err: ErrInvalidJson, Msg: err.Error()}
+	}
+	if decoder.More() {
+		return nil, &Error{Path: "$", Err: ErrInvalidJson, Msg: "unexpected data after the query"}
+	}
+
+	return parseObject(raw, "$")
+}
+
+func parseObject(raw interface{}, path string) (Query, error) {
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, &Error{Path: path, Err: ErrInvalidQuery, Msg: "expected an object"}
+	}
+
+	// sorted so the same query always compiles to the same output
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var queries []Query
+	for _, key := range keys {
+		keyPath := path + "." + key
+		var q Query
+		var err error
+
+		switch key {
+		case "$and", "$or":
+			var list []Query
+			list, err = parseList(obj[key], keyPath)
+			if key == "$and" {
+				q = &And{Queries: list}
+			} else {
+				q = &Or{Queries: list}
+			}
+		case "$not":
+			var sub Query
+			sub, err = parseObject(obj[key], keyPath)
+			q = &Not{Query: sub}
+		default:
+			if strings.HasPrefix(key, "$") {
+				return nil, &Error{Path: keyPath, Err: ErrUnknownOperator, Msg: fmt.Sprintf("'%s' is not a logical operator", key)}
+			}
+			q, err = parseCondition(key, obj[key], keyPath)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+
+	if len(queries) == 1 {
+		return queries[0], nil
+	}
+	return &And{Queries: queries}, nil
+}
+
+func parseList(raw interface{}, path string) ([]Query, error) {
+	list, ok := raw.([]interface{})
+	if !ok {
+		return nil, &Error{Path: path, Err: ErrInvalidQuery, Msg: "expected an array of queries"}
+	}
+
+	queries := make([]Query, 0, len(list))
+	for i, item := range list {
+		q, err := parseObject(item, fmt.Sprintf("%s[%d]", path, i))
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+	return queries, nil
+}
+
+func parseCondition(tag string, raw interface{}, path string) (Query, error) {
+	if strings.TrimPrefix(tag, "~") == "" {
+		return nil, &Error{Path: path, Err: ErrInvalidQuery, Msg: "empty tag name"}
+	}
+
+	switch v := raw.(type) {
+	case string:
+		return &Condition{Tag: tag, Op: OpEq, Values: []string{v}}, nil
+	case map[string]interface{}:
+		if len(v) != 1 {
+			return nil, &Error{Path: path, Err: ErrInvalidQuery, Msg: "expected exactly one operator"}
+		}
+		for name, operand := range v {
+			opPath := path + "." + name
+			op, ok := tagOperators[name]
+			if !ok {
+				return nil, &Error{Path: opPath, Err: ErrUnknownOperator, Msg: fmt.Sprintf("'%s' is not a tag operator", name)}
+			}
+
+			if op == OpIn {
+				values, err := parseStrings(operand, opPath)
+				if err != nil {
+					return nil, err
+				}
+				return &Condition{Tag: tag, Op: op, Values: values}, nil
+			}
+
+			value, ok := operand.(string)
+			if !ok {
+				return nil, &Error{Path: opPath, Err: ErrInvalidValue, Msg: fmt.Sprintf("expected a string, got %s", typeName(operand))}
+			}
+			if (op == OpGt || op == OpGte || op == OpLt || op == OpLte || op == OpLike) && !strings.HasPrefix(tag, "~") {
+				return nil, &Error{Path: opPath, Err: ErrInvalidQuery, Msg: fmt.Sprintf("'%s' is only allowed on unencrypted (~) tags", name)}
+			}
+			return &Condition{Tag: tag, Op: op, Values: []string{value}}, nil
+		}
+	}
+
+	return nil, &Error{Path: path, Err: ErrInvalidValue, Msg: fmt.Sprintf("expected a string or an operator object, got %s", typeName(raw))}
+}
+
+func parseStrings(raw interface{}, path string) ([]string, error) {
+	list, ok := raw.([]interface{})
+	if !ok {
+		return nil, &Error{Path: path, Err: ErrInvalidValue, Msg: fmt.Sprintf("expected an array of strings, got %s", typeName(raw))}
+	}
+
+	values := make([]string, 0, len(list))
+	for i, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			return nil, &Error{Path: fmt.Sprintf("%s[%d]", path, i), Err: ErrInvalidValue, Msg: fmt.Sprintf("expected a string, got %s", typeName(item))}
+		}
+		values = append(values, s)
+	}
+	return values, nil
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
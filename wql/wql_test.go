@@ -0,0 +1,180 @@
+/*
+// ******************************************************************
+// Purpose: wql parser and compilers unit testing
+// Author:  alexandru.leonte@siemens.com
+// Notes:
+// Copyright (c): Siemens SRL
+// This work is licensed under the terms of the Apache License Version 2.0.  See
+// the LICENSE.txt file in the top-level directory.
+// ******************************************************************
+*/
+
+package wql
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	type args struct {
+		Query string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    Query
+		wantErr error
+	}{
+		{"empty", args{``}, &And{}, nil},
+		{"empty-object", args{`{}`}, &And{}, nil},
+		{"eq", args{`{"name": "alice"}`}, &Condition{Tag: "name", Op: OpEq, Values: []string{"alice"}}, nil},
+		{"implicit-and", args{`{"b": "2", "a": "1"}`}, &And{Queries: []Query{
+			&Condition{Tag: "a", Op: OpEq, Values: []string{"1"}},
+			&Condition{Tag: "b", Op: OpEq, Values: []string{"2"}},
+		}}, nil},
+		{"in", args{`{"a": {"$in": ["1", "2"]}}`}, &Condition{Tag: "a", Op: OpIn, Values: []string{"1", "2"}}, nil},
+		{"not", args{`{"$not": {"a": {"$neq": "1"}}}`}, &Not{Query: &Condition{Tag: "a", Op: OpNeq, Values: []string{"1"}}}, nil},
+		{"or", args{`{"$or": [{"~a": {"$gt": "1"}}, {"~a": {"$like": "x%"}}]}`}, &Or{Queries: []Query{
+			&Condition{Tag: "~a", Op: OpGt, Values: []string{"1"}},
+			&Condition{Tag: "~a", Op: OpLike, Values: []string{"x%"}},
+		}}, nil},
+		{"invalid-json", args{`{"a": `}, nil, ErrInvalidJson},
+		{"not-an-object", args{`["a"]`}, nil, ErrInvalidQuery},
+		{"unknown-logical-operator", args{`{"$xor": []}`}, nil, ErrUnknownOperator},
+		{"unknown-tag-operator", args{`{"a": {"$regex": "x"}}`}, nil, ErrUnknownOperator},
+		{"number-value", args{`{"a": 1}`}, nil, ErrInvalidValue},
+		{"number-operand", args{`{"a": {"$neq": 1}}`}, nil, ErrInvalidValue},
+		{"number-in-list", args{`{"a": {"$in": ["1", 2]}}`}, nil, ErrInvalidValue},
+		{"two-operators", args{`{"a": {"$neq": "1", "$gt": "2"}}`}, nil, ErrInvalidQuery},
+		{"and-not-array", args{`{"$and": {"a": "1"}}`}, nil, ErrInvalidQuery},
+		{"range-on-encrypted-tag", args{`{"a": {"$gt": "1"}}`}, nil, ErrInvalidQuery},
+		{"empty-tag-name", args{`{"": {"$gt": "1"}}`}, nil, ErrInvalidQuery},
+		{"empty-unencrypted-tag-name", args{`{"~": "1"}`}, nil, ErrInvalidQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.args.Query)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = '%v', wantErr = '%v'", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = '%#v', want = '%#v'", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrorPath(t *testing.T) {
+	_, err := Parse(`{"$or": [{"a": "1"}, {"b": {"$in": ["x", true]}}]}`)
+
+	var wqlErr *Error
+	if !errors.As(err, &wqlErr) {
+		t.Errorf("Parse() error = '%v', want *Error", err)
+		return
+	}
+	if wqlErr.Path != "$.$or[1].b.$in[1]" {
+		t.Errorf("Parse() error path = '%s', want = '%s'", wqlErr.Path, "$.$or[1].b.$in[1]")
+	}
+}
+
+func TestSqlCompiler(t *testing.T) {
+	compiler := SqlCompiler{
+		TagsTable: func(plaintext bool) string {
+			if plaintext {
+				return "tp"
+			}
+			return "te"
+		},
+	}
+
+	type args struct {
+		Query string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantClause string
+		wantArgs   []interface{}
+	}{
+		{"empty", args{`{}`}, "TRUE", nil},
+		{"eq", args{`{"a": "1"}`}, "(id IN (SELECT item_id FROM te WHERE name = ? AND value = ?))", []interface{}{"a", "1"}},
+		{"plaintext", args{`{"~a": {"$lte": "1"}}`}, "(id IN (SELECT item_id FROM tp WHERE name = ? AND value <= ?))", []interface{}{"~a", "1"}},
+		{"in", args{`{"a": {"$in": ["1", "2"]}}`}, "(id IN (SELECT item_id FROM te WHERE name = ? AND value IN (?, ?)))", []interface{}{"a", "1", "2"}},
+		{"empty-in", args{`{"a": {"$in": []}}`}, "FALSE", nil},
+		{"not", args{`{"$not": {"a": "1"}}`}, "(NOT (id IN (SELECT item_id FROM te WHERE name = ? AND value = ?)))", []interface{}{"a", "1"}},
+		{"and-or", args{`{"$or": [{"a": "1"}, {"b": "2"}], "c": "3"}`},
+			"(((id IN (SELECT item_id FROM te WHERE name = ? AND value = ?)) OR (id IN (SELECT item_id FROM te WHERE name = ? AND value = ?))) AND " +
+				"(id IN (SELECT item_id FROM te WHERE name = ? AND value = ?)))",
+			[]interface{}{"a", "1", "b", "2", "c", "3"}},
+		{"empty-or", args{`{"$or": []}`}, "FALSE", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.args.Query)
+			if err != nil {
+				t.Errorf("Parse() error = '%v'", err)
+				return
+			}
+			got, err := compiler.CompileSql(q)
+			if err != nil {
+				t.Errorf("CompileSql() error = '%v'", err)
+				return
+			}
+			if got.Clause != tt.wantClause {
+				t.Errorf("CompileSql() clause = '%s', want = '%s'", got.Clause, tt.wantClause)
+			}
+			if !reflect.DeepEqual(got.Args, tt.wantArgs) {
+				t.Errorf("CompileSql() args = '%v', want = '%v'", got.Args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tags := map[string]string{"name": "alice", "~age": "30", "~city": "Bucharest"}
+
+	type args struct {
+		Query string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantMatch bool
+	}{
+		{"empty", args{`{}`}, true},
+		{"eq", args{`{"name": "alice"}`}, true},
+		{"eq-missing-tag", args{`{"other": "alice"}`}, false},
+		{"neq-missing-tag", args{`{"other": {"$neq": "alice"}}`}, false},
+		{"gt", args{`{"~age": {"$gt": "29"}}`}, true},
+		{"lt", args{`{"~age": {"$lt": "30"}}`}, false},
+		{"like", args{`{"~city": {"$like": "%rest"}}`}, true},
+		{"like-special-chars", args{`{"~city": {"$like": "Buch.rest"}}`}, false},
+		{"in", args{`{"name": {"$in": ["bob", "alice"]}}`}, true},
+		{"not-missing-tag", args{`{"$not": {"other": "x"}}`}, true},
+		{"or", args{`{"$or": [{"name": "bob"}, {"~age": "30"}]}`}, true},
+		{"and", args{`{"$and": [{"name": "bob"}, {"~age": "30"}]}`}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.args.Query)
+			if err != nil {
+				t.Errorf("Parse() error = '%v'", err)
+				return
+			}
+			match, err := Match(q, tags)
+			if err != nil {
+				t.Errorf("Match() error = '%v'", err)
+				return
+			}
+			if match != tt.wantMatch {
+				t.Errorf("Match() = '%v', want = '%v'", match, tt.wantMatch)
+			}
+		})
+	}
+}